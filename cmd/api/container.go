@@ -4,13 +4,17 @@ import (
 	"context"
 
 	"github.com/daochanio/backend/cmd/api/http"
+	"github.com/daochanio/backend/cmd/api/push"
 	"github.com/daochanio/backend/cmd/api/subscribe"
+	"github.com/daochanio/backend/cmd/api/webhook"
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/usecases"
 	"github.com/daochanio/backend/gateways/ethereum"
 	"github.com/daochanio/backend/gateways/images"
 	"github.com/daochanio/backend/gateways/postgres"
 	"github.com/daochanio/backend/gateways/redis"
+	"github.com/daochanio/backend/gateways/webhooks"
+	"github.com/daochanio/backend/gateways/webpush"
 	"go.uber.org/dig"
 )
 
@@ -56,6 +60,12 @@ func provideGateways(container *dig.Container) {
 	if err := container.Provide(ethereum.NewEthereumGateway); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(webhooks.NewWebhooksGateway); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(webpush.NewWebPushGateway); err != nil {
+		panic(err)
+	}
 }
 
 func provideUseCases(container *dig.Container) {
@@ -83,18 +93,42 @@ func provideUseCases(container *dig.Container) {
 	if err := container.Provide(usecases.NewDeleteThreadUseCase); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewEditThreadUseCase); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(usecases.NewCreateVoteUseCase); err != nil {
 		panic(err)
 	}
 	if err := container.Provide(usecases.NewGetCommentsUseCase); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewGetCommentTreeUseCase); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(usecases.NewCreateCommentUseCase); err != nil {
 		panic(err)
 	}
 	if err := container.Provide(usecases.NewDeleteCommentUseCase); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewEditCommentUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetRevisionsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewSearchUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetBoardsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetBoardUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewCreateBoardUseCase); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(usecases.NewUploadImageUsecase); err != nil {
 		panic(err)
 	}
@@ -107,6 +141,126 @@ func provideUseCases(container *dig.Container) {
 	if err := container.Provide(usecases.NewGetUserUseCase); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewGetUserThreadsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetUserCommentsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetRolesUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGrantRoleUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewRevokeRoleUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewSeedAdminsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewCreateReportUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetReportsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewResolveReportsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetBanUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewBanUserUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewUnbanUserUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetModerationLogUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewRestoreThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewRestoreCommentUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewLockThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewPinThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewArchiveThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewReadThreadEventsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetThreadEventsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewViewThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewLeaveThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewCreateNotificationsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetNotificationsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewCountUnreadNotificationsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewReadNotificationsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewSubscribeToThreadUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewCreateWebhookUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetWebhooksUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewDeleteWebhookUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewEnableWebhookUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetWebhookDeliveriesUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewDeliverWebhooksUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewRegisterPushSubscriptionUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewDeletePushSubscriptionUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewSendPushNotificationsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetClaimUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetDistributionsUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetDistributionUseCase); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(usecases.NewGetUserAllocationsUseCase); err != nil {
+		panic(err)
+	}
 }
 
 func provideControllers(container *dig.Container) {
@@ -116,4 +270,10 @@ func provideControllers(container *dig.Container) {
 	if err := container.Provide(subscribe.NewSubscriber); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(webhook.NewDeliverer); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(push.NewSender); err != nil {
+		panic(err)
+	}
 }
@@ -3,10 +3,12 @@ package http
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"strings"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/usecases"
 )
 
@@ -39,8 +41,72 @@ func (h *httpServer) authentication(next http.Handler) http.Handler {
 			return
 		}
 
+		ban, err := h.getBan.Execute(ctx, usecases.GetBanInput{
+			Address: address,
+		})
+
+		if err != nil {
+			h.presentUnathorized(w, r, err)
+			return
+		}
+
+		// shadow banned users are let through and only have their posts filtered for everyone else
+		if ban != nil && ban.Type() == entities.HardBan {
+			h.presentForbidden(w, r, fmt.Errorf("address %v is banned", address))
+			return
+		}
+
+		ctx = context.WithValue(ctx, common.ContextKeyUser, user)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Public routes that render user specific state accept a token without requiring one.
+// Missing or invalid tokens are served anonymously rather than rejected.
+func (h *httpServer) optionalAuthentication(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		token := strings.Split(r.Header.Get("Authorization"), " ")
+
+		if len(token) != 2 || token[0] != "Bearer" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		address, err := h.authenticate.Execute(ctx, &usecases.AuthenticateInput{
+			Token:     token[1],
+			JWTSecret: h.config.JWTSecret,
+		})
+
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		user, err := h.getUser.Execute(ctx, usecases.GetUserInput{
+			Address: address,
+		})
+
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		ctx = context.WithValue(ctx, common.ContextKeyUser, user)
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// the address of the authenticated user, nil if anonymous
+func viewer(r *http.Request) *string {
+	user, ok := r.Context().Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		return nil
+	}
+
+	address := user.Address()
+	return &address
+}
@@ -0,0 +1,54 @@
+package http
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+// Attaches the roles of the authenticated user to the user in context and
+// ensures the user holds one of the given roles globally before proceeding.
+// Without roles any authenticated user may proceed, which lets usecases check board scoped roles.
+func (h *httpServer) authorization(roles ...entities.RoleType) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+			if !ok {
+				h.presentForbidden(w, r, fmt.Errorf("invalid user"))
+				return
+			}
+
+			userRoles, err := h.getRoles.Execute(ctx, usecases.GetRolesInput{
+				Address: user.Address(),
+			})
+
+			if err != nil {
+				h.presentForbidden(w, r, err)
+				return
+			}
+
+			user.SetRoles(userRoles)
+			ctx = context.WithValue(ctx, common.ContextKeyUser, user)
+
+			if len(roles) == 0 {
+				next.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			for _, role := range roles {
+				if user.HasRole(role, nil) {
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
+				}
+			}
+
+			h.presentForbidden(w, r, fmt.Errorf("%v role required", roles[0]))
+		})
+	}
+}
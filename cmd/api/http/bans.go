@@ -0,0 +1,80 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) banUserRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[banJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err = h.banUser.Execute(ctx, usecases.BanUserInput{
+		Address:       chi.URLParam(r, "address"),
+		Type:          entities.BanType(body.Type),
+		Reason:        body.Reason,
+		ExpiresAt:     body.ExpiresAt,
+		IssuerAddress: user.Address(),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusCreated)
+}
+
+func (h *httpServer) unbanUserRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err := h.unbanUser.Execute(ctx, usecases.UnbanUserInput{
+		Address:        chi.URLParam(r, "address"),
+		RevokerAddress: user.Address(),
+		Reason:         r.URL.Query().Get("reason"),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+type banJson struct {
+	Type   string `json:"type"`
+	Reason string `json:"reason"`
+	// omitted for a permanent ban
+	ExpiresAt *time.Time `json:"expiresAt"`
+}
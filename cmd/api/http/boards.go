@@ -0,0 +1,117 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) getBoardsRoute(w http.ResponseWriter, r *http.Request) {
+	boards, err := h.getBoards.Execute(r.Context())
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toBoardsJson(boards), nil)
+}
+
+func (h *httpServer) getBoardRoute(w http.ResponseWriter, r *http.Request) {
+	board, err := h.getBoard.Execute(r.Context(), usecases.GetBoardInput{
+		Slug: chi.URLParam(r, "slug"),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toBoardJson(board), nil)
+}
+
+func (h *httpServer) createBoardRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[createBoardJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	board, err := h.createBoard.Execute(ctx, usecases.CreateBoardInput{
+		Address:       user.Address(),
+		Slug:          body.Slug,
+		Title:         body.Title,
+		Description:   body.Description,
+		Rules:         body.Rules,
+		ImageFileName: body.ImageFileName,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusCreated, toBoardJson(board), nil)
+}
+
+type createBoardJson struct {
+	Slug          string `json:"slug"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	Rules         string `json:"rules"`
+	ImageFileName string `json:"imageFileName"`
+}
+
+type boardJson struct {
+	Id          string     `json:"id"`
+	Slug        string     `json:"slug"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Rules       string     `json:"rules"`
+	CreatedBy   string     `json:"createdBy"`
+	Image       *imageJson `json:"image,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+func toBoardJson(board entities.Board) boardJson {
+	return boardJson{
+		Id:          fmt.Sprint(board.Id()),
+		Slug:        board.Slug(),
+		Title:       board.Title(),
+		Description: board.Description(),
+		Rules:       board.Rules(),
+		CreatedBy:   board.CreatedBy(),
+		Image:       toImageJson(board.Image()),
+		CreatedAt:   board.CreatedAt(),
+	}
+}
+
+func toBoardsJson(boards []entities.Board) []boardJson {
+	json := make([]boardJson, len(boards))
+
+	for i, board := range boards {
+		json[i] = toBoardJson(board)
+	}
+
+	return json
+}
@@ -28,10 +28,19 @@ func (h *httpServer) getCommentsRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	comments, count, err := h.getComments.Execute(r.Context(), usecases.GetCommentsInput{
+	offset, cursor, err := h.getCommentsPagination(r, page)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	comments, commentsPage, err := h.getComments.Execute(r.Context(), usecases.GetCommentsInput{
 		ThreadId: threadId,
-		Offset:   page.Offset,
+		Offset:   offset,
+		Cursor:   cursor,
 		Limit:    page.Limit,
+		Viewer:   viewer(r),
 	})
 
 	if err != nil {
@@ -39,11 +48,139 @@ func (h *httpServer) getCommentsRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	page.Count = count
+	if err := h.setCommentsPage(&page, commentsPage); err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
 
 	h.presentJSON(w, r, http.StatusOK, toCommentsJson(comments), &page)
 }
 
+// Returns the top level comments of a thread or the replies to a comment as trees.
+// Replies beyond the depth or reply limits are loaded from the replies route of their parent.
+func (h *httpServer) getCommentTreeRoute(w http.ResponseWriter, r *http.Request) {
+	threadId, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	var parentId *int64
+	if commentId := chi.URLParam(r, "commentId"); commentId != "" {
+		id, err := strconv.ParseInt(commentId, 10, 64)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		parentId = &id
+	}
+
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	cursor, err := h.toCommentCursor(page.Cursor)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	depthStr := r.URL.Query().Get("depth")
+	if depthStr == "" {
+		depthStr = "3"
+	}
+	depth, err := strconv.ParseInt(depthStr, 10, 32)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid depth: %w", err))
+		return
+	}
+
+	repliesStr := r.URL.Query().Get("replies")
+	if repliesStr == "" {
+		repliesStr = "5"
+	}
+	replies, err := strconv.ParseInt(repliesStr, 10, 64)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid replies: %w", err))
+		return
+	}
+
+	comments, nextCursor, err := h.getCommentTree.Execute(r.Context(), usecases.GetCommentTreeInput{
+		ThreadId:   threadId,
+		ParentId:   parentId,
+		Cursor:     cursor,
+		Limit:      page.Limit,
+		MaxDepth:   int32(depth),
+		ReplyLimit: replies,
+		Viewer:     viewer(r),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.NextCursor, err = h.toCommentCursorString(nextCursor)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	json, err := h.toCommentTreesJson(comments)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, json, &page)
+}
+
+// Comments are cursor paginated unless an offset is explicitly requested by an older client
+func (h *httpServer) getCommentsPagination(r *http.Request, page pageJson) (*int64, *entities.CommentCursor, error) {
+	if r.URL.Query().Has("offset") {
+		return &page.Offset, nil, nil
+	}
+
+	cursor, err := h.toCommentCursor(page.Cursor)
+
+	return nil, cursor, err
+}
+
+func (h *httpServer) setCommentsPage(page *pageJson, commentsPage usecases.CommentsPage) error {
+	page.Count = commentsPage.Count
+
+	next, err := h.toCommentCursorString(commentsPage.Next)
+
+	if err != nil {
+		return err
+	}
+
+	prev, err := h.toCommentCursorString(commentsPage.Prev)
+
+	if err != nil {
+		return err
+	}
+
+	page.NextCursor = next
+	page.PrevCursor = prev
+
+	return nil
+}
+
 func (h *httpServer) createCommentRoute(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -87,6 +224,16 @@ func (h *httpServer) createCommentRoute(w http.ResponseWriter, r *http.Request)
 		ImageFileName:      body.ImageFileName,
 	})
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrThreadLocked) || errors.Is(err, common.ErrThreadArchived) {
+		h.presentConflict(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, fmt.Errorf("invalid comment: %w", err))
 		return
@@ -95,6 +242,55 @@ func (h *httpServer) createCommentRoute(w http.ResponseWriter, r *http.Request)
 	h.presentJSON(w, r, http.StatusCreated, toCommentJson(comment), nil)
 }
 
+func (h *httpServer) editCommentRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(chi.URLParam(r, "commentId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	body, err := common.Decode[editCommentJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	comment, err := h.editComment.Execute(ctx, usecases.EditCommentInput{
+		CommentId:     id,
+		EditorAddress: user.Address(),
+		Content:       body.Content,
+		ImageFileName: body.ImageFileName,
+		Window:        h.config.EditWindow,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toCommentJson(comment), nil)
+}
+
 func (h *httpServer) deleteCommentRoute(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
@@ -115,6 +311,8 @@ func (h *httpServer) deleteCommentRoute(w http.ResponseWriter, r *http.Request)
 	err = h.deleteComment.Execute(ctx, usecases.DeleteCommentInput{
 		Id:             id,
 		DeleterAddress: user.Address(),
+		DeleterRoles:   user.Roles(),
+		Reason:         r.URL.Query().Get("reason"),
 	})
 
 	if errors.Is(err, common.ErrNotFound) {
@@ -122,6 +320,11 @@ func (h *httpServer) deleteCommentRoute(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
@@ -155,6 +358,11 @@ func (h *httpServer) createCommentVoteRoute(w http.ResponseWriter, r *http.Reque
 		Type:    entities.CommentVote,
 	})
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
@@ -163,6 +371,54 @@ func (h *httpServer) createCommentVoteRoute(w http.ResponseWriter, r *http.Reque
 	h.presentStatus(w, r, http.StatusCreated)
 }
 
+func (h *httpServer) restoreCommentRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "commentId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	body, err := common.Decode[restoreJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	comment, err := h.restoreComment.Execute(ctx, usecases.RestoreCommentInput{
+		Id:              id,
+		RestorerAddress: user.Address(),
+		RestorerRoles:   user.Roles(),
+		Reason:          body.Reason,
+		Window:          h.config.RestoreWindow,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toCommentJson(comment), nil)
+}
+
 type createCommentJson struct {
 	RepliedToCommentId *string `json:"repliedToCommentId,omitempty"`
 	ThreadId           string  `json:"threadId"`
@@ -170,17 +426,29 @@ type createCommentJson struct {
 	ImageFileName      string  `json:"imageFileName"`
 }
 
+// omitted fields are left unchanged
+type editCommentJson struct {
+	Content       *string `json:"content,omitempty"`
+	ImageFileName *string `json:"imageFileName,omitempty"`
+}
+
 type commentJson struct {
-	Id               string       `json:"id"`
-	RepliedToComment *commentJson `json:"repliedToComment,omitempty"`
-	ThreadId         string       `json:"threadId,omitempty"` // empty if reply
-	Content          string       `json:"content"`
-	Image            *imageJson   `json:"image,omitempty"` // empty if comment deleted
-	User             *userJson    `json:"user,omitempty"`  // empty if replied to comment
-	IsDeleted        bool         `json:"isDeleted"`
-	CreatedAt        time.Time    `json:"createdAt"`
-	DeletedAt        *time.Time   `json:"deletedAt,omitempty"` // empty if comment not deleted
-	Votes            int64        `json:"votes"`               // zero if reply
+	Id               string         `json:"id"`
+	RepliedToComment *commentJson   `json:"repliedToComment,omitempty"`
+	ThreadId         string         `json:"threadId,omitempty"` // empty if reply
+	Content          string         `json:"content"`
+	Image            *imageJson     `json:"image,omitempty"` // empty if comment deleted
+	User             *userJson      `json:"user,omitempty"`  // empty if replied to comment
+	IsDeleted        bool           `json:"isDeleted"`
+	IsHidden         bool           `json:"isHidden"` // hidden comments are left out of pages until reviewed
+	CreatedAt        time.Time      `json:"createdAt"`
+	DeletedAt        *time.Time     `json:"deletedAt,omitempty"`     // empty if comment not deleted
+	EditedAt         *time.Time     `json:"editedAt,omitempty"`      // empty if never edited
+	Votes            int64          `json:"votes"`                   // zero if reply
+	MyVote           *string        `json:"myVote"`                  // null if anonymous or never voted
+	ReplyCount       *int64         `json:"replyCount,omitempty"`    // empty if not in a tree
+	Replies          *[]commentJson `json:"replies,omitempty"`       // empty if not hydrated
+	RepliesCursor    string         `json:"repliesCursor,omitempty"` // empty if all hydrated or none to continue from
 }
 
 func toCommentsJson(comments []entities.Comment) []commentJson {
@@ -202,9 +470,12 @@ func toCommentJson(comment entities.Comment) commentJson {
 		Image:     toImageJson(comment.Image()),
 		User:      &userJson,
 		IsDeleted: comment.IsDeleted(),
+		IsHidden:  comment.IsHidden(),
 		CreatedAt: comment.CreatedAt(),
 		DeletedAt: comment.DeletedAt(),
+		EditedAt:  comment.EditedAt(),
 		Votes:     comment.Votes(),
+		MyVote:    toMyVoteJson(comment.MyVote()),
 	}
 
 	if repliedToComment := comment.RepliedToComment(); repliedToComment != nil {
@@ -221,3 +492,46 @@ func toCommentJson(comment entities.Comment) commentJson {
 
 	return json
 }
+
+func (h *httpServer) toCommentTreesJson(comments []entities.Comment) ([]commentJson, error) {
+	json := make([]commentJson, len(comments))
+
+	for i, comment := range comments {
+		commentJson, err := h.toCommentTreeJson(comment)
+
+		if err != nil {
+			return nil, err
+		}
+
+		json[i] = commentJson
+	}
+
+	return json, nil
+}
+
+func (h *httpServer) toCommentTreeJson(comment entities.Comment) (commentJson, error) {
+	json := toCommentJson(comment)
+
+	replyCount := comment.ReplyCount()
+	json.ReplyCount = &replyCount
+
+	if replies := comment.Replies(); replies != nil {
+		repliesJson, err := h.toCommentTreesJson(*replies)
+
+		if err != nil {
+			return commentJson{}, err
+		}
+
+		json.Replies = &repliesJson
+	}
+
+	repliesCursor, err := h.toCommentCursorString(comment.RepliesCursor())
+
+	if err != nil {
+		return commentJson{}, err
+	}
+
+	json.RepliesCursor = repliesCursor
+
+	return json, nil
+}
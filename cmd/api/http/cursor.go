@@ -0,0 +1,228 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+// Cursors are opaque to clients so we are free to change the underlying keys without breaking them.
+// They are base64 encoded json of the sort keys of an item at the edge of a page,
+// signed so clients cannot forge cursors pointing at arbitrary keys.
+func (h *httpServer) encodeCursor(v any) (string, error) {
+	data, err := json.Marshal(v)
+
+	if err != nil {
+		return "", fmt.Errorf("error encoding cursor: %w", err)
+	}
+
+	payload := base64.RawURLEncoding.EncodeToString(data)
+	signature := base64.RawURLEncoding.EncodeToString(h.signCursor(payload))
+
+	return payload + "." + signature, nil
+}
+
+func decodeCursor[T any](h *httpServer, cursor string) (*T, error) {
+	payload, signature, ok := strings.Cut(cursor, ".")
+
+	if !ok {
+		return nil, errors.New("invalid cursor format")
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor signature encoding: %w", err)
+	}
+
+	if !hmac.Equal(mac, h.signCursor(payload)) {
+		return nil, errors.New("invalid cursor signature")
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(payload)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	out := new(T)
+	if err := json.Unmarshal(data, out); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return out, nil
+}
+
+func (h *httpServer) signCursor(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(h.config.CursorSecret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+type threadCursorJson struct {
+	Id        int64     `json:"i"`
+	HotScore  float64   `json:"h"`
+	Votes     int64     `json:"v"`
+	CreatedAt time.Time `json:"c"`
+	ActiveAt  time.Time `json:"a"`
+	Since     time.Time `json:"s"`
+}
+
+func (h *httpServer) toThreadCursor(cursor string) (*entities.ThreadCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	json, err := decodeCursor[threadCursorJson](h, cursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	threadCursor := entities.NewThreadCursor(entities.ThreadCursorParams{
+		Id:        json.Id,
+		HotScore:  json.HotScore,
+		Votes:     json.Votes,
+		CreatedAt: json.CreatedAt,
+		ActiveAt:  json.ActiveAt,
+		Since:     json.Since,
+	})
+
+	return &threadCursor, nil
+}
+
+func (h *httpServer) toThreadCursorString(cursor *entities.ThreadCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return h.encodeCursor(threadCursorJson{
+		Id:        cursor.Id(),
+		HotScore:  cursor.HotScore(),
+		Votes:     cursor.Votes(),
+		CreatedAt: cursor.CreatedAt(),
+		ActiveAt:  cursor.ActiveAt(),
+		Since:     cursor.Since(),
+	})
+}
+
+type commentCursorJson struct {
+	Id        int64                    `json:"i"`
+	CreatedAt time.Time                `json:"c"`
+	Direction entities.CursorDirection `json:"d"`
+}
+
+func (h *httpServer) toCommentCursor(cursor string) (*entities.CommentCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	json, err := decodeCursor[commentCursorJson](h, cursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if json.Direction != entities.NextCursorDirection && json.Direction != entities.PrevCursorDirection {
+		return nil, fmt.Errorf("invalid cursor direction: %v", json.Direction)
+	}
+
+	commentCursor := entities.NewCommentCursor(entities.CommentCursorParams{
+		Id:        json.Id,
+		CreatedAt: json.CreatedAt,
+		Direction: json.Direction,
+	})
+
+	return &commentCursor, nil
+}
+
+func (h *httpServer) toCommentCursorString(cursor *entities.CommentCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return h.encodeCursor(commentCursorJson{
+		Id:        cursor.Id(),
+		CreatedAt: cursor.CreatedAt(),
+		Direction: cursor.Direction(),
+	})
+}
+
+type activityCursorJson struct {
+	Id        int64     `json:"i"`
+	Votes     int64     `json:"v"`
+	CreatedAt time.Time `json:"c"`
+}
+
+func (h *httpServer) toActivityCursor(cursor string) (*entities.ActivityCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	json, err := decodeCursor[activityCursorJson](h, cursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	activityCursor := entities.NewActivityCursor(entities.ActivityCursorParams{
+		Id:        json.Id,
+		Votes:     json.Votes,
+		CreatedAt: json.CreatedAt,
+	})
+
+	return &activityCursor, nil
+}
+
+func (h *httpServer) toActivityCursorString(cursor *entities.ActivityCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return h.encodeCursor(activityCursorJson{
+		Id:        cursor.Id(),
+		Votes:     cursor.Votes(),
+		CreatedAt: cursor.CreatedAt(),
+	})
+}
+
+type searchCursorJson struct {
+	Rank float64                   `json:"r"`
+	Kind entities.SearchResultKind `json:"k"`
+	Id   int64                     `json:"i"`
+}
+
+func (h *httpServer) toSearchCursor(cursor string) (*entities.SearchCursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+
+	json, err := decodeCursor[searchCursorJson](h, cursor)
+
+	if err != nil {
+		return nil, err
+	}
+
+	searchCursor := entities.NewSearchCursor(json.Rank, json.Kind, json.Id)
+
+	return &searchCursor, nil
+}
+
+func (h *httpServer) toSearchCursorString(cursor *entities.SearchCursor) (string, error) {
+	if cursor == nil {
+		return "", nil
+	}
+
+	return h.encodeCursor(searchCursorJson{
+		Rank: cursor.Rank(),
+		Kind: cursor.Kind(),
+		Id:   cursor.Id(),
+	})
+}
@@ -0,0 +1,219 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+type distributionJson struct {
+	Id           string           `json:"id"`
+	Round        time.Time        `json:"round"`
+	Votes        int64            `json:"votes"`
+	Amount       string           `json:"amount"`
+	Participants int64            `json:"participants"`
+	MerkleRoot   string           `json:"merkleRoot"`
+	CreatedAt    time.Time        `json:"createdAt"`
+	Allocations  []allocationJson `json:"allocations,omitempty"`
+}
+
+type allocationJson struct {
+	DistributionId string    `json:"distributionId"`
+	Round          time.Time `json:"round"`
+	Address        string    `json:"address"`
+	Amount         string    `json:"amount"`
+	Votes          int64     `json:"votes"`
+}
+
+type earningsJson struct {
+	Address     string           `json:"address"`
+	Amount      string           `json:"amount"`
+	Rounds      int64            `json:"rounds"`
+	Allocations []allocationJson `json:"allocations"`
+}
+
+type claimJson struct {
+	DistributionId string   `json:"distributionId"`
+	Address        string   `json:"address"`
+	Amount         string   `json:"amount"`
+	MerkleRoot     string   `json:"merkleRoot"`
+	Proof          []string `json:"proof"`
+}
+
+func (h *httpServer) getDistributionsRoute(w http.ResponseWriter, r *http.Request) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	distributions, count, err := h.getDistributions.Execute(r.Context(), usecases.GetDistributionsInput{
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	json := []distributionJson{}
+	for _, distribution := range distributions {
+		json = append(json, toDistributionJson(distribution, nil))
+	}
+
+	h.presentJSON(w, r, http.StatusOK, json, &page)
+}
+
+// The page describes the allocations of the round
+func (h *httpServer) getDistributionRoute(w http.ResponseWriter, r *http.Request) {
+	distributionId, err := strconv.ParseInt(chi.URLParam(r, "distributionId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	distribution, allocations, count, err := h.getDistribution.Execute(r.Context(), usecases.GetDistributionInput{
+		DistributionId: distributionId,
+		Offset:         page.Offset,
+		Limit:          page.Limit,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	h.presentJSON(w, r, http.StatusOK, toDistributionJson(distribution, allocations), &page)
+}
+
+// The page describes the allocations of the address
+func (h *httpServer) getUserAllocationsRoute(w http.ResponseWriter, r *http.Request) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	earnings, allocations, count, err := h.getUserAllocations.Execute(r.Context(), usecases.GetUserAllocationsInput{
+		Address: chi.URLParam(r, "address"),
+		Offset:  page.Offset,
+		Limit:   page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	json := earningsJson{
+		Address:     earnings.Address(),
+		Amount:      earnings.Amount().String(),
+		Rounds:      earnings.Rounds(),
+		Allocations: []allocationJson{},
+	}
+	for _, allocation := range allocations {
+		json.Allocations = append(json.Allocations, toAllocationJson(allocation))
+	}
+
+	h.presentJSON(w, r, http.StatusOK, json, &page)
+}
+
+// The amount and merkle proof an address needs to claim its allocation of a distribution on chain
+func (h *httpServer) getClaimRoute(w http.ResponseWriter, r *http.Request) {
+	distributionId, err := strconv.ParseInt(chi.URLParam(r, "distributionId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	claim, err := h.getClaim.Execute(r.Context(), usecases.GetClaimInput{
+		DistributionId: distributionId,
+		Address:        chi.URLParam(r, "address"),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toClaimJson(claim), nil)
+}
+
+func toClaimJson(claim entities.Claim) claimJson {
+	allocation := claim.Allocation()
+
+	return claimJson{
+		DistributionId: fmt.Sprint(allocation.DistributionId()),
+		Address:        allocation.Address(),
+		Amount:         allocation.Amount().String(),
+		MerkleRoot:     claim.MerkleRoot(),
+		Proof:          claim.Proof(),
+	}
+}
+
+// allocations are omitted when nil
+func toDistributionJson(distribution entities.Distribution, allocations []entities.Allocation) distributionJson {
+	json := distributionJson{
+		Id:           fmt.Sprint(distribution.Id()),
+		Round:        distribution.Round(),
+		Votes:        distribution.Votes(),
+		Amount:       distribution.Amount().String(),
+		Participants: distribution.Participants(),
+		MerkleRoot:   distribution.MerkleRoot(),
+		CreatedAt:    distribution.CreatedAt(),
+	}
+
+	if allocations != nil {
+		json.Allocations = []allocationJson{}
+		for _, allocation := range allocations {
+			json.Allocations = append(json.Allocations, toAllocationJson(allocation))
+		}
+	}
+
+	return json
+}
+
+func toAllocationJson(allocation entities.Allocation) allocationJson {
+	return allocationJson{
+		DistributionId: fmt.Sprint(allocation.DistributionId()),
+		Round:          allocation.Round(),
+		Address:        allocation.Address(),
+		Amount:         allocation.Amount().String(),
+		Votes:          allocation.Votes(),
+	}
+}
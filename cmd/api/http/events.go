@@ -0,0 +1,297 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+var eventIdRegex = regexp.MustCompile(`^\d+-\d+$`)
+
+type threadEventJson struct {
+	ThreadId  string  `json:"threadId"`
+	CommentId *string `json:"commentId,omitempty"`
+	Votes     *int64  `json:"votes,omitempty"`
+	Viewers   *int64  `json:"viewers,omitempty"`
+}
+
+// Fans the thread events read from the stream out to the clients connected to this replica.
+// A connection is a single channel that can watch any number of threads.
+// Connections are counted per ip so a single client can not exhaust the replica.
+type eventHub struct {
+	mu sync.Mutex
+	// the connections watching each thread
+	subscribers map[int64]map[chan entities.ThreadEvent]bool
+	// the threads each open connection is watching
+	connections map[chan entities.ThreadEvent]map[int64]bool
+	ips         map[string]int64
+}
+
+func newEventHub() *eventHub {
+	return &eventHub{
+		subscribers: map[int64]map[chan entities.ThreadEvent]bool{},
+		connections: map[chan entities.ThreadEvent]map[int64]bool{},
+		ips:         map[string]int64{},
+	}
+}
+
+// false if the ip already holds the maximum number of connections
+func (e *eventHub) connect(ip string, maxConnections int64) (chan entities.ThreadEvent, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.ips[ip] >= maxConnections {
+		return nil, false
+	}
+	e.ips[ip]++
+
+	events := make(chan entities.ThreadEvent, 100)
+	e.connections[events] = map[int64]bool{}
+
+	return events, true
+}
+
+func (e *eventHub) disconnect(ip string, events chan entities.ThreadEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.ips[ip]--; e.ips[ip] <= 0 {
+		delete(e.ips, ip)
+	}
+
+	// the connection is already dropped if it fell behind
+	if _, ok := e.connections[events]; ok {
+		e.drop(events)
+	}
+}
+
+// false if the connection was dropped for falling behind
+func (e *eventHub) join(threadId int64, events chan entities.ThreadEvent) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	threads, ok := e.connections[events]
+	if !ok {
+		return false
+	}
+	threads[threadId] = true
+
+	if _, ok := e.subscribers[threadId]; !ok {
+		e.subscribers[threadId] = map[chan entities.ThreadEvent]bool{}
+	}
+	e.subscribers[threadId][events] = true
+
+	return true
+}
+
+func (e *eventHub) leave(threadId int64, events chan entities.ThreadEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if threads, ok := e.connections[events]; ok {
+		delete(threads, threadId)
+	}
+	e.removeSubscriber(threadId, events)
+}
+
+// Connections that fall behind are dropped instead of blocking everyone else.
+// Closing their channel ends the connection and clients resume from their last event id when reconnecting.
+func (e *eventHub) publish(event entities.ThreadEvent) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for events := range e.subscribers[event.ThreadId()] {
+		select {
+		case events <- event:
+		default:
+			e.drop(events)
+		}
+	}
+}
+
+// must be called while holding the lock
+func (e *eventHub) drop(events chan entities.ThreadEvent) {
+	for threadId := range e.connections[events] {
+		e.removeSubscriber(threadId, events)
+	}
+	delete(e.connections, events)
+	close(events)
+}
+
+// must be called while holding the lock
+func (e *eventHub) removeSubscriber(threadId int64, events chan entities.ThreadEvent) {
+	delete(e.subscribers[threadId], events)
+	if len(e.subscribers[threadId]) == 0 {
+		delete(e.subscribers, threadId)
+	}
+}
+
+// Reads the events of every thread from the stream and hands them to the hub until the context is cancelled.
+// We start from the current time rather than $ so events published in between reads are not lost.
+func (h *httpServer) readEvents(ctx context.Context) {
+	after := fmt.Sprintf("%v-0", time.Now().UnixMilli())
+
+	for {
+		select {
+		case <-ctx.Done():
+			h.logger.Info(ctx).Msg("event reader stopped")
+			return
+		default:
+		}
+
+		events, err := h.readThreadEvents.Execute(ctx, usecases.ReadThreadEventsInput{
+			After: after,
+			Block: time.Second * 5,
+		})
+
+		if err != nil {
+			if ctx.Err() == nil {
+				h.logger.Error(ctx).Err(err).Msg("error reading thread events")
+				time.Sleep(time.Second)
+			}
+			continue
+		}
+
+		for _, event := range events {
+			after = event.Id()
+			h.events.publish(event)
+		}
+	}
+}
+
+// Streams the events of a thread as server-sent events.
+// Clients reconnecting with a Last-Event-ID header are first sent the events they missed.
+func (h *httpServer) getThreadEventsRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	threadId, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+
+	if !ok {
+		h.presentBadRequest(w, r, errors.New("streaming is not supported"))
+		return
+	}
+
+	_, _, err = h.getThread.Execute(ctx, usecases.GetThreadInput{
+		ThreadId:     threadId,
+		CommentLimit: 1,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	events, ok := h.events.connect(r.RemoteAddr, h.config.EventConnectionsPerIP)
+
+	if !ok {
+		h.presentTooManyRequests(w, r, fmt.Errorf("too many event connections from %v", r.RemoteAddr))
+		return
+	}
+
+	defer h.events.disconnect(r.RemoteAddr, events)
+
+	// join before replaying so nothing published in between is missed, duplicates are skipped below
+	h.events.join(threadId, events)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	h.presentStatus(w, r, http.StatusOK)
+	flusher.Flush()
+
+	replayed := map[string]bool{}
+	if lastEventId := r.Header.Get("Last-Event-ID"); eventIdRegex.MatchString(lastEventId) {
+		missed, err := h.getThreadEvents.Execute(ctx, usecases.GetThreadEventsInput{
+			ThreadId: threadId,
+			After:    lastEventId,
+		})
+
+		if err != nil {
+			h.logger.Error(ctx).Err(err).Msgf("error replaying events for thread %v", threadId)
+			return
+		}
+
+		for _, event := range missed {
+			replayed[event.Id()] = true
+			if err := writeThreadEvent(w, event); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+
+	heartbeat := time.NewTicker(time.Second * 15)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case event, ok := <-events:
+			if !ok {
+				h.logger.Warn(ctx).Msgf("dropped event connection for thread %v that fell behind", threadId)
+				return
+			}
+			if replayed[event.Id()] {
+				continue
+			}
+			if err := writeThreadEvent(w, event); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+func writeThreadEvent(w http.ResponseWriter, event entities.ThreadEvent) error {
+	data, err := json.Marshal(toThreadEventJson(event))
+
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %v\nevent: %v\ndata: %s\n\n", event.Id(), event.Type(), data)
+	return err
+}
+
+func toThreadEventJson(event entities.ThreadEvent) threadEventJson {
+	json := threadEventJson{
+		ThreadId: fmt.Sprint(event.ThreadId()),
+		Votes:    event.Votes(),
+		Viewers:  event.Viewers(),
+	}
+
+	if commentId := event.CommentId(); commentId != nil {
+		id := fmt.Sprint(*commentId)
+		json.CommentId = &id
+	}
+
+	return json
+}
@@ -10,41 +10,106 @@ import (
 	"time"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/usecases"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
 )
 
+var allowedOrigins = []string{"https://daochan.io", "http://localhost:3000"}
+
+// the size of the sha256 hmac key the cursor secret is used as
+const minSecretLength = 32
+
 type HttpServer interface {
 	Start(ctx context.Context, config HttpConfig)
 	Shutdown(ctx context.Context) error
 }
 
 type httpServer struct {
-	server        *http.Server
-	logger        common.Logger
-	config        *HttpConfig
-	getChallenge  *usecases.GetChallenge
-	signin        *usecases.Signin
-	authenticate  *usecases.Authenticate
-	rateLimit     *usecases.RateLimit
-	createThread  *usecases.CreateThread
-	getThread     *usecases.GetThread
-	getThreads    *usecases.GetThreads
-	deleteThread  *usecases.DeleteThread
-	createVote    *usecases.CreateVote
-	createComment *usecases.CreateComment
-	getComments   *usecases.GetComments
-	deleteComment *usecases.DeleteComment
-	uploadImage   *usecases.UploadImage
-	getUser       *usecases.GetUser
+	server                   *http.Server
+	logger                   common.Logger
+	config                   *HttpConfig
+	events                   *eventHub
+	getChallenge             *usecases.GetChallenge
+	signin                   *usecases.Signin
+	authenticate             *usecases.Authenticate
+	rateLimit                *usecases.RateLimit
+	createThread             *usecases.CreateThread
+	getThread                *usecases.GetThread
+	getThreads               *usecases.GetThreads
+	deleteThread             *usecases.DeleteThread
+	editThread               *usecases.EditThread
+	createVote               *usecases.CreateVote
+	createComment            *usecases.CreateComment
+	getComments              *usecases.GetComments
+	getCommentTree           *usecases.GetCommentTree
+	deleteComment            *usecases.DeleteComment
+	editComment              *usecases.EditComment
+	getRevisions             *usecases.GetRevisions
+	uploadImage              *usecases.UploadImage
+	getUser                  *usecases.GetUser
+	search                   *usecases.Search
+	getBoards                *usecases.GetBoards
+	getBoard                 *usecases.GetBoard
+	createBoard              *usecases.CreateBoard
+	getUserThreads           *usecases.GetUserThreads
+	getUserComments          *usecases.GetUserComments
+	getRoles                 *usecases.GetRoles
+	grantRole                *usecases.GrantRole
+	revokeRole               *usecases.RevokeRole
+	seedAdmins               *usecases.SeedAdmins
+	createReport             *usecases.CreateReport
+	getReports               *usecases.GetReports
+	resolveReports           *usecases.ResolveReports
+	getBan                   *usecases.GetBan
+	banUser                  *usecases.BanUser
+	unbanUser                *usecases.UnbanUser
+	getModerationLog         *usecases.GetModerationLog
+	restoreThread            *usecases.RestoreThread
+	restoreComment           *usecases.RestoreComment
+	lockThread               *usecases.LockThread
+	pinThread                *usecases.PinThread
+	archiveThread            *usecases.ArchiveThread
+	readThreadEvents         *usecases.ReadThreadEvents
+	getThreadEvents          *usecases.GetThreadEvents
+	viewThread               *usecases.ViewThread
+	leaveThread              *usecases.LeaveThread
+	getNotifications         *usecases.GetNotifications
+	countUnreadNotifications *usecases.CountUnreadNotifications
+	readNotifications        *usecases.ReadNotifications
+	subscribeToThread        *usecases.SubscribeToThread
+	createWebhook            *usecases.CreateWebhook
+	getWebhooks              *usecases.GetWebhooks
+	deleteWebhook            *usecases.DeleteWebhook
+	enableWebhook            *usecases.EnableWebhook
+	getWebhookDeliveries     *usecases.GetWebhookDeliveries
+	registerPushSubscription *usecases.RegisterPushSubscription
+	deletePushSubscription   *usecases.DeletePushSubscription
+	getClaim                 *usecases.GetClaim
+	getDistributions         *usecases.GetDistributions
+	getDistribution          *usecases.GetDistribution
+	getUserAllocations       *usecases.GetUserAllocations
 }
 
 type HttpConfig struct {
 	Port         string
 	JWTSecret    string
 	RealIPHeader string
+	CursorSecret string
+	// how long after creation authors can edit threads and comments
+	EditWindow time.Duration
+	// how long after deleting them authors can restore threads and comments
+	RestoreWindow time.Duration
+	// the addresses granted the global admin role on startup
+	Admins []string
+	// threads and comments with this many open reports are hidden until reviewed
+	ReportThreshold int64
+	// how many event streams a single ip can hold open on a replica
+	EventConnectionsPerIP int64
+	// the application server key browsers subscribe to web push with
+	VAPIDPublicKey string
 }
 
 func NewHttpServer(
@@ -57,17 +122,63 @@ func NewHttpServer(
 	getThread *usecases.GetThread,
 	getThreads *usecases.GetThreads,
 	deleteThread *usecases.DeleteThread,
+	editThread *usecases.EditThread,
 	createVote *usecases.CreateVote,
 	createComment *usecases.CreateComment,
 	getComments *usecases.GetComments,
+	getCommentTree *usecases.GetCommentTree,
 	deleteComment *usecases.DeleteComment,
+	editComment *usecases.EditComment,
+	getRevisions *usecases.GetRevisions,
 	uploadImage *usecases.UploadImage,
-	getUser *usecases.GetUser) HttpServer {
+	getUser *usecases.GetUser,
+	search *usecases.Search,
+	getBoards *usecases.GetBoards,
+	getBoard *usecases.GetBoard,
+	createBoard *usecases.CreateBoard,
+	getUserThreads *usecases.GetUserThreads,
+	getUserComments *usecases.GetUserComments,
+	getRoles *usecases.GetRoles,
+	grantRole *usecases.GrantRole,
+	revokeRole *usecases.RevokeRole,
+	seedAdmins *usecases.SeedAdmins,
+	createReport *usecases.CreateReport,
+	getReports *usecases.GetReports,
+	resolveReports *usecases.ResolveReports,
+	getBan *usecases.GetBan,
+	banUser *usecases.BanUser,
+	unbanUser *usecases.UnbanUser,
+	getModerationLog *usecases.GetModerationLog,
+	restoreThread *usecases.RestoreThread,
+	restoreComment *usecases.RestoreComment,
+	lockThread *usecases.LockThread,
+	pinThread *usecases.PinThread,
+	archiveThread *usecases.ArchiveThread,
+	readThreadEvents *usecases.ReadThreadEvents,
+	getThreadEvents *usecases.GetThreadEvents,
+	viewThread *usecases.ViewThread,
+	leaveThread *usecases.LeaveThread,
+	getNotifications *usecases.GetNotifications,
+	countUnreadNotifications *usecases.CountUnreadNotifications,
+	readNotifications *usecases.ReadNotifications,
+	subscribeToThread *usecases.SubscribeToThread,
+	createWebhook *usecases.CreateWebhook,
+	getWebhooks *usecases.GetWebhooks,
+	deleteWebhook *usecases.DeleteWebhook,
+	enableWebhook *usecases.EnableWebhook,
+	getWebhookDeliveries *usecases.GetWebhookDeliveries,
+	registerPushSubscription *usecases.RegisterPushSubscription,
+	deletePushSubscription *usecases.DeletePushSubscription,
+	getClaim *usecases.GetClaim,
+	getDistributions *usecases.GetDistributions,
+	getDistribution *usecases.GetDistribution,
+	getUserAllocations *usecases.GetUserAllocations) HttpServer {
 	var server *http.Server
 	return &httpServer{
 		server,
 		logger,
 		nil,
+		newEventHub(),
 		getChallenge,
 		signin,
 		authenticate,
@@ -76,25 +187,81 @@ func NewHttpServer(
 		getThread,
 		getThreads,
 		deleteThread,
+		editThread,
 		createVote,
 		createComment,
 		getComments,
+		getCommentTree,
 		deleteComment,
+		editComment,
+		getRevisions,
 		uploadImage,
 		getUser,
+		search,
+		getBoards,
+		getBoard,
+		createBoard,
+		getUserThreads,
+		getUserComments,
+		getRoles,
+		grantRole,
+		revokeRole,
+		seedAdmins,
+		createReport,
+		getReports,
+		resolveReports,
+		getBan,
+		banUser,
+		unbanUser,
+		getModerationLog,
+		restoreThread,
+		restoreComment,
+		lockThread,
+		pinThread,
+		archiveThread,
+		readThreadEvents,
+		getThreadEvents,
+		viewThread,
+		leaveThread,
+		getNotifications,
+		countUnreadNotifications,
+		readNotifications,
+		subscribeToThread,
+		createWebhook,
+		getWebhooks,
+		deleteWebhook,
+		enableWebhook,
+		getWebhookDeliveries,
+		registerPushSubscription,
+		deletePushSubscription,
+		getClaim,
+		getDistributions,
+		getDistribution,
+		getUserAllocations,
 	}
 }
 
 func (h *httpServer) Start(ctx context.Context, config HttpConfig) {
 	h.logger.Info(ctx).Msg("starting http service")
 
+	// an empty or short secret would let anyone forge cursors
+	if len(config.CursorSecret) < minSecretLength {
+		panic(fmt.Sprintf("cursor secret must be at least %v bytes", minSecretLength))
+	}
+
 	h.config = &config
 
+	if err := h.seedAdmins.Execute(ctx, usecases.SeedAdminsInput{Addresses: config.Admins}); err != nil {
+		h.logger.Error(ctx).Err(err).Msg("failed to seed admins")
+	}
+
+	go h.readEvents(ctx)
+
 	r := chi.NewRouter()
 
 	r.Use(cors.Handler(cors.Options{
-		AllowedOrigins:   []string{"https://daochan.io", "http://localhost:3000"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Address"},
 		AllowCredentials: false,
 		MaxAge:           300,
@@ -115,13 +282,31 @@ func (h *httpServer) Start(ctx context.Context, config HttpConfig) {
 
 		// public routes
 		r.Group(func(r chi.Router) {
+			r.Use(h.optionalAuthentication)
 			r.Use(h.rateLimiter("public", 20, time.Minute))
 			r.Use(h.maxSize(1))
 
 			r.Get("/users/{address}", h.getUserByAddressRoute)
+			r.Get("/users/{address}/threads", h.getUserThreadsRoute)
+			r.Get("/users/{address}/comments", h.getUserCommentsRoute)
+			r.Get("/users/{address}/roles", h.getRolesRoute)
+			r.Get("/users/{address}/allocations", h.getUserAllocationsRoute)
 			r.Get("/threads", h.getThreadsRoute)
 			r.Get("/threads/{threadId}", h.getThreadByIdRoute)
 			r.Get("/threads/{threadId}/comments", h.getCommentsRoute)
+			r.Get("/threads/{threadId}/comments/tree", h.getCommentTreeRoute)
+			r.Get("/threads/{threadId}/comments/{commentId}/replies", h.getCommentTreeRoute)
+			r.Get("/threads/{threadId}/revisions", h.getThreadRevisionsRoute)
+			r.Get("/threads/{threadId}/comments/{commentId}/revisions", h.getCommentRevisionsRoute)
+			r.Get("/search", h.searchRoute)
+			r.Get("/boards", h.getBoardsRoute)
+			r.Get("/boards/{slug}", h.getBoardRoute)
+			r.Get("/boards/{slug}/threads", h.getThreadsRoute)
+			r.Get("/modlog", h.getModerationLogRoute)
+			r.Get("/push/key", h.getPushKeyRoute)
+			r.Get("/distributions", h.getDistributionsRoute)
+			r.Get("/distributions/{distributionId}", h.getDistributionRoute)
+			r.Get("/distributions/{distributionId}/proofs/{address}", h.getClaimRoute)
 		})
 
 		// signin routes
@@ -142,18 +327,85 @@ func (h *httpServer) Start(ctx context.Context, config HttpConfig) {
 			r.With(h.rateLimiter("create:thread", 2, time.Minute*10)).Post("/threads", h.createThreadRoute)
 			r.With(h.rateLimiter("vote:thread", 10, time.Minute)).Put("/threads/{threadId}/votes/{value}", h.createThreadVoteRoute)
 			r.With(h.rateLimiter("create:comment", 5, time.Minute*10)).Post("/threads/{threadId}/comments", h.createCommentRoute)
+			r.With(h.rateLimiter("edit:thread", 5, time.Minute*10)).Patch("/threads/{threadId}", h.editThreadRoute)
+			r.With(h.rateLimiter("edit:comment", 5, time.Minute*10)).Patch("/threads/{threadId}/comments/{commentId}", h.editCommentRoute)
 			r.With(h.rateLimiter("vote:comment", 10, time.Minute)).Put("/threads/{threadId}/comments/{commentId}/votes/{value}", h.createCommentVoteRoute)
+			r.With(h.rateLimiter("create:report", 5, time.Minute*10)).Post("/reports", h.createReportRoute)
+		})
+
+		// notification and thread subscription routes
+		r.Group(func(r chi.Router) {
+			r.Use(h.authentication)
+			r.Use(h.rateLimiter("notifications", 30, time.Minute))
+			r.Use(h.maxSize(1))
+
+			r.Get("/notifications", h.getNotificationsRoute)
+			r.Get("/notifications/unread", h.getUnreadNotificationsRoute)
+			r.Put("/notifications/read", h.readNotificationsRoute)
+			r.Put("/notifications/{notificationId}/read", h.readNotificationsRoute)
+			r.Put("/threads/{threadId}/subscription", h.threadSubscriptionRoute(true))
+			r.Delete("/threads/{threadId}/subscription", h.threadSubscriptionRoute(false))
+			r.Put("/push/subscriptions", h.registerPushSubscriptionRoute)
+			r.Delete("/push/subscriptions", h.deletePushSubscriptionRoute)
 		})
 
 		// permissioned routes
-		// TODO: Make this require moderator+ permission
+		// authors can delete and restore their own content and moderators any content of their boards.
+		// moderators can also lock, pin and archive the threads of their boards.
 		r.Group(func(r chi.Router) {
 			r.Use(h.authentication)
+			r.Use(h.authorization())
 			r.Use(h.rateLimiter("permissioned", 10, time.Second))
 			r.Use(h.maxSize(1))
 
 			r.Delete("/threads/{threadId}", h.deleteThreadRoute)
 			r.Delete("/threads/{threadId}/comments/{commentId}", h.deleteCommentRoute)
+			r.Post("/threads/{threadId}/restore", h.restoreThreadRoute)
+			r.Post("/threads/{threadId}/comments/{commentId}/restore", h.restoreCommentRoute)
+			r.Put("/threads/{threadId}/lock", h.lockThreadRoute(true))
+			r.Delete("/threads/{threadId}/lock", h.lockThreadRoute(false))
+			r.Put("/threads/{threadId}/pin", h.pinThreadRoute(true))
+			r.Delete("/threads/{threadId}/pin", h.pinThreadRoute(false))
+			r.Put("/threads/{threadId}/archive", h.archiveThreadRoute(true))
+			r.Delete("/threads/{threadId}/archive", h.archiveThreadRoute(false))
+		})
+
+		// moderator routes
+		r.Group(func(r chi.Router) {
+			r.Use(h.authentication)
+			r.Use(h.authorization(entities.ModeratorRole))
+			r.Use(h.rateLimiter("moderator", 10, time.Second))
+			r.Use(h.maxSize(5))
+
+			r.Post("/boards", h.createBoardRoute)
+			r.Get("/reports", h.getReportsRoute)
+			r.Post("/reports/{targetType}/{targetId}/resolve", h.resolveReportsRoute)
+			r.Post("/users/{address}/bans", h.banUserRoute)
+			r.Delete("/users/{address}/bans", h.unbanUserRoute)
+		})
+
+		// admin routes
+		r.Group(func(r chi.Router) {
+			r.Use(h.authentication)
+			r.Use(h.authorization(entities.AdminRole))
+			r.Use(h.rateLimiter("admin", 10, time.Second))
+			r.Use(h.maxSize(1))
+
+			r.Put("/users/{address}/roles/{role}", h.grantRoleRoute)
+			r.Delete("/users/{address}/roles/{role}", h.revokeRoleRoute)
+			r.Get("/webhooks", h.getWebhooksRoute)
+			r.Post("/webhooks", h.createWebhookRoute)
+			r.Delete("/webhooks/{webhookId}", h.deleteWebhookRoute)
+			r.Put("/webhooks/{webhookId}/enable", h.enableWebhookRoute)
+			r.Get("/webhooks/{webhookId}/deliveries", h.getWebhookDeliveriesRoute)
+		})
+
+		// event stream routes
+		r.Group(func(r chi.Router) {
+			r.Use(h.rateLimiter("events", 10, time.Minute))
+
+			r.Get("/threads/{threadId}/events", h.getThreadEventsRoute)
+			r.Get("/ws", h.socketRoute)
 		})
 
 		// image route
@@ -208,26 +460,52 @@ func (h *httpServer) presentForbidden(w http.ResponseWriter, r *http.Request, er
 	h.presentJSON(w, r, http.StatusForbidden, toErrJson("forbidden"), nil)
 }
 
+// the error message is presented so clients can tell conflicts apart
+func (h *httpServer) presentConflict(w http.ResponseWriter, r *http.Request, err error) {
+	h.logger.Warn(r.Context()).Err(err).Msg("conflict")
+	h.presentJSON(w, r, http.StatusConflict, toErrJson(err.Error()), nil)
+}
+
 func (h *httpServer) presentTooManyRequests(w http.ResponseWriter, r *http.Request, err error) {
 	h.logger.Warn(r.Context()).Err(err).Msg("too many requests")
 	h.presentJSON(w, r, http.StatusTooManyRequests, toErrJson("too many requests"), nil)
 }
 
+// only for failures of the server itself, the error is not presented
+func (h *httpServer) presentInternalServerError(w http.ResponseWriter, r *http.Request, err error) {
+	h.logger.Error(r.Context()).Err(err).Msg("internal server error")
+	h.presentJSON(w, r, http.StatusInternalServerError, toErrJson("internal server error"), nil)
+}
+
 func (h *httpServer) presentJSON(w http.ResponseWriter, r *http.Request, statusCode int, data any, lastPage *pageJson) {
 	w.Header().Set("Content-Type", "application/json")
 	h.presentStatus(w, r, statusCode)
 
-	var nextPage *pageJson
-	if lastPage != nil && lastPage.Offset+lastPage.Limit < lastPage.Count {
+	// cursor paginated routes set the cursors of the surrounding pages on the last page directly
+	var nextPage any
+	var nextCursor *string
+	var prevCursor *string
+	if lastPage != nil && lastPage.NextCursor != "" {
+		nextPage = &cursorPageJson{
+			Limit:  lastPage.Limit,
+			Cursor: lastPage.NextCursor,
+		}
+		nextCursor = &lastPage.NextCursor
+	} else if lastPage != nil && lastPage.Offset+lastPage.Limit < lastPage.Count {
 		nextPage = &pageJson{
 			Offset: lastPage.Offset + lastPage.Limit,
 			Limit:  lastPage.Limit,
 			Count:  lastPage.Count,
 		}
 	}
+	if lastPage != nil && lastPage.PrevCursor != "" {
+		prevCursor = &lastPage.PrevCursor
+	}
 	if err := json.NewEncoder(w).Encode(bodyJson{
-		Data:     data,
-		NextPage: nextPage,
+		Data:       data,
+		NextPage:   nextPage,
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
 	}); err != nil {
 		h.logger.Error(r.Context()).Err(err).Msg("error encoding json")
 	}
@@ -273,8 +551,11 @@ func (h *httpServer) logEvent(w http.ResponseWriter, r *http.Request, statusCode
 }
 
 type bodyJson struct {
-	Data     any       `json:"data"`
-	NextPage *pageJson `json:"nextPage,omitempty"`
+	Data any `json:"data"`
+	// a pageJson for offset paginated routes and a cursorPageJson for cursor paginated routes
+	NextPage   any     `json:"nextPage,omitempty"`
+	NextCursor *string `json:"nextCursor,omitempty"`
+	PrevCursor *string `json:"prevCursor,omitempty"`
 }
 
 func toErrJson(msg string) *errJson {
@@ -310,6 +591,9 @@ func (h *httpServer) getPage(r *http.Request) (pageJson, error) {
 		offset,
 		limit,
 		-1,
+		r.URL.Query().Get("cursor"),
+		"",
+		"",
 	}, nil
 }
 
@@ -317,4 +601,13 @@ type pageJson struct {
 	Offset int64 `json:"offset"`
 	Limit  int64 `json:"limit"`
 	Count  int64 `json:"count"`
+	// the requested cursor and the cursors of the surrounding pages set by cursor paginated routes
+	Cursor     string `json:"-"`
+	NextCursor string `json:"-"`
+	PrevCursor string `json:"-"`
+}
+
+type cursorPageJson struct {
+	Limit  int64  `json:"limit"`
+	Cursor string `json:"cursor"`
 }
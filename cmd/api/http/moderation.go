@@ -0,0 +1,63 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+func (h *httpServer) getModerationLogRoute(w http.ResponseWriter, r *http.Request) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	logs, count, err := h.getModerationLog.Execute(r.Context(), usecases.GetModerationLogInput{
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	h.presentJSON(w, r, http.StatusOK, toModerationLogsJson(logs), &page)
+}
+
+type moderationLogJson struct {
+	Id         string            `json:"id"`
+	Action     string            `json:"action"`
+	Actor      string            `json:"actor"`
+	TargetType string            `json:"targetType"` // thread, comment or user
+	TargetId   string            `json:"targetId"`   // the id of the thread or comment, or the address of the user
+	Reason     string            `json:"reason"`
+	Before     entities.Snapshot `json:"before"` // null if the target had no state before the action
+	After      entities.Snapshot `json:"after"`  // null if the target has no state after the action
+	CreatedAt  time.Time         `json:"createdAt"`
+}
+
+func toModerationLogsJson(logs []entities.ModerationLog) []moderationLogJson {
+	json := []moderationLogJson{}
+	for _, log := range logs {
+		json = append(json, moderationLogJson{
+			Id:         fmt.Sprint(log.Id()),
+			Action:     string(log.Action()),
+			Actor:      log.Actor(),
+			TargetType: string(log.TargetType()),
+			TargetId:   log.TargetId(),
+			Reason:     log.Reason(),
+			Before:     log.Before(),
+			After:      log.After(),
+			CreatedAt:  log.CreatedAt(),
+		})
+	}
+	return json
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+// pass ?unread=true to only list unread notifications
+func (h *httpServer) getNotificationsRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	notifications, count, err := h.getNotifications.Execute(ctx, usecases.GetNotificationsInput{
+		Recipient:  user.Address(),
+		UnreadOnly: r.URL.Query().Get("unread") == "true",
+		Offset:     page.Offset,
+		Limit:      page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	h.presentJSON(w, r, http.StatusOK, toNotificationsJson(notifications), &page)
+}
+
+func (h *httpServer) getUnreadNotificationsRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	count, err := h.countUnreadNotifications.Execute(ctx, usecases.CountUnreadNotificationsInput{
+		Recipient: user.Address(),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, notificationCountJson{Count: count}, nil)
+}
+
+// Marks a single notification as read when the route has a notification id and all of them otherwise
+func (h *httpServer) readNotificationsRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	var id *int64
+	if param := chi.URLParam(r, "notificationId"); param != "" {
+		notificationId, err := strconv.ParseInt(param, 10, 64)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		id = &notificationId
+	}
+
+	count, err := h.readNotifications.Execute(ctx, usecases.ReadNotificationsInput{
+		Recipient: user.Address(),
+		Id:        id,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, notificationCountJson{Count: count}, nil)
+}
+
+type notificationJson struct {
+	Id        string     `json:"id"`
+	Type      string     `json:"type"`  // reply, thread_reply, mention or subscription
+	Actor     string     `json:"actor"` // the address of the user who commented
+	ThreadId  string     `json:"threadId"`
+	CommentId string     `json:"commentId"`
+	IsRead    bool       `json:"isRead"`
+	ReadAt    *time.Time `json:"readAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+type notificationCountJson struct {
+	Count int64 `json:"count"`
+}
+
+func toNotificationsJson(notifications []entities.Notification) []notificationJson {
+	json := []notificationJson{}
+	for _, notification := range notifications {
+		json = append(json, notificationJson{
+			Id:        fmt.Sprint(notification.Id()),
+			Type:      string(notification.Type()),
+			Actor:     notification.Actor(),
+			ThreadId:  fmt.Sprint(notification.ThreadId()),
+			CommentId: fmt.Sprint(notification.CommentId()),
+			IsRead:    notification.IsRead(),
+			ReadAt:    notification.ReadAt(),
+			CreatedAt: notification.CreatedAt(),
+		})
+	}
+	return json
+}
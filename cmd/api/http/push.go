@@ -0,0 +1,95 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+// browsers need the key to subscribe, an empty key means web push is disabled
+func (h *httpServer) getPushKeyRoute(w http.ResponseWriter, r *http.Request) {
+	h.presentJSON(w, r, http.StatusOK, pushKeyJson{
+		PublicKey: h.config.VAPIDPublicKey,
+	}, nil)
+}
+
+// the body is the serialized PushSubscription of the browser
+func (h *httpServer) registerPushSubscriptionRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[pushSubscriptionJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err = h.registerPushSubscription.Execute(ctx, usecases.RegisterPushSubscriptionInput{
+		Address:  user.Address(),
+		Endpoint: body.Endpoint,
+		P256dh:   body.Keys.P256dh,
+		Auth:     body.Keys.Auth,
+	})
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, fmt.Errorf("push subscription belongs to another user"))
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+// the endpoint is passed as a query param since delete requests have no body
+func (h *httpServer) deletePushSubscriptionRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err := h.deletePushSubscription.Execute(ctx, usecases.DeletePushSubscriptionInput{
+		Address:  user.Address(),
+		Endpoint: r.URL.Query().Get("endpoint"),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+type pushKeyJson struct {
+	PublicKey string `json:"publicKey"`
+}
+
+type pushSubscriptionJson struct {
+	Endpoint string `json:"endpoint"`
+	Keys     struct {
+		P256dh string `json:"p256dh"`
+		Auth   string `json:"auth"`
+	} `json:"keys"`
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) createReportRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[createReportJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err = h.createReport.Execute(ctx, usecases.CreateReportInput{
+		ReporterAddress: user.Address(),
+		TargetType:      entities.ReportTargetType(body.TargetType),
+		TargetId:        body.TargetId,
+		Reason:          entities.ReportReason(body.Reason),
+		Details:         body.Details,
+		Threshold:       h.config.ReportThreshold,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusCreated)
+}
+
+func (h *httpServer) getReportsRoute(w http.ResponseWriter, r *http.Request) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	groups, count, err := h.getReports.Execute(r.Context(), usecases.GetReportsInput{
+		Offset: page.Offset,
+		Limit:  page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	h.presentJSON(w, r, http.StatusOK, toReportGroupsJson(groups), &page)
+}
+
+func (h *httpServer) resolveReportsRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[resolveReportsJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err = h.resolveReports.Execute(ctx, usecases.ResolveReportsInput{
+		TargetType:      entities.ReportTargetType(chi.URLParam(r, "targetType")),
+		TargetId:        chi.URLParam(r, "targetId"),
+		Action:          entities.ReportResolution(body.Action),
+		ResolverAddress: user.Address(),
+		BanReason:       body.BanReason,
+		BanExpiresAt:    body.BanExpiresAt,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+type createReportJson struct {
+	TargetType string `json:"targetType"` // thread, comment or user
+	TargetId   string `json:"targetId"`   // the id of the thread or comment, or the address of the user
+	Reason     string `json:"reason"`
+	Details    string `json:"details"`
+}
+
+type resolveReportsJson struct {
+	Action       string     `json:"action"` // dismiss, delete or ban
+	BanReason    string     `json:"banReason"`
+	BanExpiresAt *time.Time `json:"banExpiresAt,omitempty"` // bans forever if empty
+}
+
+type reportGroupJson struct {
+	TargetType      string    `json:"targetType"`
+	TargetId        string    `json:"targetId"`
+	Count           int64     `json:"count"`
+	Reasons         []string  `json:"reasons"`
+	Details         []string  `json:"details"`
+	FirstReportedAt time.Time `json:"firstReportedAt"`
+	LastReportedAt  time.Time `json:"lastReportedAt"`
+}
+
+func toReportGroupsJson(groups []entities.ReportGroup) []reportGroupJson {
+	json := []reportGroupJson{}
+	for _, group := range groups {
+		reasons := []string{}
+		for _, reason := range group.Reasons() {
+			reasons = append(reasons, string(reason))
+		}
+		json = append(json, reportGroupJson{
+			TargetType:      string(group.TargetType()),
+			TargetId:        group.TargetId(),
+			Count:           group.Count(),
+			Reasons:         reasons,
+			Details:         group.Details(),
+			FirstReportedAt: group.FirstReportedAt(),
+			LastReportedAt:  group.LastReportedAt(),
+		})
+	}
+	return json
+}
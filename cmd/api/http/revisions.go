@@ -0,0 +1,74 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) getThreadRevisionsRoute(w http.ResponseWriter, r *http.Request) {
+	h.revisionsRoute(w, r, "threadId", entities.ThreadRevision)
+}
+
+func (h *httpServer) getCommentRevisionsRoute(w http.ResponseWriter, r *http.Request) {
+	h.revisionsRoute(w, r, "commentId", entities.CommentRevision)
+}
+
+func (h *httpServer) revisionsRoute(w http.ResponseWriter, r *http.Request, param string, revisionType entities.RevisionType) {
+	id, err := strconv.ParseInt(chi.URLParam(r, param), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	revisions, err := h.getRevisions.Execute(r.Context(), usecases.GetRevisionsInput{
+		Id:   id,
+		Type: revisionType,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toRevisionsJson(revisions), nil)
+}
+
+type revisionJson struct {
+	Id        string     `json:"id"`
+	Title     string     `json:"title,omitempty"` // empty if comment revision
+	Content   string     `json:"content"`
+	Image     *imageJson `json:"image,omitempty"`
+	CreatedAt time.Time  `json:"createdAt"`
+	RevisedAt time.Time  `json:"revisedAt"`
+}
+
+func toRevisionsJson(revisions []entities.Revision) []revisionJson {
+	json := make([]revisionJson, len(revisions))
+
+	for i, revision := range revisions {
+		json[i] = revisionJson{
+			Id:        fmt.Sprint(revision.Id()),
+			Title:     revision.Title(),
+			Content:   revision.Content(),
+			Image:     toImageJson(revision.Image()),
+			CreatedAt: revision.CreatedAt(),
+			RevisedAt: revision.RevisedAt(),
+		}
+	}
+
+	return json
+}
@@ -0,0 +1,120 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) getRolesRoute(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.getRoles.Execute(r.Context(), usecases.GetRolesInput{
+		Address: chi.URLParam(r, "address"),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toRolesJson(roles), nil)
+}
+
+// the role is scoped to the board given by the board query param, or global without it
+func (h *httpServer) grantRoleRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err := h.grantRole.Execute(ctx, usecases.GrantRoleInput{
+		Address:        chi.URLParam(r, "address"),
+		Role:           entities.RoleType(chi.URLParam(r, "role")),
+		BoardSlug:      getBoardQuery(r),
+		GranterAddress: user.Address(),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+func (h *httpServer) revokeRoleRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	err := h.revokeRole.Execute(ctx, usecases.RevokeRoleInput{
+		Address:        chi.URLParam(r, "address"),
+		Role:           entities.RoleType(chi.URLParam(r, "role")),
+		BoardSlug:      getBoardQuery(r),
+		RevokerAddress: user.Address(),
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+func getBoardQuery(r *http.Request) *string {
+	board := r.URL.Query().Get("board")
+	if board == "" {
+		return nil
+	}
+	return &board
+}
+
+type roleJson struct {
+	Address   string    `json:"address"`
+	Role      string    `json:"role"`
+	BoardId   *string   `json:"boardId,omitempty"` // empty if global
+	CreatedBy string    `json:"createdBy"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func toRolesJson(roles []entities.Role) []roleJson {
+	json := []roleJson{}
+	for _, role := range roles {
+		var boardId *string
+		if id := role.BoardId(); id != nil {
+			value := fmt.Sprint(*id)
+			boardId = &value
+		}
+		json = append(json, roleJson{
+			Address:   role.Address(),
+			Role:      string(role.Role()),
+			BoardId:   boardId,
+			CreatedBy: role.CreatedBy(),
+			CreatedAt: role.CreatedAt(),
+		})
+	}
+	return json
+}
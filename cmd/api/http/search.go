@@ -0,0 +1,114 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+func (h *httpServer) searchRoute(w http.ResponseWriter, r *http.Request) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	cursor, err := h.toSearchCursor(page.Cursor)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	var author *string
+	if address := r.URL.Query().Get("author"); address != "" {
+		author = &address
+	}
+
+	createdAfter, err := parseTimeQuery(r, "from")
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	createdBefore, err := parseTimeQuery(r, "to")
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	results, nextCursor, err := h.search.Execute(r.Context(), usecases.SearchInput{
+		Query:         r.URL.Query().Get("q"),
+		Author:        author,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
+		Cursor:        cursor,
+		Limit:         page.Limit,
+		Viewer:        viewer(r),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.NextCursor, err = h.toSearchCursorString(nextCursor)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toSearchResultsJson(results), &page)
+}
+
+// nil if the query param is not set
+func parseTimeQuery(r *http.Request, key string) (*time.Time, error) {
+	value := r.URL.Query().Get(key)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid %v: %w", key, err)
+	}
+
+	return &t, nil
+}
+
+// snippets are html escaped user content with the matching terms highlighted by <mark> tags
+type searchResultJson struct {
+	Type      string    `json:"type"`
+	Id        string    `json:"id"`
+	ThreadId  string    `json:"threadId"`
+	Title     string    `json:"title"`
+	Snippet   string    `json:"snippet"`
+	User      userJson  `json:"user"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func toSearchResultsJson(results []entities.SearchResult) []searchResultJson {
+	json := make([]searchResultJson, len(results))
+
+	for i, result := range results {
+		json[i] = searchResultJson{
+			Type:      string(result.Kind()),
+			Id:        fmt.Sprint(result.Id()),
+			ThreadId:  fmt.Sprint(result.ThreadId()),
+			Title:     result.Title(),
+			Snippet:   result.Snippet(),
+			User:      toUserJson(result.User()),
+			CreatedAt: result.CreatedAt(),
+		}
+	}
+
+	return json
+}
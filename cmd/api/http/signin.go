@@ -1,6 +1,7 @@
 package http
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/daochanio/backend/common"
@@ -42,6 +43,11 @@ func (h *httpServer) putChallengeRoute(w http.ResponseWriter, r *http.Request) {
 		JWTSecret: h.config.JWTSecret,
 	})
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentUnathorized(w, r, err)
 		return
@@ -0,0 +1,55 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+// Threads are followed with PUT and unfollowed with DELETE
+func (h *httpServer) threadSubscriptionRoute(subscribed bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+		if !ok {
+			h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+			return
+		}
+
+		id, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		err = h.subscribeToThread.Execute(ctx, usecases.SubscribeToThreadInput{
+			ThreadId:   id,
+			Address:    user.Address(),
+			Subscribed: subscribed,
+		})
+
+		if errors.Is(err, common.ErrNotFound) {
+			h.presentNotFound(w, r, err)
+			return
+		}
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		h.presentJSON(w, r, http.StatusOK, threadSubscriptionJson{IsSubscribed: subscribed}, nil)
+	}
+}
+
+type threadSubscriptionJson struct {
+	IsSubscribed bool `json:"isSubscribed"`
+}
@@ -28,10 +28,19 @@ func (h *httpServer) getThreadByIdRoute(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	thread, count, err := h.getThread.Execute(r.Context(), usecases.GetThreadInput{
+	offset, cursor, err := h.getCommentsPagination(r, page)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	thread, commentsPage, err := h.getThread.Execute(r.Context(), usecases.GetThreadInput{
 		ThreadId:      id,
-		CommentOffset: page.Offset,
+		CommentOffset: offset,
+		CommentCursor: cursor,
 		CommentLimit:  page.Limit,
+		Viewer:        viewer(r),
 	})
 
 	if errors.Is(err, common.ErrNotFound) {
@@ -44,7 +53,10 @@ func (h *httpServer) getThreadByIdRoute(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	page.Count = count
+	if err := h.setCommentsPage(&page, commentsPage); err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
 
 	h.presentJSON(w, r, http.StatusOK, toThreadJson(thread), &page)
 }
@@ -57,16 +69,56 @@ func (h *httpServer) getThreadsRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	threads, err := h.getThreads.Execute(r.Context(), usecases.GetThreadsInput{
-		Limit: page.Limit,
+	cursor, err := h.toThreadCursor(page.Cursor)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		sort = string(entities.HotThreadSort)
+	}
+
+	window := r.URL.Query().Get("window")
+	if window == "" {
+		window = string(entities.DayThreadWindow)
+	}
+
+	// the board feed is served from the same route with the board slug in the path
+	var boardSlug *string
+	if slug := chi.URLParam(r, "slug"); slug != "" {
+		boardSlug = &slug
+	}
+
+	threads, nextCursor, err := h.getThreads.Execute(r.Context(), usecases.GetThreadsInput{
+		BoardSlug: boardSlug,
+		Sort:      entities.ThreadSort(sort),
+		Window:    entities.ThreadWindow(window),
+		Cursor:    cursor,
+		Limit:     page.Limit,
+		Viewer:    viewer(r),
 	})
 
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
 	}
 
-	h.presentJSON(w, r, http.StatusOK, toThreadsJson(threads), nil)
+	page.NextCursor, err = h.toThreadCursorString(nextCursor)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toThreadsJson(threads), &page)
 }
 
 func (h *httpServer) createThreadRoute(w http.ResponseWriter, r *http.Request) {
@@ -86,11 +138,17 @@ func (h *httpServer) createThreadRoute(w http.ResponseWriter, r *http.Request) {
 
 	thread, err := h.createThread.Execute(ctx, usecases.CreateThreadInput{
 		Address:       user.Address(),
+		BoardSlug:     body.Board,
 		Title:         body.Title,
 		ImageFileName: body.ImageFileName,
 		Content:       body.Content,
 	})
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
@@ -99,6 +157,55 @@ func (h *httpServer) createThreadRoute(w http.ResponseWriter, r *http.Request) {
 	h.presentJSON(w, r, http.StatusCreated, toThreadJson(thread), nil)
 }
 
+func (h *httpServer) editThreadRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	body, err := common.Decode[editThreadJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	thread, err := h.editThread.Execute(ctx, usecases.EditThreadInput{
+		ThreadId:      id,
+		EditorAddress: user.Address(),
+		Title:         body.Title,
+		Content:       body.Content,
+		ImageFileName: body.ImageFileName,
+		Window:        h.config.EditWindow,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toThreadJson(thread), nil)
+}
+
 func (h *httpServer) deleteThreadRoute(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	id, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
@@ -118,6 +225,8 @@ func (h *httpServer) deleteThreadRoute(w http.ResponseWriter, r *http.Request) {
 	err = h.deleteThread.Execute(ctx, usecases.DeleteThreadInput{
 		ThreadId:       id,
 		DeleterAddress: user.Address(),
+		DeleterRoles:   user.Roles(),
+		Reason:         r.URL.Query().Get("reason"),
 	})
 
 	if errors.Is(err, common.ErrNotFound) {
@@ -125,6 +234,11 @@ func (h *httpServer) deleteThreadRoute(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
@@ -156,6 +270,11 @@ func (h *httpServer) createThreadVoteRoute(w http.ResponseWriter, r *http.Reques
 		Type:    entities.ThreadVote,
 	})
 
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
 	if err != nil {
 		h.presentBadRequest(w, r, err)
 		return
@@ -164,36 +283,115 @@ func (h *httpServer) createThreadVoteRoute(w http.ResponseWriter, r *http.Reques
 	h.presentStatus(w, r, http.StatusCreated)
 }
 
+func (h *httpServer) restoreThreadRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	body, err := common.Decode[restoreJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	thread, err := h.restoreThread.Execute(ctx, usecases.RestoreThreadInput{
+		ThreadId:        id,
+		RestorerAddress: user.Address(),
+		RestorerRoles:   user.Roles(),
+		Reason:          body.Reason,
+		Window:          h.config.RestoreWindow,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toThreadJson(thread), nil)
+}
+
+type restoreJson struct {
+	Reason string `json:"reason"`
+}
+
 type createThreadJson struct {
+	Board         string `json:"board"` // the slug of the board
 	Title         string `json:"title"`
 	Content       string `json:"content"`
 	ImageFileName string `json:"imageFileName"`
 }
 
+// omitted fields are left unchanged
+type editThreadJson struct {
+	Title         *string `json:"title,omitempty"`
+	Content       *string `json:"content,omitempty"`
+	ImageFileName *string `json:"imageFileName,omitempty"`
+}
+
 type threadJson struct {
-	Id        string         `json:"id"`
-	Title     string         `json:"title"`
-	Content   string         `json:"content"`
-	Image     *imageJson     `json:"image,omitempty"` // empty if thread deleted
-	User      userJson       `json:"user"`
-	Comments  *[]commentJson `json:"comments,omitempty"`
-	IsDeleted bool           `json:"isDeleted"`
-	CreatedAt time.Time      `json:"createdAt"`
-	DeletedAt *time.Time     `json:"deletedAt,omitempty"`
-	Votes     int64          `json:"votes"`
+	Id          string         `json:"id"`
+	BoardId     string         `json:"boardId"`
+	Title       string         `json:"title"`
+	Content     string         `json:"content"`
+	Image       *imageJson     `json:"image,omitempty"` // empty if thread deleted
+	User        userJson       `json:"user"`
+	Comments    *[]commentJson `json:"comments,omitempty"`
+	IsDeleted   bool           `json:"isDeleted"`
+	IsHidden    bool           `json:"isHidden"` // hidden threads are left out of feeds until reviewed
+	IsLocked    bool           `json:"isLocked"`
+	IsPinned    bool           `json:"isPinned"`
+	PinnedUntil *time.Time     `json:"pinnedUntil,omitempty"` // empty if not pinned
+	ArchivedAt  *time.Time     `json:"archivedAt,omitempty"`  // empty if not archived
+	CreatedAt   time.Time      `json:"createdAt"`
+	DeletedAt   *time.Time     `json:"deletedAt,omitempty"`
+	EditedAt    *time.Time     `json:"editedAt,omitempty"` // empty if never edited
+	Votes       int64          `json:"votes"`
+	MyVote      *string        `json:"myVote"` // null if anonymous or never voted
 }
 
 func toThreadJson(thread entities.Thread) threadJson {
 	json := threadJson{
-		Id:        fmt.Sprint(thread.Id()),
-		Title:     thread.Title(),
-		Content:   thread.Content(),
-		Image:     toImageJson(thread.Image()),
-		User:      toUserJson(thread.User()),
-		IsDeleted: thread.IsDeleted(),
-		CreatedAt: thread.CreatedAt(),
-		DeletedAt: thread.DeletedAt(),
-		Votes:     thread.Votes(),
+		Id:         fmt.Sprint(thread.Id()),
+		BoardId:    fmt.Sprint(thread.BoardId()),
+		Title:      thread.Title(),
+		Content:    thread.Content(),
+		Image:      toImageJson(thread.Image()),
+		User:       toUserJson(thread.User()),
+		IsDeleted:  thread.IsDeleted(),
+		IsHidden:   thread.IsHidden(),
+		IsLocked:   thread.IsLocked(),
+		IsPinned:   thread.IsPinned(),
+		ArchivedAt: thread.ArchivedAt(),
+		CreatedAt:  thread.CreatedAt(),
+		DeletedAt:  thread.DeletedAt(),
+		EditedAt:   thread.EditedAt(),
+		Votes:      thread.Votes(),
+		MyVote:     toMyVoteJson(thread.MyVote()),
+	}
+
+	if thread.IsPinned() {
+		json.PinnedUntil = thread.PinnedUntil()
 	}
 
 	if thread.Comments() != nil {
@@ -210,3 +408,11 @@ func toThreadsJson(threads []entities.Thread) []threadJson {
 	}
 	return json
 }
+
+func toMyVoteJson(vote *entities.VoteValue) *string {
+	if vote == nil {
+		return nil
+	}
+	value := string(*vote)
+	return &value
+}
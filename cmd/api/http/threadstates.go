@@ -0,0 +1,137 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+// Threads are locked with PUT and unlocked with DELETE, the same goes for pinning and archiving.
+// The reason is read from the body when setting a state and from the reason query param when clearing it.
+func (h *httpServer) lockThreadRoute(locked bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, user, body, err := h.getThreadStateRequest(r, locked)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		thread, err := h.lockThread.Execute(ctx, usecases.LockThreadInput{
+			ThreadId:         id,
+			ModeratorAddress: user.Address(),
+			ModeratorRoles:   user.Roles(),
+			Locked:           locked,
+			Reason:           body.Reason,
+		})
+
+		h.presentThreadState(w, r, thread, err)
+	}
+}
+
+func (h *httpServer) pinThreadRoute(pinned bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, user, body, err := h.getThreadStateRequest(r, pinned)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		if pinned && body.Until == nil {
+			h.presentBadRequest(w, r, errors.New("pinned threads require an until time"))
+			return
+		}
+
+		thread, err := h.pinThread.Execute(ctx, usecases.PinThreadInput{
+			ThreadId:         id,
+			ModeratorAddress: user.Address(),
+			ModeratorRoles:   user.Roles(),
+			Until:            body.Until,
+			Reason:           body.Reason,
+		})
+
+		h.presentThreadState(w, r, thread, err)
+	}
+}
+
+func (h *httpServer) archiveThreadRoute(archived bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		id, user, body, err := h.getThreadStateRequest(r, archived)
+
+		if err != nil {
+			h.presentBadRequest(w, r, err)
+			return
+		}
+
+		thread, err := h.archiveThread.Execute(ctx, usecases.ArchiveThreadInput{
+			ThreadId:         id,
+			ModeratorAddress: user.Address(),
+			ModeratorRoles:   user.Roles(),
+			Archived:         archived,
+			Reason:           body.Reason,
+		})
+
+		h.presentThreadState(w, r, thread, err)
+	}
+}
+
+func (h *httpServer) getThreadStateRequest(r *http.Request, set bool) (int64, entities.User, threadStateJson, error) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "threadId"), 10, 64)
+
+	if err != nil {
+		return 0, entities.User{}, threadStateJson{}, err
+	}
+
+	user, ok := r.Context().Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		return 0, entities.User{}, threadStateJson{}, fmt.Errorf("invalid user")
+	}
+
+	if !set {
+		return id, user, threadStateJson{Reason: r.URL.Query().Get("reason")}, nil
+	}
+
+	body, err := common.Decode[threadStateJson](r.Body)
+
+	if err != nil {
+		return 0, entities.User{}, threadStateJson{}, fmt.Errorf("invalid json: %w", err)
+	}
+
+	return id, user, *body, nil
+}
+
+func (h *httpServer) presentThreadState(w http.ResponseWriter, r *http.Request, thread entities.Thread, err error) {
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if errors.Is(err, common.ErrForbidden) {
+		h.presentForbidden(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toThreadJson(thread), nil)
+}
+
+type threadStateJson struct {
+	Reason string     `json:"reason"`
+	Until  *time.Time `json:"until,omitempty"` // only used when pinning
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 )
 
@@ -11,9 +12,16 @@ import (
 // we are intentionally not returning an error response
 // and allowing for the usecases to handle downstream context timeouts naturally
 //
+// event streams and sockets are long lived and end when the client disconnects instead
+//
 // see https://github.com/go-chi/chi/blob/master/middleware/timeout.go#L33
 func (h *httpServer) timeout(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if isLongLived(r) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
 		ctx, cancel := context.WithTimeout(r.Context(), time.Second*30)
 
 		defer cancel()
@@ -21,3 +29,8 @@ func (h *httpServer) timeout(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
+
+// the timeout runs before routing so we match on the path of the long lived routes
+func isLongLived(r *http.Request) bool {
+	return r.URL.Path == "/v1/ws" || (strings.HasPrefix(r.URL.Path, "/v1/threads/") && strings.HasSuffix(r.URL.Path, "/events"))
+}
@@ -13,7 +13,8 @@ import (
 
 func (h *httpServer) getUserByAddressRoute(w http.ResponseWriter, r *http.Request) {
 	user, err := h.getUser.Execute(r.Context(), usecases.GetUserInput{
-		Address: chi.URLParam(r, "address"),
+		Address:   chi.URLParam(r, "address"),
+		WithStats: true,
 	})
 
 	if errors.Is(err, common.ErrNotFound) {
@@ -29,6 +30,90 @@ func (h *httpServer) getUserByAddressRoute(w http.ResponseWriter, r *http.Reques
 	h.presentJSON(w, r, http.StatusOK, toUserJson(user), nil)
 }
 
+func (h *httpServer) getUserThreadsRoute(w http.ResponseWriter, r *http.Request) {
+	page, sort, cursor, err := h.getActivityPagination(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	threads, nextCursor, err := h.getUserThreads.Execute(r.Context(), usecases.GetUserThreadsInput{
+		Address: chi.URLParam(r, "address"),
+		Sort:    sort,
+		Cursor:  cursor,
+		Limit:   page.Limit,
+		Viewer:  viewer(r),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.NextCursor, err = h.toActivityCursorString(nextCursor)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toThreadsJson(threads), &page)
+}
+
+func (h *httpServer) getUserCommentsRoute(w http.ResponseWriter, r *http.Request) {
+	page, sort, cursor, err := h.getActivityPagination(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	comments, nextCursor, err := h.getUserComments.Execute(r.Context(), usecases.GetUserCommentsInput{
+		Address: chi.URLParam(r, "address"),
+		Sort:    sort,
+		Cursor:  cursor,
+		Limit:   page.Limit,
+		Viewer:  viewer(r),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.NextCursor, err = h.toActivityCursorString(nextCursor)
+
+	if err != nil {
+		h.presentInternalServerError(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toCommentsJson(comments), &page)
+}
+
+// the activity of a user is sorted by newest first unless requested otherwise
+func (h *httpServer) getActivityPagination(r *http.Request) (pageJson, entities.ActivitySort, *entities.ActivityCursor, error) {
+	page, err := h.getPage(r)
+
+	if err != nil {
+		return pageJson{}, "", nil, err
+	}
+
+	cursor, err := h.toActivityCursor(page.Cursor)
+
+	if err != nil {
+		return pageJson{}, "", nil, err
+	}
+
+	sort := r.URL.Query().Get("sort")
+	if sort == "" {
+		sort = string(entities.NewActivitySort)
+	}
+
+	return page, entities.ActivitySort(sort), cursor, nil
+}
+
 type userJson struct {
 	Address    string     `json:"address"`
 	EnsName    *string    `json:"ensName,omitempty"`
@@ -36,10 +121,14 @@ type userJson struct {
 	Reputation string     `json:"reputation"`
 	CreatedAt  time.Time  `json:"createdAt"`
 	UpdatedAt  *time.Time `json:"updatedAt,omitempty"`
+	// activity counters are only set on the profile of a user
+	ThreadCount  *int64 `json:"threadCount,omitempty"`
+	CommentCount *int64 `json:"commentCount,omitempty"`
+	Votes        *int64 `json:"votes,omitempty"` // total votes received by the threads and comments of the user
 }
 
 func toUserJson(user entities.User) userJson {
-	return userJson{
+	json := userJson{
 		Address:    user.Address(),
 		EnsName:    user.EnsName(),
 		EnsAvatar:  toImageJson(user.EnsAvatar()),
@@ -47,4 +136,15 @@ func toUserJson(user entities.User) userJson {
 		CreatedAt:  user.CreatedAt(),
 		UpdatedAt:  user.UpdatedAt(),
 	}
+
+	if stats := user.Stats(); stats != nil {
+		threadCount := stats.ThreadCount()
+		commentCount := stats.CommentCount()
+		votes := stats.Votes()
+		json.ThreadCount = &threadCount
+		json.CommentCount = &commentCount
+		json.Votes = &votes
+	}
+
+	return json
 }
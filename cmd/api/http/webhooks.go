@@ -0,0 +1,212 @@
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/go-chi/chi/v5"
+)
+
+func (h *httpServer) getWebhooksRoute(w http.ResponseWriter, r *http.Request) {
+	webhooks, err := h.getWebhooks.Execute(r.Context())
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	json := []webhookJson{}
+	for _, webhook := range webhooks {
+		json = append(json, toWebhookJson(webhook))
+	}
+
+	h.presentJSON(w, r, http.StatusOK, json, nil)
+}
+
+func (h *httpServer) createWebhookRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	body, err := common.Decode[createWebhookJson](r.Body)
+	if err != nil {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid json: %w", err))
+		return
+	}
+
+	user, ok := ctx.Value(common.ContextKeyUser).(entities.User)
+
+	if !ok {
+		h.presentBadRequest(w, r, fmt.Errorf("invalid user"))
+		return
+	}
+
+	events := []entities.WebhookEventType{}
+	for _, event := range body.Events {
+		events = append(events, entities.WebhookEventType(event))
+	}
+
+	webhook, err := h.createWebhook.Execute(ctx, usecases.CreateWebhookInput{
+		Url:       body.Url,
+		Events:    events,
+		Secret:    body.Secret,
+		CreatedBy: user.Address(),
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusCreated, toWebhookJson(webhook), nil)
+}
+
+func (h *httpServer) deleteWebhookRoute(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := strconv.ParseInt(chi.URLParam(r, "webhookId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	err = h.deleteWebhook.Execute(r.Context(), usecases.DeleteWebhookInput{
+		Id: webhookId,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentStatus(w, r, http.StatusOK)
+}
+
+func (h *httpServer) enableWebhookRoute(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := strconv.ParseInt(chi.URLParam(r, "webhookId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	webhook, err := h.enableWebhook.Execute(r.Context(), usecases.EnableWebhookInput{
+		Id: webhookId,
+	})
+
+	if errors.Is(err, common.ErrNotFound) {
+		h.presentNotFound(w, r, err)
+		return
+	}
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	h.presentJSON(w, r, http.StatusOK, toWebhookJson(webhook), nil)
+}
+
+func (h *httpServer) getWebhookDeliveriesRoute(w http.ResponseWriter, r *http.Request) {
+	webhookId, err := strconv.ParseInt(chi.URLParam(r, "webhookId"), 10, 64)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page, err := h.getPage(r)
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	deliveries, count, err := h.getWebhookDeliveries.Execute(r.Context(), usecases.GetWebhookDeliveriesInput{
+		WebhookId: webhookId,
+		Offset:    page.Offset,
+		Limit:     page.Limit,
+	})
+
+	if err != nil {
+		h.presentBadRequest(w, r, err)
+		return
+	}
+
+	page.Count = count
+
+	json := []webhookDeliveryJson{}
+	for _, delivery := range deliveries {
+		json = append(json, toWebhookDeliveryJson(delivery))
+	}
+
+	h.presentJSON(w, r, http.StatusOK, json, &page)
+}
+
+type createWebhookJson struct {
+	Url    string   `json:"url"`
+	Events []string `json:"events"`
+	Secret string   `json:"secret"`
+}
+
+// the secret is never presented back
+type webhookJson struct {
+	Id                  string     `json:"id"`
+	Url                 string     `json:"url"`
+	Events              []string   `json:"events"`
+	CreatedBy           string     `json:"createdBy"`
+	ConsecutiveFailures int64      `json:"consecutiveFailures"`
+	IsDisabled          bool       `json:"isDisabled"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+}
+
+type webhookDeliveryJson struct {
+	Id         string    `json:"id"`
+	EventType  string    `json:"eventType"`
+	EventId    string    `json:"eventId"`
+	StatusCode *int32    `json:"statusCode,omitempty"`
+	Error      string    `json:"error,omitempty"`
+	Attempts   int32     `json:"attempts"`
+	Succeeded  bool      `json:"succeeded"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func toWebhookJson(webhook entities.Webhook) webhookJson {
+	events := []string{}
+	for _, event := range webhook.Events() {
+		events = append(events, string(event))
+	}
+
+	return webhookJson{
+		Id:                  fmt.Sprint(webhook.Id()),
+		Url:                 webhook.Url(),
+		Events:              events,
+		CreatedBy:           webhook.CreatedBy(),
+		ConsecutiveFailures: webhook.ConsecutiveFailures(),
+		IsDisabled:          webhook.IsDisabled(),
+		DisabledAt:          webhook.DisabledAt(),
+		CreatedAt:           webhook.CreatedAt(),
+	}
+}
+
+func toWebhookDeliveryJson(delivery entities.WebhookDelivery) webhookDeliveryJson {
+	return webhookDeliveryJson{
+		Id:         fmt.Sprint(delivery.Id()),
+		EventType:  string(delivery.EventType()),
+		EventId:    delivery.EventId(),
+		StatusCode: delivery.StatusCode(),
+		Error:      delivery.Error(),
+		Attempts:   delivery.Attempts(),
+		Succeeded:  delivery.Succeeded(),
+		CreatedAt:  delivery.CreatedAt(),
+	}
+}
@@ -0,0 +1,320 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// viewers are refreshed on every ping so the ttl has to outlast a couple of them
+	socketPingInterval = time.Second * 30
+	socketPresenceTTL  = time.Second * 90
+	socketAuthTimeout  = time.Second * 10
+	socketWriteTimeout = time.Second * 10
+	socketMaxThreads   = 50
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		for _, allowed := range allowedOrigins {
+			if origin == allowed {
+				return true
+			}
+		}
+		return false
+	},
+}
+
+// Sent by clients, the token is only read from the first message when it is not passed as a query param
+type socketCommandJson struct {
+	Type     string `json:"type"`
+	Token    string `json:"token"`
+	ThreadId string `json:"threadId"`
+}
+
+type socketMessageJson struct {
+	Type string `json:"type"`
+	Id   string `json:"id,omitempty"`
+	Data any    `json:"data"`
+}
+
+type socketErrorJson struct {
+	Message string `json:"message"`
+}
+
+// A single multiplexed socket where clients subscribe to and unsubscribe from any number of threads.
+// Subscribers receive the events of the thread and are counted as viewing it until they unsubscribe or disconnect.
+//
+// Clients authenticate with the token query param or by sending {"type":"auth","token":"..."} as their first message,
+// then send {"type":"subscribe","threadId":"1"} and {"type":"unsubscribe","threadId":"1"}.
+func (h *httpServer) socketRoute(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	events, ok := h.events.connect(r.RemoteAddr, h.config.EventConnectionsPerIP)
+
+	if !ok {
+		h.presentTooManyRequests(w, r, fmt.Errorf("too many event connections from %v", r.RemoteAddr))
+		return
+	}
+
+	defer h.events.disconnect(r.RemoteAddr, events)
+
+	// the upgrader responds with the error itself
+	conn, err := upgrader.Upgrade(w, r, nil)
+
+	if err != nil {
+		h.logger.Warn(ctx).Err(err).Msg("websocket upgrade failed")
+		return
+	}
+
+	defer conn.Close()
+
+	h.logEvent(w, r, http.StatusSwitchingProtocols)
+
+	address, err := h.authenticateSocket(ctx, conn, r.URL.Query().Get("token"))
+
+	if err != nil {
+		h.logger.Warn(ctx).Err(err).Msg("websocket unauthorized")
+		closeSocket(conn, websocket.ClosePolicyViolation, "unauthorized")
+		return
+	}
+
+	// presence is kept per connection so other tabs of the user stay counted when this one closes
+	connection := uuid.New().String()
+
+	if err := conn.SetReadDeadline(time.Now().Add(socketPingInterval * 2)); err != nil {
+		return
+	}
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPingInterval * 2))
+	})
+
+	done := make(chan struct{})
+	defer close(done)
+
+	commands := make(chan socketCommandJson)
+	go readSocket(conn, commands, done)
+
+	threads := map[int64]bool{}
+	defer func() {
+		for threadId := range threads {
+			h.leaveSocketThread(ctx, events, address, connection, threadId)
+		}
+	}()
+
+	ping := time.NewTicker(socketPingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case command, ok := <-commands:
+			if !ok {
+				return
+			}
+			if err := h.handleSocketCommand(ctx, conn, events, address, connection, threads, command); err != nil {
+				h.logger.Warn(ctx).Err(err).Msg("websocket closed")
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				closeSocket(conn, websocket.CloseTryAgainLater, "connection fell behind")
+				return
+			}
+			if err := writeSocket(conn, socketMessageJson{
+				Type: string(event.Type()),
+				Id:   event.Id(),
+				Data: toThreadEventJson(event),
+			}); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteTimeout)); err != nil {
+				return
+			}
+			for threadId := range threads {
+				if _, err := h.viewThread.Execute(ctx, usecases.ViewThreadInput{
+					ThreadId:   threadId,
+					Address:    address,
+					Connection: connection,
+					TTL:        socketPresenceTTL,
+				}); err != nil {
+					h.logger.Error(ctx).Err(err).Msgf("error refreshing presence on thread %v", threadId)
+				}
+			}
+		}
+	}
+}
+
+// invalid commands are answered with an error message, only failing to write to the socket closes it
+func (h *httpServer) handleSocketCommand(ctx context.Context, conn *websocket.Conn, events chan entities.ThreadEvent, address string, connection string, threads map[int64]bool, command socketCommandJson) error {
+	threadId, err := strconv.ParseInt(command.ThreadId, 10, 64)
+
+	if err != nil || threadId <= 0 {
+		return writeSocketError(conn, fmt.Sprintf("invalid thread id %v", command.ThreadId))
+	}
+
+	switch command.Type {
+	case "subscribe":
+		if threads[threadId] {
+			return nil
+		}
+		if len(threads) >= socketMaxThreads {
+			return writeSocketError(conn, fmt.Sprintf("cannot subscribe to more than %v threads", socketMaxThreads))
+		}
+		// presence is only tracked for threads the user could open
+		if _, _, err := h.getThread.Execute(ctx, usecases.GetThreadInput{
+			ThreadId:     threadId,
+			CommentLimit: 1,
+			Viewer:       &address,
+		}); errors.Is(err, common.ErrNotFound) {
+			return writeSocketError(conn, fmt.Sprintf("thread %v not found", threadId))
+		} else if err != nil {
+			h.logger.Error(ctx).Err(err).Msgf("error getting thread %v", threadId)
+			return writeSocketError(conn, fmt.Sprintf("error subscribing to thread %v", threadId))
+		}
+		if !h.events.join(threadId, events) {
+			return errors.New("connection fell behind")
+		}
+		threads[threadId] = true
+
+		viewers, err := h.viewThread.Execute(ctx, usecases.ViewThreadInput{
+			ThreadId:   threadId,
+			Address:    address,
+			Connection: connection,
+			TTL:        socketPresenceTTL,
+		})
+
+		if err != nil {
+			h.logger.Error(ctx).Err(err).Msgf("error setting presence on thread %v", threadId)
+			return nil
+		}
+
+		// the current count is sent straight away since viewers already counted are not announced
+		event := entities.NewThreadEvent(entities.ThreadEventParams{
+			Type:     entities.PresenceUpdatedEvent,
+			ThreadId: threadId,
+			Viewers:  &viewers,
+		})
+		return writeSocket(conn, socketMessageJson{
+			Type: string(event.Type()),
+			Data: toThreadEventJson(event),
+		})
+	case "unsubscribe":
+		if threads[threadId] {
+			delete(threads, threadId)
+			h.leaveSocketThread(ctx, events, address, connection, threadId)
+		}
+		return nil
+	default:
+		return writeSocketError(conn, fmt.Sprintf("invalid command %v", command.Type))
+	}
+}
+
+func (h *httpServer) leaveSocketThread(ctx context.Context, events chan entities.ThreadEvent, address string, connection string, threadId int64) {
+	h.events.leave(threadId, events)
+
+	if err := h.leaveThread.Execute(ctx, usecases.LeaveThreadInput{
+		ThreadId:   threadId,
+		Address:    address,
+		Connection: connection,
+	}); err != nil {
+		h.logger.Error(ctx).Err(err).Msgf("error removing presence on thread %v", threadId)
+	}
+}
+
+// Hard banned users are rejected the same way the authentication middleware rejects them
+func (h *httpServer) authenticateSocket(ctx context.Context, conn *websocket.Conn, token string) (string, error) {
+	if token == "" {
+		if err := conn.SetReadDeadline(time.Now().Add(socketAuthTimeout)); err != nil {
+			return "", err
+		}
+
+		var command socketCommandJson
+		if err := conn.ReadJSON(&command); err != nil {
+			return "", fmt.Errorf("error reading auth message: %w", err)
+		}
+
+		if command.Type != "auth" {
+			return "", fmt.Errorf("expected auth message but got %v", command.Type)
+		}
+
+		token = command.Token
+	}
+
+	address, err := h.authenticate.Execute(ctx, &usecases.AuthenticateInput{
+		Token:     token,
+		JWTSecret: h.config.JWTSecret,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	ban, err := h.getBan.Execute(ctx, usecases.GetBanInput{
+		Address: address,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	if ban != nil && ban.Type() == entities.HardBan {
+		return "", fmt.Errorf("address %v is banned", address)
+	}
+
+	return address, nil
+}
+
+// gorilla sockets support a single concurrent reader so commands are read here and handed to the route.
+// commands is closed once the socket can no longer be read from.
+func readSocket(conn *websocket.Conn, commands chan socketCommandJson, done chan struct{}) {
+	defer close(commands)
+
+	for {
+		var command socketCommandJson
+		if err := conn.ReadJSON(&command); err != nil {
+			return
+		}
+
+		select {
+		case commands <- command:
+		case <-done:
+			return
+		}
+	}
+}
+
+func writeSocket(conn *websocket.Conn, message socketMessageJson) error {
+	if err := conn.SetWriteDeadline(time.Now().Add(socketWriteTimeout)); err != nil {
+		return err
+	}
+
+	return conn.WriteJSON(message)
+}
+
+func writeSocketError(conn *websocket.Conn, message string) error {
+	return writeSocket(conn, socketMessageJson{
+		Type: "error",
+		Data: socketErrorJson{Message: message},
+	})
+}
+
+func closeSocket(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(socketWriteTimeout))
+}
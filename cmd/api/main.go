@@ -7,7 +7,9 @@ import (
 	"syscall"
 
 	"github.com/daochanio/backend/cmd/api/http"
+	"github.com/daochanio/backend/cmd/api/push"
 	"github.com/daochanio/backend/cmd/api/subscribe"
+	"github.com/daochanio/backend/cmd/api/webhook"
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/gateways"
 )
@@ -29,11 +31,15 @@ func start(
 	logger common.Logger,
 	httpServer http.HttpServer,
 	subscriber subscribe.Subscriber,
+	deliverer webhook.Deliverer,
+	sender push.Sender,
 	database gateways.Database,
 	cache gateways.Cache,
 	stream gateways.Stream,
 	blockchain gateways.Blockchain,
 	images gateways.Images,
+	webhooks gateways.Webhooks,
+	webPush gateways.WebPush,
 ) {
 	logger.Start(ctx, settings.LoggerConfig())
 	database.Start(ctx, settings.DatabaseConfig())
@@ -41,9 +47,11 @@ func start(
 	stream.Start(ctx, settings.StreamConfig())
 	blockchain.Start(ctx, settings.BlockchainConfig())
 	images.Start(ctx, settings.ImagesConfig())
+	webhooks.Start(ctx, settings.WebhooksConfig())
+	webPush.Start(ctx, settings.WebPushConfig())
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(4)
 
 	go func() {
 		defer wg.Done()
@@ -55,6 +63,16 @@ func start(
 		subscriber.Start(ctx, settings.SubscriberConfig())
 	}()
 
+	go func() {
+		defer wg.Done()
+		deliverer.Start(ctx, settings.DelivererConfig())
+	}()
+
+	go func() {
+		defer wg.Done()
+		sender.Start(ctx, settings.SenderConfig())
+	}()
+
 	logger.Info(ctx).Msg("awaiting kill signal")
 
 	<-ctx.Done()
@@ -67,16 +85,20 @@ func start(
 		logger.Error(ctx).Err(err).Msg("failed to shutdown http server")
 	}
 
-	// the ctx being marked as done should cause the subscriber to return from its blocking call
+	// the ctx being marked as done should cause the subscriber, deliverer and sender to return from their blocking calls
 	wg.Wait()
 
 	subscriber.Shutdown(shutdownCtx)
+	deliverer.Shutdown(shutdownCtx)
+	sender.Shutdown(shutdownCtx)
 
 	database.Shutdown(shutdownCtx)
 	cache.Shutdown(shutdownCtx)
 	stream.Shutdown(shutdownCtx)
 	blockchain.Shutdown(shutdownCtx)
 	images.Shutdown(shutdownCtx)
+	webhooks.Shutdown(shutdownCtx)
+	webPush.Shutdown(shutdownCtx)
 
 	logger.Info(ctx).Msgf("shutdown complete")
 }
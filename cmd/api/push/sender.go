@@ -0,0 +1,173 @@
+package push
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/redis/go-redis/v9"
+)
+
+type Sender interface {
+	Start(ctx context.Context, config SenderConfig)
+	Shutdown(ctx context.Context)
+}
+
+type sender struct {
+	logger                       common.Logger
+	client                       *redis.Client
+	sendPushNotificationsUseCase *usecases.SendPushNotifications
+}
+
+type SenderConfig struct {
+	// must differ from the group of the subscriber so both receive every comment
+	Group            string
+	Consumer         string
+	ConnectionString string
+	DialTimeout      time.Duration
+	MinIdleConns     int
+	PoolSize         int
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+}
+
+func NewSender(
+	logger common.Logger,
+	sendPushNotificationsUseCase *usecases.SendPushNotifications,
+) Sender {
+	return &sender{
+		logger:                       logger,
+		client:                       nil,
+		sendPushNotificationsUseCase: sendPushNotificationsUseCase,
+	}
+}
+
+// Reads new comments with a dedicated consumer group and pushes them to the browsers of the users replied to.
+// Unlike the subscriber nothing is buffered so pushes arrive as soon as the comment is created.
+// Messages are acknowledged once their pushes have been sent, failed pushes are logged rather than redelivered.
+func (s *sender) Start(ctx context.Context, config SenderConfig) {
+	s.logger.Info(ctx).Msg("starting push sender")
+
+	opt, err := redis.ParseURL(config.ConnectionString)
+
+	if err != nil {
+		panic(err)
+	}
+
+	opt.DialTimeout = config.DialTimeout
+	opt.MinIdleConns = config.MinIdleConns
+	opt.PoolSize = config.PoolSize
+	opt.ReadTimeout = config.ReadTimeout
+	opt.WriteTimeout = config.WriteTimeout
+
+	s.client = redis.NewClient(opt)
+
+	_ = s.client.XGroupCreateMkStream(ctx, common.CommentCreatedStream, config.Group, "$").Err()
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.logger.Info(ctx).Msg("push sender stopped")
+			return
+		default:
+			s.execute(ctx, config.Group, config.Consumer)
+		}
+	}
+}
+
+func (s *sender) Shutdown(ctx context.Context) {
+	s.logger.Info(ctx).Msg("shutting down push sender")
+
+	if err := s.client.Close(); err != nil {
+		s.logger.Error(ctx).Err(err).Msg("error closing redis client")
+	}
+}
+
+func (s *sender) execute(ctx context.Context, group string, consumer string) {
+	messages, err := s.readMessages(ctx, group, consumer)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			s.logger.Error(ctx).Err(err).Msg("error reading messages from stream")
+			time.Sleep(time.Second)
+		}
+		return
+	}
+
+	commentIds := []int64{}
+	seenCommentIds := map[int64]bool{}
+	for _, message := range messages {
+		body := []byte(message.Values["body"].(string))
+		commentMessage, err := common.Unmarshal[common.CommentCreatedMessage](body)
+		if err != nil {
+			s.logger.Error(ctx).Err(err).Msgf("error parsing comment created message: %v %v", message.ID, message.Values)
+			continue
+		}
+		// claimed messages can be delivered more than once
+		if !seenCommentIds[commentMessage.Id] {
+			seenCommentIds[commentMessage.Id] = true
+			commentIds = append(commentIds, commentMessage.Id)
+		}
+	}
+
+	s.sendPushNotificationsUseCase.Execute(ctx, usecases.SendPushNotificationsInput{
+		CommentIds: commentIds,
+	})
+
+	// leave the messages pending to be claimed again if we were interrupted
+	if ctx.Err() != nil {
+		return
+	}
+
+	for _, message := range messages {
+		if err := s.client.XAck(ctx, common.CommentCreatedStream, group, message.ID).Err(); err != nil {
+			s.logger.Error(ctx).Err(err).Msgf("error acknowledging message: %v %v", common.CommentCreatedStream, message.ID)
+		}
+	}
+}
+
+// Reads messages from the stream starting by checking the pending messages that are unacknowledged
+// If there are no messages, block for 5 seconds
+func (s *sender) readMessages(ctx context.Context, group string, consumer string) ([]redis.XMessage, error) {
+	messages, _, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+		Stream:  common.CommentCreatedStream,
+		Group:   group,
+		Start:   "0-0",
+		MinIdle: time.Minute * 5,
+		Count:   100,
+	}).Result()
+
+	if err != nil && err != redis.Nil {
+		return []redis.XMessage{}, fmt.Errorf("error claiming pending messages from stream: %v %w", common.CommentCreatedStream, err)
+	}
+
+	if len(messages) > 0 {
+		s.logger.Info(ctx).Msgf("claimed %v pending messages from stream %v group %v", len(messages), common.CommentCreatedStream, group)
+		return messages, nil
+	}
+
+	results, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  []string{common.CommentCreatedStream, ">"},
+		Block:    time.Second * 5,
+		Count:    100,
+	}).Result()
+
+	if err == redis.Nil {
+		return []redis.XMessage{}, nil
+	}
+
+	if err != nil {
+		return []redis.XMessage{}, err
+	}
+
+	messages = []redis.XMessage{}
+	for _, result := range results {
+		messages = append(messages, result.Messages...)
+	}
+
+	return messages, nil
+}
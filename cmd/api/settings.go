@@ -1,11 +1,16 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/daochanio/backend/cmd/api/http"
+	"github.com/daochanio/backend/cmd/api/push"
 	"github.com/daochanio/backend/cmd/api/subscribe"
+	"github.com/daochanio/backend/cmd/api/webhook"
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/gateways"
 	"github.com/joho/godotenv"
@@ -15,11 +20,15 @@ type Settings interface {
 	LoggerConfig() common.LoggerConfig
 	HttpConfig() http.HttpConfig
 	SubscriberConfig() subscribe.SubscriberConfig
+	DelivererConfig() webhook.DelivererConfig
+	SenderConfig() push.SenderConfig
 	DatabaseConfig() gateways.DatabaseConfig
 	StreamConfig() gateways.StreamConfig
 	CacheConfig() gateways.CacheConfig
 	BlockchainConfig() gateways.BlockchainConfig
 	ImagesConfig() gateways.ImagesConfig
+	WebhooksConfig() gateways.WebhooksConfig
+	WebPushConfig() gateways.WebPushConfig
 }
 
 type settings struct {
@@ -31,10 +40,21 @@ type settings struct {
 	redisCacheConnectionString  string
 	redisStreamConnectionString string
 	jwtSecret                   string
+	cursorSecret                string
+	editWindow                  time.Duration
+	restoreWindow               time.Duration
+	admins                      []string
+	reportThreshold             int64
+	eventConnectionsPerIP       int64
 	blockchainURL               string
 	realIPHeader                string
 	imagesBaseUrl               string
 	imagesAPIKey                string
+	webhookTimeout              time.Duration
+	webhookMaxFailures          int64
+	vapidPublicKey              string
+	vapidPrivateKey             string
+	vapidSubject                string
 }
 
 func NewSettings() Settings {
@@ -54,13 +74,53 @@ func NewSettings() Settings {
 		redisCacheConnectionString:  os.Getenv("REDIS_CACHE_CONNECTION_STRING"),
 		redisStreamConnectionString: os.Getenv("REDIS_STREAM_CONNECTION_STRING"),
 		jwtSecret:                   os.Getenv("JWT_SECRET"),
+		cursorSecret:                os.Getenv("CURSOR_SECRET"),
+		editWindow:                  parseDuration(os.Getenv("EDIT_WINDOW"), 15*time.Minute),
+		restoreWindow:               parseDuration(os.Getenv("RESTORE_WINDOW"), 24*time.Hour),
+		admins:                      parseList(os.Getenv("ADMIN_ADDRESSES")),
+		reportThreshold:             parseInt(os.Getenv("REPORT_THRESHOLD"), 5),
+		eventConnectionsPerIP:       parseInt(os.Getenv("EVENT_CONNECTIONS_PER_IP"), 5),
 		blockchainURL:               os.Getenv("BLOCKCHAIN_URI"),
 		realIPHeader:                os.Getenv("REAL_IP_HEADER"),
 		imagesBaseUrl:               os.Getenv("IMAGES_BASE_URL"),
 		imagesAPIKey:                os.Getenv("IMAGES_API_KEY"),
+		webhookTimeout:              parseDuration(os.Getenv("WEBHOOK_TIMEOUT"), 10*time.Second),
+		webhookMaxFailures:          parseInt(os.Getenv("WEBHOOK_MAX_FAILURES"), 10),
+		vapidPublicKey:              os.Getenv("VAPID_PUBLIC_KEY"),
+		vapidPrivateKey:             os.Getenv("VAPID_PRIVATE_KEY"),
+		vapidSubject:                os.Getenv("VAPID_SUBJECT"),
 	}
 }
 
+// falls back to the default when the value is unset or invalid
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fallback
+	}
+	return duration
+}
+
+// falls back to the default when the value is unset or invalid
+func parseInt(value string, fallback int64) int64 {
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return i
+}
+
+// comma separated values with empty entries removed
+func parseList(value string) []string {
+	list := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
 func (s *settings) LoggerConfig() common.LoggerConfig {
 	return common.LoggerConfig{
 		Env:      s.env,
@@ -71,9 +131,16 @@ func (s *settings) LoggerConfig() common.LoggerConfig {
 
 func (s *settings) HttpConfig() http.HttpConfig {
 	return http.HttpConfig{
-		Port:         s.port,
-		JWTSecret:    s.jwtSecret,
-		RealIPHeader: s.realIPHeader,
+		Port:                  s.port,
+		JWTSecret:             s.jwtSecret,
+		RealIPHeader:          s.realIPHeader,
+		CursorSecret:          s.cursorSecret,
+		EditWindow:            s.editWindow,
+		RestoreWindow:         s.restoreWindow,
+		Admins:                s.admins,
+		ReportThreshold:       s.reportThreshold,
+		EventConnectionsPerIP: s.eventConnectionsPerIP,
+		VAPIDPublicKey:        s.vapidPublicKey,
 	}
 }
 
@@ -109,6 +176,33 @@ func (s *settings) SubscriberConfig() subscribe.SubscriberConfig {
 	}
 }
 
+func (s *settings) DelivererConfig() webhook.DelivererConfig {
+	return webhook.DelivererConfig{
+		Group:            fmt.Sprintf("%v-webhooks", s.appname),
+		Consumer:         s.hostname,
+		MaxFailures:      s.webhookMaxFailures,
+		ConnectionString: s.redisStreamConnectionString,
+		DialTimeout:      10 * time.Second,
+		MinIdleConns:     10,
+		PoolSize:         100,
+		ReadTimeout:      -1,
+		WriteTimeout:     -1,
+	}
+}
+
+func (s *settings) SenderConfig() push.SenderConfig {
+	return push.SenderConfig{
+		Group:            fmt.Sprintf("%v-push", s.appname),
+		Consumer:         s.hostname,
+		ConnectionString: s.redisStreamConnectionString,
+		DialTimeout:      10 * time.Second,
+		MinIdleConns:     10,
+		PoolSize:         100,
+		ReadTimeout:      -1,
+		WriteTimeout:     -1,
+	}
+}
+
 func (s *settings) StreamConfig() gateways.StreamConfig {
 	return gateways.StreamConfig{
 		ConnectionString: s.redisStreamConnectionString,
@@ -133,3 +227,19 @@ func (s *settings) ImagesConfig() gateways.ImagesConfig {
 		APIKey:  s.imagesAPIKey,
 	}
 }
+
+func (s *settings) WebhooksConfig() gateways.WebhooksConfig {
+	return gateways.WebhooksConfig{
+		Timeout: s.webhookTimeout,
+	}
+}
+
+func (s *settings) WebPushConfig() gateways.WebPushConfig {
+	return gateways.WebPushConfig{
+		VAPIDPublicKey:  s.vapidPublicKey,
+		VAPIDPrivateKey: s.vapidPrivateKey,
+		VAPIDSubject:    s.vapidSubject,
+		TTL:             24 * time.Hour,
+		Timeout:         10 * time.Second,
+	}
+}
@@ -18,12 +18,13 @@ type Subscriber interface {
 }
 
 type subscriber struct {
-	logger                common.Logger
-	client                *redis.Client
-	aggregateVotesUseCase *usecases.AggregateVotes
-	hydrateUsersUseCase   *usecases.HydrateUsers
-	messageBuffer         *[]bufferMessage
-	lastFlush             time.Time
+	logger                     common.Logger
+	client                     *redis.Client
+	aggregateVotesUseCase      *usecases.AggregateVotes
+	hydrateUsersUseCase        *usecases.HydrateUsers
+	createNotificationsUseCase *usecases.CreateNotifications
+	messageBuffer              *[]bufferMessage
+	lastFlush                  time.Time
 }
 
 type bufferMessage struct {
@@ -46,14 +47,16 @@ func NewSubscriber(
 	logger common.Logger,
 	aggregateVotesUseCase *usecases.AggregateVotes,
 	hydrateUsersUseCase *usecases.HydrateUsers,
+	createNotificationsUseCase *usecases.CreateNotifications,
 ) Subscriber {
 	return &subscriber{
-		logger:                logger,
-		client:                nil,
-		aggregateVotesUseCase: aggregateVotesUseCase,
-		hydrateUsersUseCase:   hydrateUsersUseCase,
-		messageBuffer:         nil,
-		lastFlush:             time.Now(),
+		logger:                     logger,
+		client:                     nil,
+		aggregateVotesUseCase:      aggregateVotesUseCase,
+		hydrateUsersUseCase:        hydrateUsersUseCase,
+		createNotificationsUseCase: createNotificationsUseCase,
+		messageBuffer:              nil,
+		lastFlush:                  time.Now(),
 	}
 }
 
@@ -86,6 +89,7 @@ func (s *subscriber) Start(ctx context.Context, config SubscriberConfig) {
 
 	_ = s.client.XGroupCreateMkStream(ctx, common.SigninStream, config.Group, "$").Err()
 	_ = s.client.XGroupCreateMkStream(ctx, common.VoteStream, config.Group, "$").Err()
+	_ = s.client.XGroupCreateMkStream(ctx, common.CommentCreatedStream, config.Group, "$").Err()
 
 	for {
 		select {
@@ -127,7 +131,7 @@ func (s *subscriber) execute(ctx context.Context, group string, consumer string)
 // Reads messages from the streams starting by checking the pending messages that are unacknowledged
 // If there are no messages, block for 10 seconds
 func (s *subscriber) readMessages(ctx context.Context, group string, consumer string) ([]redis.XStream, error) {
-	for _, stream := range []string{common.SigninStream, common.VoteStream} {
+	for _, stream := range []string{common.SigninStream, common.VoteStream, common.CommentCreatedStream} {
 		messages, _, err := s.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
 			Stream:  stream,
 			Group:   group,
@@ -153,7 +157,7 @@ func (s *subscriber) readMessages(ctx context.Context, group string, consumer st
 	results, err := s.client.XReadGroup(ctx, &redis.XReadGroupArgs{
 		Group:    group,
 		Consumer: consumer,
-		Streams:  []string{common.SigninStream, common.VoteStream, ">", ">"},
+		Streams:  []string{common.SigninStream, common.VoteStream, common.CommentCreatedStream, ">", ">", ">"},
 		Block:    time.Second * 5,
 		Count:    100,
 	}).Result()
@@ -188,6 +192,8 @@ func (s *subscriber) flushBuffer(ctx context.Context) {
 	s.logger.Info(ctx).Msgf("flushing buffer with size %v", len(*s.messageBuffer))
 	userAddresses := []string{}
 	votes := []entities.Vote{}
+	commentIds := []int64{}
+	seenCommentIds := map[int64]bool{}
 	for _, bufferMessage := range *s.messageBuffer {
 		stream := bufferMessage.stream.Stream
 		message := bufferMessage.message
@@ -212,6 +218,19 @@ func (s *subscriber) flushBuffer(ctx context.Context) {
 				}
 				userAddresses = append(userAddresses, signinMessage.Address)
 			}
+		case common.CommentCreatedStream:
+			{
+				commentMessage, err := common.Unmarshal[common.CommentCreatedMessage](body)
+				if err != nil {
+					s.logger.Error(ctx).Err(err).Msgf("error parsing comment created message: %v %v %v", stream, message.ID, message.Values)
+					continue
+				}
+				// claimed messages can be delivered more than once
+				if !seenCommentIds[commentMessage.Id] {
+					seenCommentIds[commentMessage.Id] = true
+					commentIds = append(commentIds, commentMessage.Id)
+				}
+			}
 		default:
 			{
 				s.logger.Error(ctx).Msgf("inavlid stream %v", bufferMessage.stream)
@@ -224,10 +243,11 @@ func (s *subscriber) flushBuffer(ctx context.Context) {
 	s.messageBuffer = &[]bufferMessage{}
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 
 	go s.aggregateVotes(ctx, &wg, votes)
 	go s.hydrateUsers(ctx, &wg, userAddresses)
+	go s.notify(ctx, &wg, commentIds)
 
 	wg.Wait()
 }
@@ -255,3 +275,15 @@ func (s *subscriber) hydrateUsers(ctx context.Context, wg *sync.WaitGroup, addre
 		Addresses: addresses,
 	})
 }
+
+func (s *subscriber) notify(ctx context.Context, wg *sync.WaitGroup, commentIds []int64) {
+	defer wg.Done()
+
+	if len(commentIds) == 0 {
+		return
+	}
+
+	s.createNotificationsUseCase.Execute(ctx, usecases.CreateNotificationsInput{
+		CommentIds: commentIds,
+	})
+}
@@ -0,0 +1,229 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/usecases"
+	"github.com/redis/go-redis/v9"
+)
+
+// the streams that are delivered to webhooks and the event type each of them is delivered as
+var streamEvents = map[string]entities.WebhookEventType{
+	common.ThreadCreatedStream:  entities.ThreadCreatedWebhookEvent,
+	common.CommentCreatedStream: entities.CommentCreatedWebhookEvent,
+	common.VoteStream:           entities.VoteCreatedWebhookEvent,
+	common.ReputationStream:     entities.ReputationUpdatedWebhookEvent,
+}
+
+var streams = []string{common.ThreadCreatedStream, common.CommentCreatedStream, common.VoteStream, common.ReputationStream}
+
+// pending messages idle for longer are claimed by other replicas
+const claimMinIdle = time.Minute * 5
+
+// deliveries still pending after this are logged as failed, so a batch is acknowledged well before it can be claimed again
+const batchTimeout = time.Minute * 2
+
+type Deliverer interface {
+	Start(ctx context.Context, config DelivererConfig)
+	Shutdown(ctx context.Context)
+}
+
+type deliverer struct {
+	logger                 common.Logger
+	client                 *redis.Client
+	deliverWebhooksUseCase *usecases.DeliverWebhooks
+}
+
+type DelivererConfig struct {
+	// must differ from the group of the subscriber so both receive every message
+	Group            string
+	Consumer         string
+	MaxFailures      int64
+	ConnectionString string
+	DialTimeout      time.Duration
+	MinIdleConns     int
+	PoolSize         int
+	ReadTimeout      time.Duration
+	WriteTimeout     time.Duration
+}
+
+func NewDeliverer(
+	logger common.Logger,
+	deliverWebhooksUseCase *usecases.DeliverWebhooks,
+) Deliverer {
+	return &deliverer{
+		logger:                 logger,
+		client:                 nil,
+		deliverWebhooksUseCase: deliverWebhooksUseCase,
+	}
+}
+
+// Reads the platform events from their streams with a dedicated consumer group and delivers them to webhooks.
+// Each message is acknowledged as soon as every delivery of its event has been logged.
+// A replica that dies mid batch leaves its unacknowledged messages pending, they are claimed by another replica and delivered again,
+// so receivers should de-duplicate on the event id.
+func (d *deliverer) Start(ctx context.Context, config DelivererConfig) {
+	d.logger.Info(ctx).Msg("starting webhook deliverer")
+
+	opt, err := redis.ParseURL(config.ConnectionString)
+
+	if err != nil {
+		panic(err)
+	}
+
+	opt.DialTimeout = config.DialTimeout
+	opt.MinIdleConns = config.MinIdleConns
+	opt.PoolSize = config.PoolSize
+	opt.ReadTimeout = config.ReadTimeout
+	opt.WriteTimeout = config.WriteTimeout
+
+	d.client = redis.NewClient(opt)
+
+	for _, stream := range streams {
+		_ = d.client.XGroupCreateMkStream(ctx, stream, config.Group, "$").Err()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			d.logger.Info(ctx).Msg("webhook deliverer stopped")
+			return
+		default:
+			d.execute(ctx, config)
+		}
+	}
+}
+
+func (d *deliverer) Shutdown(ctx context.Context) {
+	d.logger.Info(ctx).Msg("shutting down webhook deliverer")
+
+	if err := d.client.Close(); err != nil {
+		d.logger.Error(ctx).Err(err).Msg("error closing redis client")
+	}
+}
+
+func (d *deliverer) execute(ctx context.Context, config DelivererConfig) {
+	results, err := d.readMessages(ctx, config.Group, config.Consumer)
+
+	if err != nil {
+		if ctx.Err() == nil {
+			d.logger.Error(ctx).Err(err).Msg("error reading messages from streams")
+			time.Sleep(time.Second)
+		}
+		return
+	}
+
+	events := []entities.WebhookEvent{}
+	for _, result := range results {
+		for _, message := range result.Messages {
+			event, err := toWebhookEvent(result.Stream, message)
+
+			if err != nil {
+				// unparsable messages are acknowledged since they will never succeed
+				d.logger.Error(ctx).Err(err).Msgf("error parsing message: %v %v %v", result.Stream, message.ID, message.Values)
+				d.ack(ctx, config.Group, result.Stream, message.ID)
+				continue
+			}
+
+			events = append(events, event)
+		}
+	}
+
+	if err := d.deliverWebhooksUseCase.Execute(ctx, usecases.DeliverWebhooksInput{
+		Events:      events,
+		MaxFailures: config.MaxFailures,
+		Timeout:     batchTimeout,
+		OnDelivered: func(event entities.WebhookEvent) {
+			// each event type is read from a single stream
+			for stream, eventType := range streamEvents {
+				if eventType == event.Type() {
+					d.ack(ctx, config.Group, stream, event.Id())
+				}
+			}
+		},
+	}); err != nil {
+		d.logger.Warn(ctx).Err(err).Msgf("stopped delivering %v events", len(events))
+	}
+}
+
+func (d *deliverer) ack(ctx context.Context, group string, stream string, id string) {
+	if err := d.client.XAck(ctx, stream, group, id).Err(); err != nil {
+		d.logger.Error(ctx).Err(err).Msgf("error acknowledging message: %v %v", stream, id)
+	}
+}
+
+// Reads messages from the streams starting by checking the pending messages that are unacknowledged
+// If there are no messages, block for 5 seconds
+func (d *deliverer) readMessages(ctx context.Context, group string, consumer string) ([]redis.XStream, error) {
+	for _, stream := range streams {
+		messages, _, err := d.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+			Stream:  stream,
+			Group:   group,
+			Start:   "0-0",
+			MinIdle: claimMinIdle,
+			Count:   100,
+		}).Result()
+
+		if err != nil && err != redis.Nil {
+			return []redis.XStream{}, fmt.Errorf("error claiming pending messages from stream: %v %w", stream, err)
+		}
+
+		if len(messages) > 0 {
+			d.logger.Info(ctx).Msgf("claimed %v pending messages from stream %v group %v", len(messages), stream, group)
+
+			return []redis.XStream{{
+				Stream:   stream,
+				Messages: messages,
+			}}, nil
+		}
+	}
+
+	args := append([]string{}, streams...)
+	for range streams {
+		args = append(args, ">")
+	}
+
+	results, err := d.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    group,
+		Consumer: consumer,
+		Streams:  args,
+		Block:    time.Second * 5,
+		Count:    100,
+	}).Result()
+
+	if err == redis.Nil {
+		return []redis.XStream{}, nil
+	}
+
+	return results, err
+}
+
+// the event is created at the time embedded in the id of the message
+func toWebhookEvent(stream string, message redis.XMessage) (entities.WebhookEvent, error) {
+	eventType, ok := streamEvents[stream]
+
+	if !ok {
+		return entities.WebhookEvent{}, fmt.Errorf("invalid stream %v", stream)
+	}
+
+	body, ok := message.Values["body"].(string)
+
+	if !ok || !json.Valid([]byte(body)) {
+		return entities.WebhookEvent{}, fmt.Errorf("invalid body")
+	}
+
+	millis, err := strconv.ParseInt(strings.Split(message.ID, "-")[0], 10, 64)
+
+	if err != nil {
+		return entities.WebhookEvent{}, fmt.Errorf("invalid message id: %w", err)
+	}
+
+	return entities.NewWebhookEvent(message.ID, eventType, json.RawMessage(body), time.UnixMilli(millis).UTC()), nil
+}
@@ -0,0 +1,59 @@
+package archive
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+type Archiver interface {
+	Start(ctx context.Context, config ArchiverConfig)
+	Shutdown(ctx context.Context)
+}
+
+type archiver struct {
+	logger         common.Logger
+	archiveThreads *usecases.ArchiveThreads
+}
+
+type ArchiverConfig struct {
+	// how often inactive threads are archived
+	Interval time.Duration
+	// threads without activity for this long are archived
+	Inactivity time.Duration
+}
+
+func NewArchiver(logger common.Logger, archiveThreads *usecases.ArchiveThreads) Archiver {
+	return &archiver{
+		logger,
+		archiveThreads,
+	}
+}
+
+func (a *archiver) Start(ctx context.Context, config ArchiverConfig) {
+	a.logger.Info(ctx).Msg("starting archiver")
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.archiveThreads.Execute(ctx, usecases.ArchiveThreadsInput{
+			Inactivity: config.Inactivity,
+		}); err != nil {
+			a.logger.Error(ctx).Err(err).Msg("error archiving threads")
+		}
+
+		select {
+		case <-ctx.Done():
+			a.logger.Info(ctx).Msg("archiver stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *archiver) Shutdown(ctx context.Context) {
+	a.logger.Info(ctx).Msg("shutting down archiver")
+}
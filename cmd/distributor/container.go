@@ -3,10 +3,12 @@ package main
 import (
 	"context"
 
+	"github.com/daochanio/backend/cmd/distributor/archive"
 	"github.com/daochanio/backend/cmd/distributor/distribute"
 	"github.com/daochanio/backend/cmd/distributor/subscribe"
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/usecases"
+	"github.com/daochanio/backend/gateways/postgres"
 	"go.uber.org/dig"
 )
 
@@ -27,15 +29,30 @@ func newContainer(ctx context.Context) *dig.Container {
 	if err := container.Provide(NewSettings); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(postgres.NewDatabaseGateway); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(usecases.NewDistribute); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewPreviewDistribution); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(usecases.NewProcessVote); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(usecases.NewArchiveThreadsUseCase); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(distribute.NewDistributor); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(distribute.NewPreviewer); err != nil {
+		panic(err)
+	}
+	if err := container.Provide(archive.NewArchiver); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(subscribe.NewSubscriber); err != nil {
 		panic(err)
 	}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/usecases"
 )
 
@@ -21,6 +22,7 @@ type distributor struct {
 
 type DistributorConfig struct {
 	Interval time.Duration
+	Formula  entities.DistributionFormula
 }
 
 func NewDistributor(logger common.Logger, createDistribution *usecases.Distribute) Distributor {
@@ -41,12 +43,13 @@ func (d *distributor) Start(ctx context.Context, config DistributorConfig) {
 		default:
 			if err := d.createDistribution.Execute(ctx, usecases.DistributeInput{
 				Interval: config.Interval,
+				Formula:  config.Formula,
 			}); err != nil {
-				if errors.Is(err, common.ErrNotDistributionTime) {
-					time.Sleep(10 * time.Second)
-				} else {
+				if !errors.Is(err, common.ErrNotDistributionTime) {
 					d.logger.Error(ctx).Err(err).Msg("error running distribution")
 				}
+				// failed rounds are retried after the same pause so a persistent error does not spin
+				time.Sleep(10 * time.Second)
 			}
 		}
 	}
@@ -0,0 +1,129 @@
+package distribute
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/daochanio/backend/domain/usecases"
+)
+
+// Runs the computation of a distribution round once without writing anything and reports what the round would do
+type Previewer interface {
+	Run(ctx context.Context, config PreviewConfig) error
+}
+
+type previewer struct {
+	logger              common.Logger
+	previewDistribution *usecases.PreviewDistribution
+}
+
+type PreviewConfig struct {
+	Formula  entities.DistributionFormula
+	Interval time.Duration
+	// nil to preview a round running now
+	Range *gateways.VoteDecisionsSpec
+	// where the report is written to as json
+	Output io.Writer
+}
+
+type previewJson struct {
+	Pool        string                  `json:"pool"`
+	Amount      string                  `json:"amount"`
+	MerkleRoot  string                  `json:"merkleRoot"`
+	Allocations []previewAllocationJson `json:"allocations"`
+	// accepted votes, unvotes are considered but carry no weight
+	Considered []previewVoteJson `json:"considered"`
+	Discarded  []previewVoteJson `json:"discarded"`
+}
+
+type previewAllocationJson struct {
+	Address string `json:"address"`
+	Amount  string `json:"amount"`
+	Votes   int64  `json:"votes"`
+}
+
+type previewVoteJson struct {
+	DecisionId string    `json:"decisionId"`
+	Type       string    `json:"type"`
+	TargetId   string    `json:"targetId"`
+	Voter      string    `json:"voter"`
+	Author     *string   `json:"author,omitempty"`
+	Value      string    `json:"value"`
+	Reputation string    `json:"reputation"`
+	VotedAt    time.Time `json:"votedAt"`
+	Reason     string    `json:"reason,omitempty"`
+}
+
+func NewPreviewer(logger common.Logger, previewDistribution *usecases.PreviewDistribution) Previewer {
+	return &previewer{
+		logger,
+		previewDistribution,
+	}
+}
+
+func (p *previewer) Run(ctx context.Context, config PreviewConfig) error {
+	p.logger.Info(ctx).Msg("previewing distribution")
+
+	allocations, votes, merkleRoot, err := p.previewDistribution.Execute(ctx, usecases.PreviewDistributionInput{
+		Formula:  config.Formula,
+		Interval: config.Interval,
+		Range:    config.Range,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	amount := big.NewInt(0)
+	preview := previewJson{
+		Pool:        config.Formula.Pool.String(),
+		MerkleRoot:  merkleRoot,
+		Allocations: []previewAllocationJson{},
+		Considered:  []previewVoteJson{},
+		Discarded:   []previewVoteJson{},
+	}
+
+	for _, allocation := range allocations {
+		amount.Add(amount, allocation.Amount())
+		preview.Allocations = append(preview.Allocations, previewAllocationJson{
+			Address: allocation.Address(),
+			Amount:  allocation.Amount().String(),
+			Votes:   allocation.Votes(),
+		})
+	}
+	preview.Amount = amount.String()
+
+	for _, vote := range votes {
+		decision := vote.Decision()
+		json := previewVoteJson{
+			DecisionId: fmt.Sprint(vote.DecisionId()),
+			Type:       string(decision.Type()),
+			TargetId:   fmt.Sprint(decision.TargetId()),
+			Voter:      decision.Voter(),
+			Author:     decision.Author(),
+			Value:      string(decision.Value()),
+			Reputation: decision.Reputation().String(),
+			VotedAt:    decision.VotedAt(),
+		}
+
+		if reason := vote.Reason(); reason != nil {
+			json.Reason = string(*reason)
+			preview.Discarded = append(preview.Discarded, json)
+		} else {
+			preview.Considered = append(preview.Considered, json)
+		}
+	}
+
+	p.logger.Info(ctx).Msgf("previewed %v allocations from %v considered and %v discarded votes", len(preview.Allocations), len(preview.Considered), len(preview.Discarded))
+
+	encoder := json.NewEncoder(config.Output)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(preview)
+}
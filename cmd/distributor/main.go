@@ -2,42 +2,139 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
+	"os"
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
+	"github.com/daochanio/backend/cmd/distributor/archive"
 	"github.com/daochanio/backend/cmd/distributor/distribute"
 	"github.com/daochanio/backend/cmd/distributor/subscribe"
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
 )
 
 func main() {
+	dryRun := flag.Bool("dry-run", false, "print the allocations a distribution round would make as json without writing anything and exit")
+	from := flag.String("from", "", "with -dry-run, only weigh votes cast at or after this RFC3339 time")
+	to := flag.String("to", "", "with -dry-run, only weigh votes cast at or before this RFC3339 time")
+	fromVote := flag.Int64("from-vote", 0, "with -dry-run, only weigh vote decisions with an id of at least this")
+	toVote := flag.Int64("to-vote", 0, "with -dry-run, only weigh vote decisions with an id of at most this")
+	flag.Parse()
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
 	defer stop()
 
 	container := newContainer(ctx)
 
-	if err := container.Invoke(start); err != nil {
-		panic(err)
+	if !*dryRun {
+		if err := container.Invoke(start); err != nil {
+			panic(err)
+		}
+		return
+	}
+
+	previewRange, err := parsePreviewRange(*from, *to, *fromVote, *toVote)
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	if err := container.Invoke(func(logger common.Logger, settings Settings, database gateways.Database, previewer distribute.Previewer) error {
+		return preview(ctx, logger, settings, database, previewer, previewRange)
+	}); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 }
 
+// Without any bounds the preview is of a round running now over the votes not consumed by a distribution yet
+func parsePreviewRange(from string, to string, fromVote int64, toVote int64) (*gateways.VoteDecisionsSpec, error) {
+	if from == "" && to == "" && fromVote == 0 && toVote == 0 {
+		return nil, nil
+	}
+
+	spec := gateways.VoteDecisionsSpec{}
+
+	if from != "" {
+		votedAfter, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -from: %w", err)
+		}
+		votedAfter = votedAfter.UTC()
+		spec.VotedAfter = &votedAfter
+	}
+
+	if to != "" {
+		votedBefore, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -to: %w", err)
+		}
+		votedBefore = votedBefore.UTC()
+		spec.VotedBefore = &votedBefore
+	}
+
+	if fromVote != 0 {
+		spec.FromId = &fromVote
+	}
+
+	if toVote != 0 {
+		spec.ToId = &toVote
+	}
+
+	return &spec, nil
+}
+
+func preview(
+	ctx context.Context,
+	logger common.Logger,
+	settings Settings,
+	database gateways.Database,
+	previewer distribute.Previewer,
+	previewRange *gateways.VoteDecisionsSpec,
+) error {
+	logger.Start(ctx, settings.LoggerConfig())
+	database.Start(ctx, settings.DatabaseConfig())
+	defer database.Shutdown(context.Background())
+
+	config := settings.DistributorConfig()
+
+	return previewer.Run(ctx, distribute.PreviewConfig{
+		Formula:  config.Formula,
+		Interval: config.Interval,
+		Range:    previewRange,
+		Output:   os.Stdout,
+	})
+}
+
 func start(
 	ctx context.Context,
 	logger common.Logger,
 	settings Settings,
+	database gateways.Database,
 	distributor distribute.Distributor,
+	archiver archive.Archiver,
 	subscriber subscribe.Subscriber,
 ) {
 	logger.Start(ctx, settings.LoggerConfig())
+	database.Start(ctx, settings.DatabaseConfig())
 
 	var wg sync.WaitGroup
-	wg.Add(2)
+	wg.Add(3)
 	go func() {
 		defer wg.Done()
 		distributor.Start(ctx, settings.DistributorConfig())
 	}()
 
+	go func() {
+		defer wg.Done()
+		archiver.Start(ctx, settings.ArchiverConfig())
+	}()
+
 	go func() {
 		defer wg.Done()
 		subscriber.Start(ctx, settings.SubscribeConfig())
@@ -55,7 +152,11 @@ func start(
 
 	distributor.Shutdown(shutdownCtx)
 
+	archiver.Shutdown(shutdownCtx)
+
 	subscriber.Shutdown(shutdownCtx)
 
+	database.Shutdown(shutdownCtx)
+
 	logger.Info(shutdownCtx).Msgf("shutdown complete")
 }
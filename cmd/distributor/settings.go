@@ -1,19 +1,26 @@
 package main
 
 import (
+	"fmt"
+	"math/big"
 	"os"
 	"strconv"
 	"time"
 
+	"github.com/daochanio/backend/cmd/distributor/archive"
 	"github.com/daochanio/backend/cmd/distributor/distribute"
 	"github.com/daochanio/backend/cmd/distributor/subscribe"
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
 	"github.com/joho/godotenv"
 )
 
 type Settings interface {
 	LoggerConfig() common.LoggerConfig
 	DistributorConfig() distribute.DistributorConfig
+	ArchiverConfig() archive.ArchiverConfig
+	DatabaseConfig() gateways.DatabaseConfig
 	SubscribeConfig() subscribe.SubscriberConfig
 }
 
@@ -23,6 +30,12 @@ type settings struct {
 	hostname              string
 	interval              time.Duration
 	redisConnectionString string
+	pgConnectionString    string
+	archiveInterval       time.Duration
+	archiveInactivity     time.Duration
+	formula               entities.DistributionFormula
+	minVoteReputation     *big.Int
+	maxVoteContentAge     time.Duration
 }
 
 func NewSettings() Settings {
@@ -39,13 +52,52 @@ func NewSettings() Settings {
 	}
 	interval := time.Duration(intervalMinutes) * time.Minute
 
+	pool, ok := new(big.Int).SetString(os.Getenv("DISTRIBUTION_POOL"), 10)
+	if !ok || pool.Sign() < 0 {
+		panic(fmt.Errorf("invalid DISTRIBUTION_POOL %v", os.Getenv("DISTRIBUTION_POOL")))
+	}
+
+	minVoteReputation, ok := new(big.Int).SetString(os.Getenv("VOTE_MIN_REPUTATION"), 10)
+	if !ok {
+		minVoteReputation = big.NewInt(0)
+	}
+
 	return &settings{
 		env:                   os.Getenv("ENV"),
 		appname:               os.Getenv("APP_NAME"),
 		hostname:              hostname,
 		interval:              interval,
 		redisConnectionString: os.Getenv("REDIS_CONNECTION_STRING"),
+		pgConnectionString:    os.Getenv("PG_CONNECTION_STRING"),
+		archiveInterval:       parseDuration(os.Getenv("ARCHIVE_INTERVAL"), time.Hour),
+		archiveInactivity:     parseDuration(os.Getenv("ARCHIVE_INACTIVITY"), 30*24*time.Hour),
+		formula: entities.DistributionFormula{
+			Pool: pool,
+			// every vote counts once plus once per whole token of reputation (18 decimals) of the voter
+			VoteWeight:       parseRat(os.Getenv("DISTRIBUTION_VOTE_WEIGHT"), "1"),
+			ReputationWeight: parseRat(os.Getenv("DISTRIBUTION_REPUTATION_WEIGHT"), "0.000000000000000001"),
+		},
+		minVoteReputation: minVoteReputation,
+		maxVoteContentAge: parseDuration(os.Getenv("VOTE_MAX_CONTENT_AGE"), 7*24*time.Hour),
+	}
+}
+
+// falls back to the default when the value is unset, invalid or not positive
+func parseDuration(value string, fallback time.Duration) time.Duration {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fallback
+	}
+	return duration
+}
+
+// decimals or fractions, falls back to the default when the value is unset or invalid
+func parseRat(value string, fallback string) *big.Rat {
+	if rat, ok := new(big.Rat).SetString(value); ok && rat.Sign() >= 0 {
+		return rat
 	}
+	rat, _ := new(big.Rat).SetString(fallback)
+	return rat
 }
 
 func (s *settings) LoggerConfig() common.LoggerConfig {
@@ -59,6 +111,22 @@ func (s *settings) LoggerConfig() common.LoggerConfig {
 func (s *settings) DistributorConfig() distribute.DistributorConfig {
 	return distribute.DistributorConfig{
 		Interval: s.interval,
+		Formula:  s.formula,
+	}
+}
+
+func (s *settings) ArchiverConfig() archive.ArchiverConfig {
+	return archive.ArchiverConfig{
+		Interval:   s.archiveInterval,
+		Inactivity: s.archiveInactivity,
+	}
+}
+
+func (s *settings) DatabaseConfig() gateways.DatabaseConfig {
+	return gateways.DatabaseConfig{
+		ConnectionString: s.pgConnectionString,
+		MinConnections:   10,
+		MaxConnections:   100,
 	}
 }
 
@@ -72,5 +140,7 @@ func (s *settings) SubscribeConfig() subscribe.SubscriberConfig {
 		PoolSize:         100,
 		ReadTimeout:      -1,
 		WriteTimeout:     -1,
+		MinReputation:    s.minVoteReputation,
+		MaxContentAge:    s.maxVoteContentAge,
 	}
 }
@@ -3,9 +3,11 @@ package subscribe
 import (
 	"context"
 	"fmt"
+	"math/big"
 	"time"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/usecases"
 	"github.com/redis/go-redis/v9"
 )
@@ -19,6 +21,7 @@ type subscriber struct {
 	logger       common.Logger
 	client       *redis.Client
 	processVotes *usecases.ProcessVote
+	config       SubscriberConfig
 }
 
 type SubscriberConfig struct {
@@ -30,6 +33,9 @@ type SubscriberConfig struct {
 	PoolSize         int
 	ReadTimeout      time.Duration
 	WriteTimeout     time.Duration
+	// the acceptance rules applied to votes
+	MinReputation *big.Int
+	MaxContentAge time.Duration
 }
 
 func NewSubscriber(
@@ -59,6 +65,7 @@ func (s *subscriber) Start(ctx context.Context, config SubscriberConfig) {
 	opt.WriteTimeout = config.WriteTimeout
 
 	s.client = redis.NewClient(opt)
+	s.config = config
 
 	_ = s.client.XGroupCreateMkStream(ctx, common.VoteStream, config.Group, "$").Err()
 
@@ -162,8 +169,12 @@ func (s *subscriber) processVoteMessage(ctx context.Context, messageID string, d
 
 	s.logger.Info(ctx).Msgf("processing vote: %v %v %v", vote.Id, vote.Type, vote.Address)
 
-	if err := s.processVotes.Execute(ctx); err != nil {
-		return fmt.Errorf("error processing vote: %v", messageID)
+	if err := s.processVotes.Execute(ctx, usecases.ProcessVoteInput{
+		Vote:          entities.NewVote(vote.Id, vote.Address, vote.Value, vote.Type, vote.UpdatedAt),
+		MinReputation: s.config.MinReputation,
+		MaxContentAge: s.config.MaxContentAge,
+	}); err != nil {
+		return fmt.Errorf("error processing vote: %v %w", messageID, err)
 	}
 
 	return nil
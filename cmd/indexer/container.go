@@ -8,6 +8,7 @@ import (
 	"github.com/daochanio/backend/domain/usecases"
 	"github.com/daochanio/backend/gateways/ethereum"
 	"github.com/daochanio/backend/gateways/postgres"
+	"github.com/daochanio/backend/gateways/redis"
 	"go.uber.org/dig"
 )
 
@@ -31,6 +32,9 @@ func newContainer(ctx context.Context) *dig.Container {
 	if err := container.Provide(postgres.NewDatabaseGateway); err != nil {
 		panic(err)
 	}
+	if err := container.Provide(redis.NewStreamGateway); err != nil {
+		panic(err)
+	}
 	if err := container.Provide(ethereum.NewEthereumGateway); err != nil {
 		panic(err)
 	}
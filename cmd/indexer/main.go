@@ -28,10 +28,12 @@ func start(
 	indexer index.Indexer,
 	settings Settings,
 	database gateways.Database,
+	stream gateways.Stream,
 	blockchain gateways.Blockchain,
 ) {
 	logger.Start(ctx, settings.LoggerConfig())
 	database.Start(ctx, settings.DatabaseConfig())
+	stream.Start(ctx, settings.StreamConfig())
 	blockchain.Start(ctx, settings.BlockchainConfig())
 
 	var wg sync.WaitGroup
@@ -55,6 +57,7 @@ func start(
 	indexer.Shutdown(shutdownCtx)
 
 	database.Shutdown(shutdownCtx)
+	stream.Shutdown(shutdownCtx)
 	blockchain.Shutdown(shutdownCtx)
 
 	logger.Info(ctx).Msgf("shutdown complete")
@@ -15,19 +15,21 @@ type Settings interface {
 	LoggerConfig() common.LoggerConfig
 	IndexerConfig() index.IndexerConfig
 	DatabaseConfig() gateways.DatabaseConfig
+	StreamConfig() gateways.StreamConfig
 	BlockchainConfig() gateways.BlockchainConfig
 }
 
 type settings struct {
-	env                string
-	appname            string
-	hostname           string
-	pgConnectionString string
-	blockchainURL      string
-	reputationAddress  string
-	reorgOffset        int64
-	interval           time.Duration
-	maxBlockRange      int64
+	env                         string
+	appname                     string
+	hostname                    string
+	pgConnectionString          string
+	redisStreamConnectionString string
+	blockchainURL               string
+	reputationAddress           string
+	reorgOffset                 int64
+	interval                    time.Duration
+	maxBlockRange               int64
 }
 
 func NewSettings() Settings {
@@ -55,15 +57,16 @@ func NewSettings() Settings {
 	}
 
 	return &settings{
-		env:                os.Getenv("ENV"),
-		appname:            os.Getenv("APP_NAME"),
-		hostname:           hostname,
-		pgConnectionString: os.Getenv("PG_CONNECTION_STRING"),
-		blockchainURL:      os.Getenv("BLOCKCHAIN_URI"),
-		reputationAddress:  os.Getenv("REPUTATION_ADDRESS"),
-		reorgOffset:        int64(reorgOffset),
-		interval:           interval,
-		maxBlockRange:      int64(maxBlockRange),
+		env:                         os.Getenv("ENV"),
+		appname:                     os.Getenv("APP_NAME"),
+		hostname:                    hostname,
+		pgConnectionString:          os.Getenv("PG_CONNECTION_STRING"),
+		redisStreamConnectionString: os.Getenv("REDIS_STREAM_CONNECTION_STRING"),
+		blockchainURL:               os.Getenv("BLOCKCHAIN_URI"),
+		reputationAddress:           os.Getenv("REPUTATION_ADDRESS"),
+		reorgOffset:                 int64(reorgOffset),
+		interval:                    interval,
+		maxBlockRange:               int64(maxBlockRange),
 	}
 }
 
@@ -91,6 +94,17 @@ func (s *settings) DatabaseConfig() gateways.DatabaseConfig {
 	}
 }
 
+func (s *settings) StreamConfig() gateways.StreamConfig {
+	return gateways.StreamConfig{
+		ConnectionString: s.redisStreamConnectionString,
+		DialTimeout:      10 * time.Second,
+		MinIdleConns:     10,
+		PoolSize:         100,
+		ReadTimeout:      -1,
+		WriteTimeout:     -1,
+	}
+}
+
 func (s *settings) BlockchainConfig() gateways.BlockchainConfig {
 	return gateways.BlockchainConfig{
 		BlockchainURL:     s.blockchainURL,
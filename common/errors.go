@@ -6,8 +6,12 @@ import (
 
 var (
 	ErrNotFound            = errors.New("not found")
+	ErrForbidden           = errors.New("forbidden")
 	ErrValidation          = errors.New("validation")
 	ErrRetryable           = errors.New("retryable")
+	ErrGone                = errors.New("gone")
 	ErrNoNewBlocks         = errors.New("no new blocks")
 	ErrNotDistributionTime = errors.New("not distribution time")
+	ErrThreadLocked        = errors.New("thread is locked")
+	ErrThreadArchived      = errors.New("thread is archived")
 )
@@ -0,0 +1,150 @@
+package common
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+type MerkleHash [32]byte
+
+func ParseMerkleHash(value string) (MerkleHash, error) {
+	var hash MerkleHash
+	decoded, err := hex.DecodeString(strings.TrimPrefix(value, "0x"))
+
+	if err != nil || len(decoded) != len(hash) {
+		return MerkleHash{}, fmt.Errorf("invalid merkle hash %v", value)
+	}
+
+	copy(hash[:], decoded)
+	return hash, nil
+}
+
+// 0x prefixed
+func (h MerkleHash) Hex() string {
+	return "0x" + hex.EncodeToString(h[:])
+}
+
+// A Merkle tree of (address, uint256) leaves laid out like the StandardMerkleTree of @openzeppelin/merkle-tree,
+// so the root and proofs can be verified on chain with the OpenZeppelin MerkleProof library.
+//
+// Leaves are sorted and stored in reverse at the end of the nodes, each parent at i has its children at 2i+1 and 2i+2.
+// Pairs are hashed in sorted order so proofs do not need to carry the side of each sibling.
+type MerkleTree struct {
+	nodes []MerkleHash
+}
+
+// keccak256(bytes.concat(keccak256(abi.encode(address, amount))))
+// The leaf is hashed twice so it can not be mistaken for an inner node.
+func MerkleLeaf(address string, amount *big.Int) (MerkleHash, error) {
+	addressBytes, err := hex.DecodeString(strings.TrimPrefix(address, "0x"))
+
+	if err != nil || len(addressBytes) != 20 {
+		return MerkleHash{}, fmt.Errorf("invalid address %v", address)
+	}
+
+	if amount.Sign() < 0 || amount.BitLen() > 256 {
+		return MerkleHash{}, fmt.Errorf("amount %v out of uint256 range", amount)
+	}
+
+	encoded := make([]byte, 64)
+	copy(encoded[12:32], addressBytes)
+	amount.FillBytes(encoded[32:])
+
+	inner := keccak256(encoded)
+	return keccak256(inner[:]), nil
+}
+
+// Builds the tree over the leaves, the order they are given in does not matter.
+// The tree of no leaves is empty and has a zero root.
+func NewMerkleTree(leaves []MerkleHash) MerkleTree {
+	if len(leaves) == 0 {
+		return MerkleTree{nodes: []MerkleHash{}}
+	}
+
+	sorted := make([]MerkleHash, len(leaves))
+	copy(sorted, leaves)
+	sort.Slice(sorted, func(i, j int) bool {
+		return bytes.Compare(sorted[i][:], sorted[j][:]) < 0
+	})
+
+	nodes := make([]MerkleHash, 2*len(sorted)-1)
+	for i, leaf := range sorted {
+		nodes[len(nodes)-1-i] = leaf
+	}
+	for i := len(nodes) - 1 - len(sorted); i >= 0; i-- {
+		nodes[i] = hashPair(nodes[2*i+1], nodes[2*i+2])
+	}
+
+	return MerkleTree{nodes: nodes}
+}
+
+// Restores a tree from its nodes as returned by Nodes
+func NewMerkleTreeFromNodes(nodes []MerkleHash) MerkleTree {
+	return MerkleTree{nodes: nodes}
+}
+
+func (t *MerkleTree) Root() MerkleHash {
+	if len(t.nodes) == 0 {
+		return MerkleHash{}
+	}
+	return t.nodes[0]
+}
+
+func (t *MerkleTree) Nodes() []MerkleHash {
+	return t.nodes
+}
+
+// The siblings from the leaf up to the root, false if the leaf is not in the tree
+func (t *MerkleTree) Proof(leaf MerkleHash) ([]MerkleHash, bool) {
+	leaves := (len(t.nodes) + 1) / 2
+
+	for i := len(t.nodes) - leaves; i < len(t.nodes); i++ {
+		if t.nodes[i] != leaf {
+			continue
+		}
+
+		proof := []MerkleHash{}
+		for j := i; j > 0; j = (j - 1) / 2 {
+			// odd nodes are left children
+			if j%2 == 1 {
+				proof = append(proof, t.nodes[j+1])
+			} else {
+				proof = append(proof, t.nodes[j-1])
+			}
+		}
+
+		return proof, true
+	}
+
+	return nil, false
+}
+
+// Mirrors MerkleProof.verify
+func VerifyMerkleProof(root MerkleHash, leaf MerkleHash, proof []MerkleHash) bool {
+	hash := leaf
+	for _, sibling := range proof {
+		hash = hashPair(hash, sibling)
+	}
+	return hash == root
+}
+
+func hashPair(a MerkleHash, b MerkleHash) MerkleHash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return keccak256(append(a[:], b[:]...))
+}
+
+func keccak256(data []byte) MerkleHash {
+	var hash MerkleHash
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(data)
+	copy(hash[:], hasher.Sum(nil))
+	return hash
+}
@@ -0,0 +1,119 @@
+package common
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+// The example values and root from the README of @openzeppelin/merkle-tree
+func TestMerkleTreeStandardVector(t *testing.T) {
+	first, _ := new(big.Int).SetString("5000000000000000000", 10)
+	second, _ := new(big.Int).SetString("2500000000000000000", 10)
+
+	firstLeaf, err := MerkleLeaf("0x1111111111111111111111111111111111111111", first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondLeaf, err := MerkleLeaf("0x2222222222222222222222222222222222222222", second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := "0xeb02c421cfa48976e66dfb29120745909ea3a0f843456c263cf8f1253483e283"; firstLeaf.Hex() != expected {
+		t.Errorf("expected leaf %v but got %v", expected, firstLeaf.Hex())
+	}
+	if expected := "0xb92c48e9d7abe27fd8dfd6b5dfdbfb1c9a463f80c712b66f3a5180a090cccafc"; secondLeaf.Hex() != expected {
+		t.Errorf("expected leaf %v but got %v", expected, secondLeaf.Hex())
+	}
+
+	tree := NewMerkleTree([]MerkleHash{firstLeaf, secondLeaf})
+	root := tree.Root()
+
+	if expected := "0xd4dee0beab2d53f2cc83e567171bd2820e49898130a22622b10ead383e90bd77"; root.Hex() != expected {
+		t.Errorf("expected root %v but got %v", expected, root.Hex())
+	}
+
+	proof, ok := tree.Proof(firstLeaf)
+	if !ok || len(proof) != 1 || proof[0] != secondLeaf {
+		t.Errorf("expected proof [%v] but got %v", secondLeaf.Hex(), proof)
+	}
+}
+
+func TestMerkleTreeProofs(t *testing.T) {
+	leaves := []MerkleHash{}
+	for i := 1; i <= 5; i++ {
+		leaf, err := MerkleLeaf(fmt.Sprintf("0x%040x", i), big.NewInt(int64(i*1000)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		leaves = append(leaves, leaf)
+	}
+
+	tree := NewMerkleTree(leaves)
+	reversed := NewMerkleTree([]MerkleHash{leaves[4], leaves[3], leaves[2], leaves[1], leaves[0]})
+
+	if tree.Root() != reversed.Root() {
+		t.Errorf("expected the root to not depend on the order of the leaves")
+	}
+
+	if len(tree.Nodes()) != 9 {
+		t.Errorf("expected 9 nodes but got %v", len(tree.Nodes()))
+	}
+
+	for i, leaf := range leaves {
+		proof, ok := tree.Proof(leaf)
+		if !ok {
+			t.Fatalf("expected leaf %v to be in the tree", i)
+		}
+		if !VerifyMerkleProof(tree.Root(), leaf, proof) {
+			t.Errorf("expected proof of leaf %v to verify", i)
+		}
+	}
+
+	missing, _ := MerkleLeaf(fmt.Sprintf("0x%040x", 6), big.NewInt(6000))
+	if _, ok := tree.Proof(missing); ok {
+		t.Errorf("expected no proof for a leaf not in the tree")
+	}
+
+	restored := NewMerkleTreeFromNodes(tree.Nodes())
+	proof, _ := restored.Proof(leaves[2])
+	if !VerifyMerkleProof(tree.Root(), leaves[2], proof) {
+		t.Errorf("expected proof from the restored tree to verify")
+	}
+}
+
+func TestMerkleTreeEmpty(t *testing.T) {
+	tree := NewMerkleTree([]MerkleHash{})
+
+	if tree.Root() != (MerkleHash{}) {
+		t.Errorf("expected zero root but got %v", tree.Root())
+	}
+}
+
+func TestMerkleLeafInvalid(t *testing.T) {
+	if _, err := MerkleLeaf("0x1234", big.NewInt(1)); err == nil {
+		t.Errorf("expected error for short address")
+	}
+
+	if _, err := MerkleLeaf("0x1111111111111111111111111111111111111111", big.NewInt(-1)); err == nil {
+		t.Errorf("expected error for negative amount")
+	}
+
+	if _, err := MerkleLeaf("0x1111111111111111111111111111111111111111", new(big.Int).Lsh(big.NewInt(1), 256)); err == nil {
+		t.Errorf("expected error for amount over uint256")
+	}
+}
+
+func TestParseMerkleHash(t *testing.T) {
+	value := "0xd4dee0beab2d53f2cc83e567171bd2820e49898130a22622b10ead383e90bd77"
+	hash, err := ParseMerkleHash(value)
+
+	if err != nil || hash.Hex() != value {
+		t.Errorf("expected %v but got %v %v", value, hash.Hex(), err)
+	}
+
+	if _, err := ParseMerkleHash("0x1234"); err == nil {
+		t.Errorf("expected error for short hash")
+	}
+}
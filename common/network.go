@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"time"
+)
+
+// carrier grade nat, not covered by net.IP.IsPrivate
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// False for loopback, private, link local (including cloud metadata services) and other non routable addresses
+// that a user supplied url must never be able to reach.
+func IsPublicIP(ip net.IP) bool {
+	return !(ip.IsUnspecified() ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() ||
+		sharedAddressSpace.Contains(ip))
+}
+
+// Requires an https url whose host only resolves to public addresses.
+// The check is repeated at dial time by NewPublicTransport since the host can resolve differently later.
+func ValidatePublicURL(ctx context.Context, value string) error {
+	u, err := url.Parse(value)
+
+	if err != nil || u.Scheme != "https" || u.Hostname() == "" {
+		return fmt.Errorf("url %v is not https %w", value, ErrValidation)
+	}
+
+	ips, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+
+	if err != nil || len(ips) == 0 {
+		return fmt.Errorf("url %v does not resolve %w", value, ErrValidation)
+	}
+
+	for _, ip := range ips {
+		if !IsPublicIP(ip.IP) {
+			return fmt.Errorf("url %v resolves to non public address %v %w", value, ip.IP, ErrValidation)
+		}
+	}
+
+	return nil
+}
+
+// A transport that refuses to connect to non public addresses.
+// The address is checked after resolution so dns rebinding can not get around it.
+// Proxies are not used since the proxy address is what would be checked.
+func NewPublicTransport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+
+			if err != nil {
+				return err
+			}
+
+			if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+				return fmt.Errorf("dial %v non public address", address)
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return transport
+}
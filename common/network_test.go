@@ -0,0 +1,67 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	for ip, expected := range map[string]bool{
+		"1.1.1.1":          true,
+		"2606:4700::1111":  true,
+		"0.0.0.0":          false,
+		"127.0.0.1":        false,
+		"10.0.0.1":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"::1":              false,
+		"fd00::1":          false,
+		"fe80::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		if IsPublicIP(net.ParseIP(ip)) != expected {
+			t.Errorf("expected public %v for %v", expected, ip)
+		}
+	}
+}
+
+func TestValidatePublicURL(t *testing.T) {
+	ctx := context.Background()
+
+	if err := ValidatePublicURL(ctx, "https://1.1.1.1/push"); err != nil {
+		t.Errorf("expected public https url to be valid but got %v", err)
+	}
+
+	for _, value := range []string{
+		"http://1.1.1.1/push",
+		"https://127.0.0.1/push",
+		"https://169.254.169.254/latest/meta-data",
+		"https://[::1]/push",
+		"https://10.0.0.1:8443/push",
+		"https:///push",
+	} {
+		if err := ValidatePublicURL(ctx, value); !errors.Is(err, ErrValidation) {
+			t.Errorf("expected validation error for %v but got %v", value, err)
+		}
+	}
+}
+
+func TestPublicTransportRefusesLoopback(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewPublicTransport()}
+
+	if resp, err := client.Get(server.URL); err == nil {
+		resp.Body.Close()
+		t.Errorf("expected dial to %v to be refused", server.URL)
+	}
+}
@@ -7,6 +7,13 @@ type Stream = string
 const (
 	SigninStream Stream = "signin"
 	VoteStream   Stream = "vote"
+	// new comments are consumed by the subscriber to notify the users involved
+	CommentCreatedStream Stream = "comment-created"
+	// new threads and reputation changes are only consumed by the webhook deliverer
+	ThreadCreatedStream Stream = "thread-created"
+	ReputationStream    Stream = "reputation"
+	// read by every api replica without a consumer group so each one receives all events
+	ThreadEventStream Stream = "thread-event"
 )
 
 type VoteMessage struct {
@@ -19,6 +26,31 @@ type VoteMessage struct {
 	UpdatedAt int64 `json:"updated_at"`
 }
 
+type CommentCreatedMessage struct {
+	Id       int64  `json:"id"`
+	ThreadId int64  `json:"thread_id"`
+	Address  string `json:"address"`
+}
+
+type ThreadCreatedMessage struct {
+	Id      int64  `json:"id"`
+	BoardId int64  `json:"board_id"`
+	Address string `json:"address"`
+}
+
+// published by the indexer for every address whose reputation was recalculated
+type ReputationMessage struct {
+	Address string `json:"address"`
+}
+
 type SigninMessage struct {
 	Address string `json:"address"`
 }
+
+type ThreadEventMessage struct {
+	Type      entities.ThreadEventType `json:"type"`
+	ThreadId  int64                    `json:"thread_id"`
+	CommentId *int64                   `json:"comment_id"`
+	Votes     *int64                   `json:"votes"`
+	Viewers   *int64                   `json:"viewers"`
+}
@@ -0,0 +1,72 @@
+package entities
+
+import "time"
+
+type ActivitySort string
+
+const (
+	NewActivitySort ActivitySort = "new"
+	TopActivitySort ActivitySort = "top"
+)
+
+// ActivityCursor holds every sort key of the last thread or comment in a page of user activity
+// so a cursor can be used to continue the activity regardless of its sort.
+type ActivityCursor struct {
+	id        int64
+	votes     int64
+	createdAt time.Time
+}
+
+type ActivityCursorParams struct {
+	Id        int64
+	Votes     int64
+	CreatedAt time.Time
+}
+
+func NewActivityCursor(params ActivityCursorParams) ActivityCursor {
+	return ActivityCursor{
+		id:        params.Id,
+		votes:     params.Votes,
+		createdAt: params.CreatedAt,
+	}
+}
+
+func (c ActivityCursor) Id() int64 {
+	return c.id
+}
+
+func (c ActivityCursor) Votes() int64 {
+	return c.votes
+}
+
+func (c ActivityCursor) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UserStats are the aggregate counters of the threads and comments of a user
+type UserStats struct {
+	threadCount  int64
+	commentCount int64
+	votes        int64
+}
+
+func NewUserStats(threadCount int64, commentCount int64, votes int64) UserStats {
+	return UserStats{
+		threadCount:  threadCount,
+		commentCount: commentCount,
+		votes:        votes,
+	}
+}
+
+func (s *UserStats) ThreadCount() int64 {
+	return s.threadCount
+}
+
+func (s *UserStats) CommentCount() int64 {
+	return s.commentCount
+}
+
+// the total votes received by the threads and comments of the user
+func (s *UserStats) Votes() int64 {
+	return s.votes
+}
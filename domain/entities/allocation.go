@@ -1 +1,111 @@
 package entities
+
+import (
+	"math/big"
+	"time"
+)
+
+// The share of a distribution earned by an author
+type Allocation struct {
+	distributionId int64
+	address        string
+	amount         *big.Int
+	votes          int64
+	round          time.Time
+}
+
+type AllocationParams struct {
+	DistributionId int64
+	Address        string
+	Amount         *big.Int
+	Votes          int64
+	Round          time.Time
+}
+
+func NewAllocation(params AllocationParams) Allocation {
+	return Allocation{
+		distributionId: params.DistributionId,
+		address:        params.Address,
+		amount:         params.Amount,
+		votes:          params.Votes,
+		round:          params.Round,
+	}
+}
+
+func (a *Allocation) DistributionId() int64 {
+	return a.distributionId
+}
+
+func (a *Allocation) Address() string {
+	return a.address
+}
+
+func (a *Allocation) Amount() *big.Int {
+	return a.amount
+}
+
+// the number of upvotes and downvotes on the content of the author that counted towards the allocation
+func (a *Allocation) Votes() int64 {
+	return a.votes
+}
+
+// the round of the distribution, zero until the allocation is recorded
+func (a *Allocation) Round() time.Time {
+	return a.round
+}
+
+// The lifetime earnings of an address across every distribution
+type Earnings struct {
+	address string
+	amount  *big.Int
+	rounds  int64
+}
+
+func NewEarnings(address string, amount *big.Int, rounds int64) Earnings {
+	return Earnings{
+		address: address,
+		amount:  amount,
+		rounds:  rounds,
+	}
+}
+
+func (e *Earnings) Address() string {
+	return e.address
+}
+
+func (e *Earnings) Amount() *big.Int {
+	return e.amount
+}
+
+// the number of distributions the address has an allocation in
+func (e *Earnings) Rounds() int64 {
+	return e.rounds
+}
+
+// What an author needs to claim their allocation from the distributor contract
+type Claim struct {
+	allocation Allocation
+	merkleRoot string
+	proof      []string
+}
+
+func NewClaim(allocation Allocation, merkleRoot string, proof []string) Claim {
+	return Claim{
+		allocation: allocation,
+		merkleRoot: merkleRoot,
+		proof:      proof,
+	}
+}
+
+func (c *Claim) Allocation() Allocation {
+	return c.allocation
+}
+
+func (c *Claim) MerkleRoot() string {
+	return c.merkleRoot
+}
+
+// the sibling hashes from the leaf of the allocation up to the root
+func (c *Claim) Proof() []string {
+	return c.proof
+}
@@ -0,0 +1,66 @@
+package entities
+
+import "time"
+
+type BanType string
+
+const (
+	// hard bans reject everything the address does
+	HardBan BanType = "hard"
+	// shadow bans let the address keep posting but hide its posts from everyone else
+	ShadowBan BanType = "shadow"
+)
+
+type Ban struct {
+	address   string
+	banType   BanType
+	reason    string
+	expiresAt *time.Time
+	issuedBy  string
+	createdAt time.Time
+}
+
+type BanParams struct {
+	Address   string
+	Type      BanType
+	Reason    string
+	ExpiresAt *time.Time
+	IssuedBy  string
+	CreatedAt time.Time
+}
+
+func NewBan(params BanParams) Ban {
+	return Ban{
+		address:   params.Address,
+		banType:   params.Type,
+		reason:    params.Reason,
+		expiresAt: params.ExpiresAt,
+		issuedBy:  params.IssuedBy,
+		createdAt: params.CreatedAt,
+	}
+}
+
+func (b *Ban) Address() string {
+	return b.address
+}
+
+func (b *Ban) Type() BanType {
+	return b.banType
+}
+
+func (b *Ban) Reason() string {
+	return b.reason
+}
+
+// nil if the ban never expires
+func (b *Ban) ExpiresAt() *time.Time {
+	return b.expiresAt
+}
+
+func (b *Ban) IssuedBy() string {
+	return b.issuedBy
+}
+
+func (b *Ban) CreatedAt() time.Time {
+	return b.createdAt
+}
@@ -0,0 +1,72 @@
+package entities
+
+import "time"
+
+type Board struct {
+	id          int64
+	slug        string
+	title       string
+	description string
+	rules       string
+	createdBy   string
+	image       *Image
+	createdAt   time.Time
+}
+
+type BoardParams struct {
+	Id          int64
+	Slug        string
+	Title       string
+	Description string
+	Rules       string
+	CreatedBy   string
+	Image       *Image
+	CreatedAt   time.Time
+}
+
+func NewBoard(params BoardParams) Board {
+	return Board{
+		id:          params.Id,
+		slug:        params.Slug,
+		title:       params.Title,
+		description: params.Description,
+		rules:       params.Rules,
+		createdBy:   params.CreatedBy,
+		image:       params.Image,
+		createdAt:   params.CreatedAt,
+	}
+}
+
+func (b *Board) Id() int64 {
+	return b.id
+}
+
+func (b *Board) Slug() string {
+	return b.slug
+}
+
+func (b *Board) Title() string {
+	return b.title
+}
+
+func (b *Board) Description() string {
+	return b.description
+}
+
+func (b *Board) Rules() string {
+	return b.rules
+}
+
+// the address of the moderator that created the board
+func (b *Board) CreatedBy() string {
+	return b.createdBy
+}
+
+// nil if the board has no image
+func (b *Board) Image() *Image {
+	return b.image
+}
+
+func (b *Board) CreatedAt() time.Time {
+	return b.createdAt
+}
@@ -12,9 +12,15 @@ type Comment struct {
 	image            Image
 	user             User
 	isDeleted        bool
+	isHidden         bool
 	createdAt        time.Time
 	deletedAt        *time.Time
+	deletedBy        *string
+	editedAt         *time.Time
 	votes            int64
+	replyCount       int64
+	replies          *[]Comment
+	myVote           *VoteValue
 }
 
 type CommentParams struct {
@@ -25,9 +31,14 @@ type CommentParams struct {
 	Image            Image
 	User             User
 	IsDeleted        bool
+	IsHidden         bool
 	CreatedAt        time.Time
 	DeletedAt        *time.Time
+	DeletedBy        *string
+	EditedAt         *time.Time
 	Votes            int64
+	ReplyCount       int64
+	Replies          *[]Comment
 }
 
 func NewComment(params CommentParams) Comment {
@@ -39,9 +50,14 @@ func NewComment(params CommentParams) Comment {
 		image:            params.Image,
 		user:             params.User,
 		isDeleted:        params.IsDeleted,
+		isHidden:         params.IsHidden,
 		createdAt:        params.CreatedAt,
 		deletedAt:        params.DeletedAt,
+		deletedBy:        params.DeletedBy,
+		editedAt:         params.EditedAt,
 		votes:            params.Votes,
+		replyCount:       params.ReplyCount,
+		replies:          params.Replies,
 	}
 }
 
@@ -85,6 +101,11 @@ func (c *Comment) IsDeleted() bool {
 	return c.isDeleted
 }
 
+// hidden comments are left out of pages until their reports are reviewed
+func (c *Comment) IsHidden() bool {
+	return c.isHidden
+}
+
 func (c *Comment) CreatedAt() time.Time {
 	return c.createdAt
 }
@@ -93,6 +114,96 @@ func (c *Comment) DeletedAt() *time.Time {
 	return c.deletedAt
 }
 
+// nil unless deleted
+func (c *Comment) DeletedBy() *string {
+	return c.deletedBy
+}
+
+// nil if never edited
+func (c *Comment) EditedAt() *time.Time {
+	return c.editedAt
+}
+
 func (c *Comment) Votes() int64 {
 	return c.votes
 }
+
+// the number of direct replies, including any not hydrated in replies
+func (c *Comment) ReplyCount() int64 {
+	return c.replyCount
+}
+
+func (c *Comment) SetReplies(replies *[]Comment) {
+	c.replies = replies
+}
+
+// returned replies can be nil if not hydrated
+func (c *Comment) Replies() *[]Comment {
+	return c.replies
+}
+
+func (c *Comment) SetMyVote(vote *VoteValue) {
+	c.myVote = vote
+}
+
+// the vote of the requesting user, nil if anonymous or never voted
+func (c *Comment) MyVote() *VoteValue {
+	return c.myVote
+}
+
+// the cursor to load the replies after the last hydrated reply.
+// nil when there are no more replies or no replies have been hydrated to continue from.
+func (c *Comment) RepliesCursor() *CommentCursor {
+	if c.replies == nil || len(*c.replies) == 0 || int64(len(*c.replies)) >= c.replyCount {
+		return nil
+	}
+
+	last := (*c.replies)[len(*c.replies)-1]
+	cursor := NewCommentCursor(CommentCursorParams{
+		Id:        last.id,
+		CreatedAt: last.createdAt,
+		Direction: NextCursorDirection,
+	})
+	return &cursor
+}
+
+type CursorDirection string
+
+const (
+	NextCursorDirection CursorDirection = "next"
+	PrevCursorDirection CursorDirection = "prev"
+)
+
+// CommentCursor is the (created_at, id) key of a comment at the edge of a page
+// and the direction to walk from it. Next walks to older comments and prev to newer ones.
+type CommentCursor struct {
+	id        int64
+	createdAt time.Time
+	direction CursorDirection
+}
+
+type CommentCursorParams struct {
+	Id        int64
+	CreatedAt time.Time
+	Direction CursorDirection
+}
+
+func NewCommentCursor(params CommentCursorParams) CommentCursor {
+	return CommentCursor{
+		id:        params.Id,
+		createdAt: params.CreatedAt,
+		direction: params.Direction,
+	}
+}
+
+func (c CommentCursor) Id() int64 {
+	return c.id
+}
+
+func (c CommentCursor) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c CommentCursor) Direction() CursorDirection {
+	return c.direction
+}
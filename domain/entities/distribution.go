@@ -1 +1,138 @@
 package entities
+
+import (
+	"math/big"
+	"time"
+)
+
+// A round of rewards split between the authors of the content voted on since the previous round
+type Distribution struct {
+	id           int64
+	round        time.Time
+	votes        int64
+	amount       *big.Int
+	merkleRoot   string
+	participants int64
+	createdAt    time.Time
+}
+
+type DistributionParams struct {
+	Id           int64
+	Round        time.Time
+	Votes        int64
+	Amount       *big.Int
+	MerkleRoot   string
+	Participants int64
+	CreatedAt    time.Time
+}
+
+func NewDistribution(params DistributionParams) Distribution {
+	return Distribution{
+		id:           params.Id,
+		round:        params.Round,
+		votes:        params.Votes,
+		amount:       params.Amount,
+		merkleRoot:   params.MerkleRoot,
+		participants: params.Participants,
+		createdAt:    params.CreatedAt,
+	}
+}
+
+func (d *Distribution) Id() int64 {
+	return d.id
+}
+
+// the start of the interval the round ran for, votes cast up to it are consumed by the round
+func (d *Distribution) Round() time.Time {
+	return d.round
+}
+
+// the number of votes that counted towards the round
+func (d *Distribution) Votes() int64 {
+	return d.votes
+}
+
+// the sum of the allocations of the round, rounding leaves it at most a few units under the pool
+func (d *Distribution) Amount() *big.Int {
+	return d.amount
+}
+
+// the root of the merkle tree of the allocations that claims are verified against on chain
+func (d *Distribution) MerkleRoot() string {
+	return d.merkleRoot
+}
+
+// the number of authors with an allocation in the round
+func (d *Distribution) Participants() int64 {
+	return d.participants
+}
+
+func (d *Distribution) CreatedAt() time.Time {
+	return d.createdAt
+}
+
+// How votes are turned into allocations.
+// Every vote adds value × (VoteWeight + voter reputation × ReputationWeight) to the score of the author voted on,
+// where value is 1 for an upvote, -1 for a downvote and 0 for an unvote.
+// The pool is then split between the authors with a positive score in proportion to it.
+type DistributionFormula struct {
+	Pool             *big.Int
+	VoteWeight       *big.Rat
+	ReputationWeight *big.Rat
+}
+
+// A vote decision consumed by a distribution along with what is needed to weigh it
+type DistributionVote struct {
+	decisionId    int64
+	decision      VoteDecision
+	latest        bool
+	countedBefore bool
+}
+
+type DistributionVoteParams struct {
+	DecisionId int64
+	Decision   VoteDecision
+	// false if the voter changed their vote after the decision
+	Latest bool
+	// true if the vote already counted towards a previous distribution
+	CountedBefore bool
+}
+
+func NewDistributionVote(params DistributionVoteParams) DistributionVote {
+	return DistributionVote{
+		decisionId:    params.DecisionId,
+		decision:      params.Decision,
+		latest:        params.Latest,
+		countedBefore: params.CountedBefore,
+	}
+}
+
+func (v *DistributionVote) DecisionId() int64 {
+	return v.decisionId
+}
+
+func (v *DistributionVote) Decision() VoteDecision {
+	return v.decision
+}
+
+// nil if the vote counts towards the distribution.
+// A vote only counts once, for the decision on the latest value the voter gave.
+func (v *DistributionVote) Reason() *VoteDecisionReason {
+	if reason := v.decision.Reason(); reason != nil {
+		return reason
+	}
+	if !v.latest {
+		reason := SupersededVote
+		return &reason
+	}
+	if v.countedBefore {
+		reason := CountedVote
+		return &reason
+	}
+	return nil
+}
+
+// Unvotes are accepted but do not count since they carry no weight
+func (v *DistributionVote) Counts() bool {
+	return v.Reason() == nil && v.decision.Value() != Unvote
+}
@@ -0,0 +1,89 @@
+package entities
+
+import "time"
+
+type ThreadSort string
+
+const (
+	HotThreadSort    ThreadSort = "hot"
+	NewThreadSort    ThreadSort = "new"
+	TopThreadSort    ThreadSort = "top"
+	ActiveThreadSort ThreadSort = "active"
+)
+
+type ThreadWindow string
+
+const (
+	DayThreadWindow  ThreadWindow = "day"
+	WeekThreadWindow ThreadWindow = "week"
+	AllThreadWindow  ThreadWindow = "all"
+)
+
+// the earliest creation time of threads included in the window
+func (w ThreadWindow) Since(now time.Time) time.Time {
+	switch w {
+	case DayThreadWindow:
+		return now.Add(-time.Hour * 24)
+	case WeekThreadWindow:
+		return now.Add(-time.Hour * 24 * 7)
+	default:
+		return time.Unix(0, 0)
+	}
+}
+
+// ThreadCursor holds every sort key of the last thread in a page
+// so a cursor can be used to continue a feed regardless of its sort.
+// It also holds the start of the window of the first page so later pages use the same window.
+type ThreadCursor struct {
+	id        int64
+	hotScore  float64
+	votes     int64
+	createdAt time.Time
+	activeAt  time.Time
+	since     time.Time
+}
+
+type ThreadCursorParams struct {
+	Id        int64
+	HotScore  float64
+	Votes     int64
+	CreatedAt time.Time
+	ActiveAt  time.Time
+	Since     time.Time
+}
+
+func NewThreadCursor(params ThreadCursorParams) ThreadCursor {
+	return ThreadCursor{
+		id:        params.Id,
+		hotScore:  params.HotScore,
+		votes:     params.Votes,
+		createdAt: params.CreatedAt,
+		activeAt:  params.ActiveAt,
+		since:     params.Since,
+	}
+}
+
+func (c ThreadCursor) Id() int64 {
+	return c.id
+}
+
+func (c ThreadCursor) HotScore() float64 {
+	return c.hotScore
+}
+
+func (c ThreadCursor) Votes() int64 {
+	return c.votes
+}
+
+func (c ThreadCursor) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+func (c ThreadCursor) ActiveAt() time.Time {
+	return c.activeAt
+}
+
+// zero for cursors issued before the window was kept
+func (c ThreadCursor) Since() time.Time {
+	return c.since
+}
@@ -0,0 +1,110 @@
+package entities
+
+import "time"
+
+type ModerationAction string
+
+const (
+	DeleteModerationAction         ModerationAction = "delete"
+	RestoreModerationAction        ModerationAction = "restore"
+	BanModerationAction            ModerationAction = "ban"
+	UnbanModerationAction          ModerationAction = "unban"
+	GrantRoleModerationAction      ModerationAction = "grant_role"
+	RevokeRoleModerationAction     ModerationAction = "revoke_role"
+	ResolveReportsModerationAction ModerationAction = "resolve_reports"
+	LockModerationAction           ModerationAction = "lock"
+	UnlockModerationAction         ModerationAction = "unlock"
+	PinModerationAction            ModerationAction = "pin"
+	UnpinModerationAction          ModerationAction = "unpin"
+	ArchiveModerationAction        ModerationAction = "archive"
+	UnarchiveModerationAction      ModerationAction = "unarchive"
+)
+
+type ModerationTargetType string
+
+const (
+	ThreadModerationTarget  ModerationTargetType = "thread"
+	CommentModerationTarget ModerationTargetType = "comment"
+	UserModerationTarget    ModerationTargetType = "user"
+)
+
+// Snapshot is the state of a moderation target as a plain json object
+type Snapshot map[string]any
+
+// ModerationLog records a single moderation action.
+// The target id is the id of the thread or comment, or the address of the user.
+type ModerationLog struct {
+	id         int64
+	action     ModerationAction
+	actor      string
+	targetType ModerationTargetType
+	targetId   string
+	reason     string
+	before     Snapshot
+	after      Snapshot
+	createdAt  time.Time
+}
+
+type ModerationLogParams struct {
+	Id         int64
+	Action     ModerationAction
+	Actor      string
+	TargetType ModerationTargetType
+	TargetId   string
+	Reason     string
+	Before     Snapshot
+	After      Snapshot
+	CreatedAt  time.Time
+}
+
+func NewModerationLog(params ModerationLogParams) ModerationLog {
+	return ModerationLog{
+		id:         params.Id,
+		action:     params.Action,
+		actor:      params.Actor,
+		targetType: params.TargetType,
+		targetId:   params.TargetId,
+		reason:     params.Reason,
+		before:     params.Before,
+		after:      params.After,
+		createdAt:  params.CreatedAt,
+	}
+}
+
+func (m *ModerationLog) Id() int64 {
+	return m.id
+}
+
+func (m *ModerationLog) Action() ModerationAction {
+	return m.action
+}
+
+func (m *ModerationLog) Actor() string {
+	return m.actor
+}
+
+func (m *ModerationLog) TargetType() ModerationTargetType {
+	return m.targetType
+}
+
+func (m *ModerationLog) TargetId() string {
+	return m.targetId
+}
+
+func (m *ModerationLog) Reason() string {
+	return m.reason
+}
+
+// nil if the target had no state before the action
+func (m *ModerationLog) Before() Snapshot {
+	return m.before
+}
+
+// nil if the target has no state after the action
+func (m *ModerationLog) After() Snapshot {
+	return m.after
+}
+
+func (m *ModerationLog) CreatedAt() time.Time {
+	return m.createdAt
+}
@@ -0,0 +1,89 @@
+package entities
+
+import "time"
+
+type NotificationType string
+
+const (
+	// someone replied to a comment of the recipient
+	ReplyNotification NotificationType = "reply"
+	// someone commented on a thread of the recipient
+	ThreadReplyNotification NotificationType = "thread_reply"
+	// someone mentioned the ens name of the recipient in a comment
+	MentionNotification NotificationType = "mention"
+	// someone commented on a thread the recipient follows
+	SubscriptionNotification NotificationType = "subscription"
+)
+
+type Notification struct {
+	id               int64
+	recipient        string
+	notificationType NotificationType
+	actor            string
+	threadId         int64
+	commentId        int64
+	readAt           *time.Time
+	createdAt        time.Time
+}
+
+type NotificationParams struct {
+	Id        int64
+	Recipient string
+	Type      NotificationType
+	Actor     string
+	ThreadId  int64
+	CommentId int64
+	ReadAt    *time.Time
+	CreatedAt time.Time
+}
+
+func NewNotification(params NotificationParams) Notification {
+	return Notification{
+		id:               params.Id,
+		recipient:        params.Recipient,
+		notificationType: params.Type,
+		actor:            params.Actor,
+		threadId:         params.ThreadId,
+		commentId:        params.CommentId,
+		readAt:           params.ReadAt,
+		createdAt:        params.CreatedAt,
+	}
+}
+
+func (n *Notification) Id() int64 {
+	return n.id
+}
+
+func (n *Notification) Recipient() string {
+	return n.recipient
+}
+
+func (n *Notification) Type() NotificationType {
+	return n.notificationType
+}
+
+// the address of the user whose comment caused the notification
+func (n *Notification) Actor() string {
+	return n.actor
+}
+
+func (n *Notification) ThreadId() int64 {
+	return n.threadId
+}
+
+func (n *Notification) CommentId() int64 {
+	return n.commentId
+}
+
+// nil while the notification is unread
+func (n *Notification) ReadAt() *time.Time {
+	return n.readAt
+}
+
+func (n *Notification) IsRead() bool {
+	return n.readAt != nil
+}
+
+func (n *Notification) CreatedAt() time.Time {
+	return n.createdAt
+}
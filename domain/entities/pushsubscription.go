@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+// The Web Push subscription of a browser, as returned by PushManager.subscribe
+type PushSubscription struct {
+	endpoint  string
+	address   string
+	p256dh    string
+	auth      string
+	createdAt time.Time
+}
+
+type PushSubscriptionParams struct {
+	Endpoint  string
+	Address   string
+	P256dh    string
+	Auth      string
+	CreatedAt time.Time
+}
+
+func NewPushSubscription(params PushSubscriptionParams) PushSubscription {
+	return PushSubscription{
+		endpoint:  params.Endpoint,
+		address:   params.Address,
+		p256dh:    params.P256dh,
+		auth:      params.Auth,
+		createdAt: params.CreatedAt,
+	}
+}
+
+// the url of the push service that delivers to the browser
+func (s *PushSubscription) Endpoint() string {
+	return s.endpoint
+}
+
+func (s *PushSubscription) Address() string {
+	return s.address
+}
+
+// the base64url encoded P-256 public key of the browser
+func (s *PushSubscription) P256dh() string {
+	return s.p256dh
+}
+
+// the base64url encoded authentication secret of the browser
+func (s *PushSubscription) Auth() string {
+	return s.auth
+}
+
+func (s *PushSubscription) CreatedAt() time.Time {
+	return s.createdAt
+}
@@ -0,0 +1,139 @@
+package entities
+
+import "time"
+
+type ReportTargetType string
+
+const (
+	ThreadReportTarget  ReportTargetType = "thread"
+	CommentReportTarget ReportTargetType = "comment"
+	UserReportTarget    ReportTargetType = "user"
+)
+
+type ReportReason string
+
+const (
+	SpamReportReason    ReportReason = "spam"
+	AbuseReportReason   ReportReason = "abuse"
+	IllegalReportReason ReportReason = "illegal"
+	OtherReportReason   ReportReason = "other"
+)
+
+type ReportResolution string
+
+const (
+	DismissReportResolution ReportResolution = "dismiss"
+	DeleteReportResolution  ReportResolution = "delete"
+	BanReportResolution     ReportResolution = "ban"
+)
+
+// Report flags a thread, comment or user for moderators.
+// The target id is the id of the thread or comment, or the address of the user.
+type Report struct {
+	reporter   string
+	targetType ReportTargetType
+	targetId   string
+	reason     ReportReason
+	details    string
+}
+
+type ReportParams struct {
+	Reporter   string
+	TargetType ReportTargetType
+	TargetId   string
+	Reason     ReportReason
+	Details    string
+}
+
+func NewReport(params ReportParams) Report {
+	return Report{
+		reporter:   params.Reporter,
+		targetType: params.TargetType,
+		targetId:   params.TargetId,
+		reason:     params.Reason,
+		details:    params.Details,
+	}
+}
+
+func (r *Report) Reporter() string {
+	return r.reporter
+}
+
+func (r *Report) TargetType() ReportTargetType {
+	return r.targetType
+}
+
+func (r *Report) TargetId() string {
+	return r.targetId
+}
+
+func (r *Report) Reason() ReportReason {
+	return r.reason
+}
+
+func (r *Report) Details() string {
+	return r.details
+}
+
+// ReportGroup is every open report of a single target in the moderation queue
+type ReportGroup struct {
+	targetType      ReportTargetType
+	targetId        string
+	count           int64
+	reasons         []ReportReason
+	details         []string
+	firstReportedAt time.Time
+	lastReportedAt  time.Time
+}
+
+type ReportGroupParams struct {
+	TargetType      ReportTargetType
+	TargetId        string
+	Count           int64
+	Reasons         []ReportReason
+	Details         []string
+	FirstReportedAt time.Time
+	LastReportedAt  time.Time
+}
+
+func NewReportGroup(params ReportGroupParams) ReportGroup {
+	return ReportGroup{
+		targetType:      params.TargetType,
+		targetId:        params.TargetId,
+		count:           params.Count,
+		reasons:         params.Reasons,
+		details:         params.Details,
+		firstReportedAt: params.FirstReportedAt,
+		lastReportedAt:  params.LastReportedAt,
+	}
+}
+
+func (g *ReportGroup) TargetType() ReportTargetType {
+	return g.targetType
+}
+
+func (g *ReportGroup) TargetId() string {
+	return g.targetId
+}
+
+func (g *ReportGroup) Count() int64 {
+	return g.count
+}
+
+// the distinct reasons of the open reports
+func (g *ReportGroup) Reasons() []ReportReason {
+	return g.reasons
+}
+
+// the non empty details of the open reports, oldest first
+func (g *ReportGroup) Details() []string {
+	return g.details
+}
+
+func (g *ReportGroup) FirstReportedAt() time.Time {
+	return g.firstReportedAt
+}
+
+func (g *ReportGroup) LastReportedAt() time.Time {
+	return g.lastReportedAt
+}
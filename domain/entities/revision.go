@@ -0,0 +1,67 @@
+package entities
+
+import "time"
+
+type RevisionType string
+
+const (
+	ThreadRevision  RevisionType = "thread"
+	CommentRevision RevisionType = "comment"
+)
+
+// Revision is a prior version of an edited thread or comment.
+// Title is empty for comment revisions.
+type Revision struct {
+	id        int64
+	title     string
+	content   string
+	image     Image
+	createdAt time.Time
+	revisedAt time.Time
+}
+
+type RevisionParams struct {
+	Id        int64
+	Title     string
+	Content   string
+	Image     Image
+	CreatedAt time.Time
+	RevisedAt time.Time
+}
+
+func NewRevision(params RevisionParams) Revision {
+	return Revision{
+		id:        params.Id,
+		title:     params.Title,
+		content:   params.Content,
+		image:     params.Image,
+		createdAt: params.CreatedAt,
+		revisedAt: params.RevisedAt,
+	}
+}
+
+func (r *Revision) Id() int64 {
+	return r.id
+}
+
+func (r *Revision) Title() string {
+	return r.title
+}
+
+func (r *Revision) Content() string {
+	return r.content
+}
+
+func (r *Revision) Image() *Image {
+	return &r.image
+}
+
+// the time the version was written
+func (r *Revision) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// the time the version was replaced by an edit
+func (r *Revision) RevisedAt() time.Time {
+	return r.revisedAt
+}
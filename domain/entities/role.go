@@ -0,0 +1,80 @@
+package entities
+
+import "time"
+
+type RoleType string
+
+const (
+	ModeratorRole RoleType = "moderator"
+	AdminRole     RoleType = "admin"
+)
+
+type RoleAction string
+
+const (
+	GrantRoleAction  RoleAction = "grant"
+	RevokeRoleAction RoleAction = "revoke"
+)
+
+// Role is a permission held by an address.
+// Roles without a board are global, roles with a board only apply to that board.
+type Role struct {
+	address   string
+	role      RoleType
+	boardId   *int64
+	createdBy string
+	createdAt time.Time
+}
+
+type RoleParams struct {
+	Address   string
+	Role      RoleType
+	BoardId   *int64
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+func NewRole(params RoleParams) Role {
+	return Role{
+		address:   params.Address,
+		role:      params.Role,
+		boardId:   params.BoardId,
+		createdBy: params.CreatedBy,
+		createdAt: params.CreatedAt,
+	}
+}
+
+func (r *Role) Address() string {
+	return r.address
+}
+
+func (r *Role) Role() RoleType {
+	return r.role
+}
+
+// nil if the role is global
+func (r *Role) BoardId() *int64 {
+	return r.boardId
+}
+
+func (r *Role) CreatedBy() string {
+	return r.createdBy
+}
+
+func (r *Role) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// Admins hold every permission of moderators and global roles apply to every board.
+// A nil board only matches global roles.
+func HasRole(roles []Role, role RoleType, boardId *int64) bool {
+	for _, r := range roles {
+		if r.role != role && r.role != AdminRole {
+			continue
+		}
+		if r.boardId == nil || (boardId != nil && *r.boardId == *boardId) {
+			return true
+		}
+	}
+	return false
+}
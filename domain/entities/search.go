@@ -0,0 +1,111 @@
+package entities
+
+import "time"
+
+type SearchResultKind string
+
+const (
+	ThreadSearchResult  SearchResultKind = "thread"
+	CommentSearchResult SearchResultKind = "comment"
+)
+
+// SearchResult is a thread or comment matching a search query.
+// Title is the title of the thread the result belongs to and
+// the snippet is the html escaped matched text with the matching terms highlighted.
+type SearchResult struct {
+	kind      SearchResultKind
+	id        int64
+	threadId  int64
+	title     string
+	snippet   string
+	user      User
+	createdAt time.Time
+	rank      float64
+}
+
+type SearchResultParams struct {
+	Kind      SearchResultKind
+	Id        int64
+	ThreadId  int64
+	Title     string
+	Snippet   string
+	User      User
+	CreatedAt time.Time
+	Rank      float64
+}
+
+func NewSearchResult(params SearchResultParams) SearchResult {
+	return SearchResult{
+		kind:      params.Kind,
+		id:        params.Id,
+		threadId:  params.ThreadId,
+		title:     params.Title,
+		snippet:   params.Snippet,
+		user:      params.User,
+		createdAt: params.CreatedAt,
+		rank:      params.Rank,
+	}
+}
+
+func (r *SearchResult) Kind() SearchResultKind {
+	return r.kind
+}
+
+func (r *SearchResult) Id() int64 {
+	return r.id
+}
+
+func (r *SearchResult) ThreadId() int64 {
+	return r.threadId
+}
+
+func (r *SearchResult) Title() string {
+	return r.title
+}
+
+func (r *SearchResult) Snippet() string {
+	return r.snippet
+}
+
+func (r *SearchResult) User() User {
+	return r.user
+}
+
+func (r *SearchResult) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+func (r *SearchResult) Rank() float64 {
+	return r.rank
+}
+
+func (r *SearchResult) Cursor() SearchCursor {
+	return NewSearchCursor(r.rank, r.kind, r.id)
+}
+
+// SearchCursor is the (rank, kind, id) key of the last result in a page
+type SearchCursor struct {
+	rank float64
+	kind SearchResultKind
+	id   int64
+}
+
+func NewSearchCursor(rank float64, kind SearchResultKind, id int64) SearchCursor {
+	return SearchCursor{
+		rank,
+		kind,
+		id,
+	}
+}
+
+func (c SearchCursor) Rank() float64 {
+	return c.rank
+}
+
+func (c SearchCursor) Kind() SearchResultKind {
+	return c.kind
+}
+
+func (c SearchCursor) Id() int64 {
+	return c.id
+}
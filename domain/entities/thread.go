@@ -5,43 +5,71 @@ import (
 )
 
 type Thread struct {
-	id        int64
-	title     string
-	content   string
-	image     Image
-	user      User
-	comments  *[]Comment
-	isDeleted bool
-	createdAt time.Time
-	deletedAt *time.Time
-	votes     int64
+	id          int64
+	boardId     int64
+	title       string
+	content     string
+	image       Image
+	user        User
+	comments    *[]Comment
+	isDeleted   bool
+	isHidden    bool
+	isLocked    bool
+	createdAt   time.Time
+	deletedAt   *time.Time
+	deletedBy   *string
+	editedAt    *time.Time
+	votes       int64
+	hotScore    float64
+	activeAt    time.Time
+	pinnedUntil *time.Time
+	archivedAt  *time.Time
+	myVote      *VoteValue
 }
 
 type ThreadParams struct {
-	Id        int64
-	Title     string
-	Content   string
-	Image     Image
-	User      User
-	Comments  *[]Comment
-	IsDeleted bool
-	CreatedAt time.Time
-	DeletedAt *time.Time
-	Votes     int64
+	Id          int64
+	BoardId     int64
+	Title       string
+	Content     string
+	Image       Image
+	User        User
+	Comments    *[]Comment
+	IsDeleted   bool
+	IsHidden    bool
+	IsLocked    bool
+	CreatedAt   time.Time
+	DeletedAt   *time.Time
+	DeletedBy   *string
+	EditedAt    *time.Time
+	Votes       int64
+	HotScore    float64
+	ActiveAt    time.Time
+	PinnedUntil *time.Time
+	ArchivedAt  *time.Time
 }
 
 func NewThread(params ThreadParams) Thread {
 	return Thread{
-		id:        params.Id,
-		title:     params.Title,
-		content:   params.Content,
-		image:     params.Image,
-		user:      params.User,
-		comments:  params.Comments,
-		isDeleted: params.IsDeleted,
-		createdAt: params.CreatedAt,
-		deletedAt: params.DeletedAt,
-		votes:     params.Votes,
+		id:          params.Id,
+		boardId:     params.BoardId,
+		title:       params.Title,
+		content:     params.Content,
+		image:       params.Image,
+		user:        params.User,
+		comments:    params.Comments,
+		isDeleted:   params.IsDeleted,
+		isHidden:    params.IsHidden,
+		isLocked:    params.IsLocked,
+		createdAt:   params.CreatedAt,
+		deletedAt:   params.DeletedAt,
+		deletedBy:   params.DeletedBy,
+		editedAt:    params.EditedAt,
+		votes:       params.Votes,
+		hotScore:    params.HotScore,
+		activeAt:    params.ActiveAt,
+		pinnedUntil: params.PinnedUntil,
+		archivedAt:  params.ArchivedAt,
 	}
 }
 
@@ -49,6 +77,10 @@ func (t *Thread) Id() int64 {
 	return t.id
 }
 
+func (t *Thread) BoardId() int64 {
+	return t.boardId
+}
+
 func (t *Thread) Title() string {
 	return t.title
 }
@@ -73,6 +105,34 @@ func (t *Thread) IsDeleted() bool {
 	return t.isDeleted
 }
 
+// hidden threads are left out of feeds until their reports are reviewed
+func (t *Thread) IsHidden() bool {
+	return t.isHidden
+}
+
+// locked threads no longer accept comments
+func (t *Thread) IsLocked() bool {
+	return t.isLocked
+}
+
+// nil if never pinned, the time may have passed
+func (t *Thread) PinnedUntil() *time.Time {
+	return t.pinnedUntil
+}
+
+func (t *Thread) IsPinned() bool {
+	return t.pinnedUntil != nil && t.pinnedUntil.After(time.Now())
+}
+
+// archived threads no longer accept comments, nil if not archived
+func (t *Thread) ArchivedAt() *time.Time {
+	return t.archivedAt
+}
+
+func (t *Thread) IsArchived() bool {
+	return t.archivedAt != nil
+}
+
 func (t *Thread) CreatedAt() time.Time {
 	return t.createdAt
 }
@@ -81,10 +141,43 @@ func (t *Thread) DeletedAt() *time.Time {
 	return t.deletedAt
 }
 
+// nil unless deleted
+func (t *Thread) DeletedBy() *string {
+	return t.deletedBy
+}
+
+// nil if never edited
+func (t *Thread) EditedAt() *time.Time {
+	return t.editedAt
+}
+
 func (t *Thread) Votes() int64 {
 	return t.votes
 }
 
+// votes decayed by the age of the thread
+func (t *Thread) HotScore() float64 {
+	return t.hotScore
+}
+
+// the time of the latest comment or the creation time if there are no comments
+func (t *Thread) ActiveAt() time.Time {
+	return t.activeAt
+}
+
+// the cursor to fetch the threads after this thread in a feed
+// since is the start of the window of the feed the thread is in
+func (t *Thread) Cursor(since time.Time) ThreadCursor {
+	return NewThreadCursor(ThreadCursorParams{
+		Id:        t.id,
+		HotScore:  t.hotScore,
+		Votes:     t.votes,
+		CreatedAt: t.createdAt,
+		ActiveAt:  t.activeAt,
+		Since:     since,
+	})
+}
+
 func (t *Thread) SetComments(comments *[]Comment) {
 	t.comments = comments
 }
@@ -93,3 +186,12 @@ func (t *Thread) SetComments(comments *[]Comment) {
 func (t *Thread) Comments() *[]Comment {
 	return t.comments
 }
+
+func (t *Thread) SetMyVote(vote *VoteValue) {
+	t.myVote = vote
+}
+
+// the vote of the requesting user, nil if anonymous or never voted
+func (t *Thread) MyVote() *VoteValue {
+	return t.myVote
+}
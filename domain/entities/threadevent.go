@@ -0,0 +1,73 @@
+package entities
+
+type ThreadEventType string
+
+const (
+	CommentCreatedEvent ThreadEventType = "comment.created"
+	CommentDeletedEvent ThreadEventType = "comment.deleted"
+	// enough reports hid the comment until a moderator reviews them, dismissing the reports unhides it
+	CommentHiddenEvent   ThreadEventType = "comment.hidden"
+	CommentUnhiddenEvent ThreadEventType = "comment.unhidden"
+	VotesUpdatedEvent    ThreadEventType = "votes.updated"
+	// the number of people viewing the thread changed
+	PresenceUpdatedEvent ThreadEventType = "presence.updated"
+)
+
+// Thread events are published to every api replica so they can be pushed to clients watching the thread.
+// Events only carry ids and counts, clients are expected to fetch the comments themselves.
+type ThreadEvent struct {
+	id        string
+	eventType ThreadEventType
+	threadId  int64
+	commentId *int64
+	votes     *int64
+	viewers   *int64
+}
+
+type ThreadEventParams struct {
+	Id        string
+	Type      ThreadEventType
+	ThreadId  int64
+	CommentId *int64
+	Votes     *int64
+	Viewers   *int64
+}
+
+func NewThreadEvent(params ThreadEventParams) ThreadEvent {
+	return ThreadEvent{
+		id:        params.Id,
+		eventType: params.Type,
+		threadId:  params.ThreadId,
+		commentId: params.CommentId,
+		votes:     params.Votes,
+		viewers:   params.Viewers,
+	}
+}
+
+// the id assigned by the stream, empty until the event is published
+func (e *ThreadEvent) Id() string {
+	return e.id
+}
+
+func (e *ThreadEvent) Type() ThreadEventType {
+	return e.eventType
+}
+
+func (e *ThreadEvent) ThreadId() int64 {
+	return e.threadId
+}
+
+// nil for votes on the thread itself
+func (e *ThreadEvent) CommentId() *int64 {
+	return e.commentId
+}
+
+// only set for votes.updated events
+func (e *ThreadEvent) Votes() *int64 {
+	return e.votes
+}
+
+// only set for presence.updated events
+func (e *ThreadEvent) Viewers() *int64 {
+	return e.viewers
+}
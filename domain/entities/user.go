@@ -12,6 +12,8 @@ type User struct {
 	reputation *big.Int
 	createdAt  time.Time
 	updatedAt  *time.Time
+	stats      *UserStats
+	roles      []Role
 }
 
 type UserParams struct {
@@ -57,3 +59,25 @@ func (u *User) CreatedAt() time.Time {
 func (u *User) UpdatedAt() *time.Time {
 	return u.updatedAt
 }
+
+func (u *User) SetStats(stats *UserStats) {
+	u.stats = stats
+}
+
+// returned stats can be nil if not hydrated
+func (u *User) Stats() *UserStats {
+	return u.stats
+}
+
+func (u *User) SetRoles(roles []Role) {
+	u.roles = roles
+}
+
+// returned roles are empty if not hydrated
+func (u *User) Roles() []Role {
+	return u.roles
+}
+
+func (u *User) HasRole(role RoleType, boardId *int64) bool {
+	return HasRole(u.roles, role, boardId)
+}
@@ -0,0 +1,101 @@
+package entities
+
+import (
+	"math/big"
+	"time"
+)
+
+type VoteDecisionReason string
+
+const (
+	// the voter has less reputation than required
+	InsufficientReputation VoteDecisionReason = "insufficient_reputation"
+	// the content was older than the cutoff when it was voted on
+	ContentTooOld VoteDecisionReason = "content_too_old"
+	// the voter is the author of the content
+	SelfVote VoteDecisionReason = "self_vote"
+	// the voter has an active ban
+	BannedVoter VoteDecisionReason = "banned_voter"
+	// the content was deleted or does not exist
+	DeletedContent VoteDecisionReason = "deleted_content"
+	// accepted votes are discarded by distributions if the voter changed their vote since
+	SupersededVote VoteDecisionReason = "superseded_vote"
+	// or if the vote already counted towards a previous distribution
+	CountedVote VoteDecisionReason = "counted_vote"
+)
+
+// Whether a vote is counted towards distributions, every vote processed gets one
+type VoteDecision struct {
+	voteType   VoteType
+	targetId   int64
+	voter      string
+	author     *string
+	value      VoteValue
+	reputation *big.Int
+	reason     *VoteDecisionReason
+	votedAt    time.Time
+}
+
+type VoteDecisionParams struct {
+	Type       VoteType
+	TargetId   int64
+	Voter      string
+	Author     *string
+	Value      VoteValue
+	Reputation *big.Int
+	Reason     *VoteDecisionReason
+	VotedAt    time.Time
+}
+
+func NewVoteDecision(params VoteDecisionParams) VoteDecision {
+	return VoteDecision{
+		voteType:   params.Type,
+		targetId:   params.TargetId,
+		voter:      params.Voter,
+		author:     params.Author,
+		value:      params.Value,
+		reputation: params.Reputation,
+		reason:     params.Reason,
+		votedAt:    params.VotedAt,
+	}
+}
+
+func (d *VoteDecision) Type() VoteType {
+	return d.voteType
+}
+
+// the id of the thread or comment voted on
+func (d *VoteDecision) TargetId() int64 {
+	return d.targetId
+}
+
+func (d *VoteDecision) Voter() string {
+	return d.voter
+}
+
+// nil if the content voted on does not exist
+func (d *VoteDecision) Author() *string {
+	return d.author
+}
+
+func (d *VoteDecision) Value() VoteValue {
+	return d.value
+}
+
+// the reputation of the voter when the decision was made
+func (d *VoteDecision) Reputation() *big.Int {
+	return d.reputation
+}
+
+// nil if the vote was accepted
+func (d *VoteDecision) Reason() *VoteDecisionReason {
+	return d.reason
+}
+
+func (d *VoteDecision) IsAccepted() bool {
+	return d.reason == nil
+}
+
+func (d *VoteDecision) VotedAt() time.Time {
+	return d.votedAt
+}
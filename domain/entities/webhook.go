@@ -0,0 +1,202 @@
+package entities
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type WebhookEventType string
+
+const (
+	ThreadCreatedWebhookEvent     WebhookEventType = "thread.created"
+	CommentCreatedWebhookEvent    WebhookEventType = "comment.created"
+	VoteCreatedWebhookEvent       WebhookEventType = "vote.created"
+	ReputationUpdatedWebhookEvent WebhookEventType = "reputation.updated"
+)
+
+type Webhook struct {
+	id                  int64
+	url                 string
+	events              []WebhookEventType
+	secret              string
+	createdBy           string
+	consecutiveFailures int64
+	disabledAt          *time.Time
+	createdAt           time.Time
+}
+
+type WebhookParams struct {
+	Id                  int64
+	Url                 string
+	Events              []WebhookEventType
+	Secret              string
+	CreatedBy           string
+	ConsecutiveFailures int64
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+}
+
+func NewWebhook(params WebhookParams) Webhook {
+	return Webhook{
+		id:                  params.Id,
+		url:                 params.Url,
+		events:              params.Events,
+		secret:              params.Secret,
+		createdBy:           params.CreatedBy,
+		consecutiveFailures: params.ConsecutiveFailures,
+		disabledAt:          params.DisabledAt,
+		createdAt:           params.CreatedAt,
+	}
+}
+
+func (w *Webhook) Id() int64 {
+	return w.id
+}
+
+func (w *Webhook) Url() string {
+	return w.url
+}
+
+// the event types the webhook is subscribed to
+func (w *Webhook) Events() []WebhookEventType {
+	return w.events
+}
+
+// the key payloads are signed with, never presented back to clients
+func (w *Webhook) Secret() string {
+	return w.secret
+}
+
+func (w *Webhook) CreatedBy() string {
+	return w.createdBy
+}
+
+// reset by every successful delivery
+func (w *Webhook) ConsecutiveFailures() int64 {
+	return w.consecutiveFailures
+}
+
+// nil while the webhook is enabled
+func (w *Webhook) DisabledAt() *time.Time {
+	return w.disabledAt
+}
+
+func (w *Webhook) IsDisabled() bool {
+	return w.disabledAt != nil
+}
+
+func (w *Webhook) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// An event read from the streams that is delivered to the webhooks subscribed to its type.
+// The id is the id of the stream message so receivers can de-duplicate redeliveries.
+type WebhookEvent struct {
+	id        string
+	eventType WebhookEventType
+	data      json.RawMessage
+	createdAt time.Time
+}
+
+func NewWebhookEvent(id string, eventType WebhookEventType, data json.RawMessage, createdAt time.Time) WebhookEvent {
+	return WebhookEvent{
+		id:        id,
+		eventType: eventType,
+		data:      data,
+		createdAt: createdAt,
+	}
+}
+
+func (e *WebhookEvent) Id() string {
+	return e.id
+}
+
+func (e *WebhookEvent) Type() WebhookEventType {
+	return e.eventType
+}
+
+// the body of the stream message
+func (e *WebhookEvent) Data() json.RawMessage {
+	return e.data
+}
+
+func (e *WebhookEvent) CreatedAt() time.Time {
+	return e.createdAt
+}
+
+// The outcome of delivering an event to a webhook once all retries are exhausted
+type WebhookDelivery struct {
+	id         int64
+	webhookId  int64
+	eventType  WebhookEventType
+	eventId    string
+	statusCode *int32
+	err        string
+	attempts   int32
+	succeeded  bool
+	createdAt  time.Time
+}
+
+type WebhookDeliveryParams struct {
+	Id         int64
+	WebhookId  int64
+	EventType  WebhookEventType
+	EventId    string
+	StatusCode *int32
+	Error      string
+	Attempts   int32
+	Succeeded  bool
+	CreatedAt  time.Time
+}
+
+func NewWebhookDelivery(params WebhookDeliveryParams) WebhookDelivery {
+	return WebhookDelivery{
+		id:         params.Id,
+		webhookId:  params.WebhookId,
+		eventType:  params.EventType,
+		eventId:    params.EventId,
+		statusCode: params.StatusCode,
+		err:        params.Error,
+		attempts:   params.Attempts,
+		succeeded:  params.Succeeded,
+		createdAt:  params.CreatedAt,
+	}
+}
+
+func (d *WebhookDelivery) Id() int64 {
+	return d.id
+}
+
+func (d *WebhookDelivery) WebhookId() int64 {
+	return d.webhookId
+}
+
+func (d *WebhookDelivery) EventType() WebhookEventType {
+	return d.eventType
+}
+
+func (d *WebhookDelivery) EventId() string {
+	return d.eventId
+}
+
+// the status code of the last attempt, nil when no response was received
+func (d *WebhookDelivery) StatusCode() *int32 {
+	return d.statusCode
+}
+
+// the error of the last attempt, empty if the delivery succeeded
+func (d *WebhookDelivery) Error() string {
+	return d.err
+}
+
+func (d *WebhookDelivery) Attempts() int32 {
+	return d.attempts
+}
+
+func (d *WebhookDelivery) Succeeded() bool {
+	return d.succeeded
+}
+
+func (d *WebhookDelivery) CreatedAt() time.Time {
+	return d.createdAt
+}
@@ -18,4 +18,10 @@ type Cache interface {
 	Start(ctx context.Context, config CacheConfig)
 	Shutdown(ctx context.Context)
 	VerifyRateLimit(ctx context.Context, key string, rate int, period time.Duration) error
+	// true if the address was not already present through another connection
+	SetPresence(ctx context.Context, key string, address string, connection string, ttl time.Duration) (bool, error)
+	// true if the connection was present and was the last one of the address
+	RemovePresence(ctx context.Context, key string, address string, connection string) (bool, error)
+	// the number of addresses with a connection whose presence has not expired
+	CountPresence(ctx context.Context, key string) (int64, error)
 }
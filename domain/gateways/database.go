@@ -3,6 +3,7 @@ package gateways
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/daochanio/backend/domain/entities"
 )
@@ -13,6 +14,92 @@ type DatabaseConfig struct {
 	MaxConnections   int32
 }
 
+// ThreadsSpec describes a page of a thread feed
+type ThreadsSpec struct {
+	// nil for the feed of every board
+	BoardId *int64
+	Sort    entities.ThreadSort
+	// only threads created after since are included in the top sort
+	Since time.Time
+	// nil when fetching the first page
+	Cursor *entities.ThreadCursor
+	Limit  int64
+	// threads of shadow banned authors are only included for the author, nil if anonymous
+	Viewer *string
+}
+
+// CommentsSpec describes a keyset page of comments on a thread
+type CommentsSpec struct {
+	ThreadId int64
+	// nil when fetching the first (newest) page
+	Cursor *entities.CommentCursor
+	Limit  int64
+	// comments of shadow banned authors are only included for the author, nil if anonymous
+	Viewer *string
+}
+
+// CommentTreeSpec describes a page of replies to a parent comment and their nested replies
+type CommentTreeSpec struct {
+	ThreadId int64
+	// nil for the top level comments of the thread
+	ParentId *int64
+	// nil when fetching the first page of replies
+	Cursor *entities.CommentCursor
+	Limit  int64
+	// the depth of the page of replies is 1
+	MaxDepth int32
+	// the max number of nested replies to hydrate per comment
+	ReplyLimit int64
+	// comments of shadow banned authors are only included for the author, nil if anonymous
+	Viewer *string
+}
+
+// SearchSpec describes a page of search results. Nil filters are not applied.
+type SearchSpec struct {
+	Query         string
+	Author        *string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// nil when fetching the first page
+	Cursor *entities.SearchCursor
+	Limit  int64
+	// results of shadow banned authors are only included for the author, nil if anonymous
+	Viewer *string
+}
+
+// ActivitySpec describes a page of the threads or comments of a user
+type ActivitySpec struct {
+	Address string
+	Sort    entities.ActivitySort
+	// nil when fetching the first page
+	Cursor *entities.ActivityCursor
+	Limit  int64
+	// the activity of a shadow banned user is only included for the user, nil if anonymous
+	Viewer *string
+}
+
+// VoteDecisionsSpec describes a range of vote decisions. Nil bounds are left open.
+type VoteDecisionsSpec struct {
+	// the time the votes were cast at, inclusive
+	VotedAfter  *time.Time
+	VotedBefore *time.Time
+	// the ids of the decisions, inclusive
+	FromId *int64
+	ToId   *int64
+}
+
+// ResolveReportsSpec describes the resolution of every open report of a target
+type ResolveReportsSpec struct {
+	TargetType entities.ReportTargetType
+	// the id of the thread or comment, or the address of the user
+	TargetId   string
+	Resolution entities.ReportResolution
+	ResolvedBy string
+	// nil unless the author of the target is banned
+	Ban *entities.Ban
+	Log entities.ModerationLog
+}
+
 type Database interface {
 	Start(ctx context.Context, config DatabaseConfig)
 	Shutdown(ctx context.Context)
@@ -23,19 +110,88 @@ type Database interface {
 	SaveChallenge(ctx context.Context, challenge entities.Challenge) error
 
 	GetUserByAddress(ctx context.Context, address string) (entities.User, error)
-	GetThreads(ctx context.Context, limit int64) ([]entities.Thread, error)
+	GetUserStats(ctx context.Context, address string) (entities.UserStats, error)
+	GetThreadsByAddress(ctx context.Context, spec ActivitySpec) ([]entities.Thread, error)
+	GetCommentsByAddress(ctx context.Context, spec ActivitySpec) ([]entities.Comment, error)
+	GetBoards(ctx context.Context) ([]entities.Board, error)
+	GetBoardBySlug(ctx context.Context, slug string) (entities.Board, error)
+	GetThreads(ctx context.Context, spec ThreadsSpec) ([]entities.Thread, error)
 	GetThreadById(ctx context.Context, threadId int64) (entities.Thread, error)
-	GetComments(ctx context.Context, threadId int64, offset int64, limit int64) ([]entities.Comment, int64, error)
+	GetComments(ctx context.Context, threadId int64, offset int64, limit int64, viewer *string) ([]entities.Comment, int64, error)
+	GetCommentsByCursor(ctx context.Context, spec CommentsSpec) ([]entities.Comment, error)
+	GetCommentTree(ctx context.Context, spec CommentTreeSpec) ([]entities.Comment, error)
 	GetCommentById(ctx context.Context, commentId int64) (entities.Comment, error)
+	Search(ctx context.Context, spec SearchSpec) ([]entities.SearchResult, error)
+	GetThreadRevisions(ctx context.Context, threadId int64) ([]entities.Revision, error)
+	GetCommentRevisions(ctx context.Context, commentId int64) ([]entities.Revision, error)
+	GetRolesByAddress(ctx context.Context, address string) ([]entities.Role, error)
+	CountOpenReports(ctx context.Context, targetType entities.ReportTargetType, targetId string) (int64, error)
+	GetOpenReports(ctx context.Context, offset int64, limit int64) ([]entities.ReportGroup, int64, error)
+	GetVotesByAddress(ctx context.Context, address string, ids []int64, voteType entities.VoteType) (map[int64]entities.VoteValue, error)
+	GetActiveBan(ctx context.Context, address string) (*entities.Ban, error)
+	GetDeletedThreadById(ctx context.Context, threadId int64) (entities.Thread, error)
+	GetPinnedThreads(ctx context.Context, boardId *int64, viewer *string) ([]entities.Thread, error)
+	GetModerationLog(ctx context.Context, offset int64, limit int64) ([]entities.ModerationLog, int64, error)
+	GetNotifications(ctx context.Context, recipient string, unreadOnly bool, offset int64, limit int64) ([]entities.Notification, int64, error)
+	CountUnreadNotifications(ctx context.Context, recipient string) (int64, error)
+	GetAddressesByEnsNames(ctx context.Context, ensNames []string) ([]string, error)
+	GetWebhooks(ctx context.Context) ([]entities.Webhook, error)
+	GetActiveWebhooks(ctx context.Context, eventType entities.WebhookEventType) ([]entities.Webhook, error)
+	GetWebhookDeliveries(ctx context.Context, webhookId int64, offset int64, limit int64) ([]entities.WebhookDelivery, int64, error)
+	GetPushSubscriptionsByAddresses(ctx context.Context, addresses []string) ([]entities.PushSubscription, error)
+	GetThreadSubscribers(ctx context.Context, threadId int64) ([]string, error)
+	GetLastDistribution(ctx context.Context) (*entities.Distribution, error)
+	GetUndistributedVotes(ctx context.Context, cutoff time.Time) ([]entities.DistributionVote, error)
+	GetVoteDecisions(ctx context.Context, spec VoteDecisionsSpec) ([]entities.DistributionVote, error)
+	GetDistributionById(ctx context.Context, id int64) (entities.Distribution, error)
+	GetAllocation(ctx context.Context, distributionId int64, address string) (entities.Allocation, error)
+	GetMerkleTree(ctx context.Context, distributionId int64) ([]string, error)
+	GetDistributions(ctx context.Context, offset int64, limit int64) ([]entities.Distribution, int64, error)
+	GetAllocationsByDistribution(ctx context.Context, distributionId int64, offset int64, limit int64) ([]entities.Allocation, int64, error)
+	GetAllocationsByAddress(ctx context.Context, address string, offset int64, limit int64) ([]entities.Allocation, int64, error)
+	GetEarnings(ctx context.Context, address string) (entities.Earnings, error)
 
 	UpsertUser(ctx context.Context, address string) error
 	UpdateUser(ctx context.Context, address string, name *string, avatar *entities.Image) error
 	CreateComment(ctx context.Context, threadId int64, address string, repliedToCommentId *int64, content string, image *entities.Image) (entities.Comment, error)
-	CreateThread(ctx context.Context, address string, title string, content string, image *entities.Image) (entities.Thread, error)
+	CreateBoard(ctx context.Context, slug string, title string, description string, rules string, createdBy string, image *entities.Image) (entities.Board, error)
+	CreateThread(ctx context.Context, address string, boardId int64, title string, content string, image *entities.Image) (entities.Thread, error)
 	CreateVote(ctx context.Context, vote entities.Vote) error
-	DeleteThread(ctx context.Context, threadId int64) error
-	DeleteComment(ctx context.Context, commentId int64) error
+	UpdateThread(ctx context.Context, threadId int64, title string, content string, image *entities.Image) (entities.Thread, error)
+	UpdateComment(ctx context.Context, commentId int64, content string, image *entities.Image) (entities.Comment, error)
+	// moderation actions write their log in the same transaction, a nil log is not logged
+	DeleteThread(ctx context.Context, threadId int64, deletedBy string, log *entities.ModerationLog) error
+	DeleteComment(ctx context.Context, commentId int64, deletedBy string, log *entities.ModerationLog) error
+	RestoreThread(ctx context.Context, threadId int64, restoredBy string, reason string, log *entities.ModerationLog) (entities.Thread, error)
+	RestoreComment(ctx context.Context, commentId int64, restoredBy string, reason string, log *entities.ModerationLog) (entities.Comment, error)
 	AggregateVotes(ctx context.Context, id int64, voteType entities.VoteType) error
+	CreateReport(ctx context.Context, report entities.Report) (int64, error)
+	ResolveReports(ctx context.Context, spec ResolveReportsSpec) error
+	SetThreadHidden(ctx context.Context, threadId int64, hidden bool) error
+	SetCommentHidden(ctx context.Context, commentId int64, hidden bool) error
+	SetThreadLocked(ctx context.Context, threadId int64, locked bool, log entities.ModerationLog) error
+	SetThreadPinned(ctx context.Context, threadId int64, until *time.Time, log entities.ModerationLog) error
+	SetThreadArchived(ctx context.Context, threadId int64, archived bool, log entities.ModerationLog) error
+	ArchiveInactiveThreads(ctx context.Context, inactiveSince time.Time) (int64, error)
+	CreateBan(ctx context.Context, ban entities.Ban, log entities.ModerationLog) error
+	RevokeBans(ctx context.Context, address string, revokedBy string, log entities.ModerationLog) error
+	CreateNotifications(ctx context.Context, notifications []entities.Notification) error
+	ReadNotification(ctx context.Context, id int64, recipient string) error
+	ReadAllNotifications(ctx context.Context, recipient string) (int64, error)
+	SubscribeToThread(ctx context.Context, address string, threadId int64) error
+	UnsubscribeFromThread(ctx context.Context, address string, threadId int64) error
+	CreateWebhook(ctx context.Context, url string, events []entities.WebhookEventType, secret string, createdBy string) (entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	EnableWebhook(ctx context.Context, id int64) (entities.Webhook, error)
+	// returns true if the delivery failure disabled the webhook
+	CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery, maxFailures int64) (bool, error)
+	CreatePushSubscription(ctx context.Context, subscription entities.PushSubscription) error
+	DeletePushSubscription(ctx context.Context, endpoint string, address string) error
+	DeleteExpiredPushSubscription(ctx context.Context, endpoint string) error
+	CreateVoteDecision(ctx context.Context, decision entities.VoteDecision) error
+	CreateDistribution(ctx context.Context, round time.Time, votes []entities.DistributionVote, allocations []entities.Allocation, merkleRoot string, merkleTree []string) (entities.Distribution, error)
+	GrantRole(ctx context.Context, address string, role entities.RoleType, boardId *int64, grantedBy string, log *entities.ModerationLog) error
+	RevokeRole(ctx context.Context, address string, role entities.RoleType, boardId *int64, revokedBy string, log entities.ModerationLog) error
 
 	GetLastIndexedBlock(ctx context.Context) (*big.Int, error)
 	UpdateLastIndexedBlock(ctx context.Context, block *big.Int) error
@@ -21,4 +21,12 @@ type Stream interface {
 	Shutdown(ctx context.Context)
 	PublishSignin(ctx context.Context, address string) error
 	PublishVote(ctx context.Context, vote entities.Vote) error
+	PublishCommentCreated(ctx context.Context, comment entities.Comment) error
+	PublishThreadCreated(ctx context.Context, thread entities.Thread) error
+	PublishReputationUpdated(ctx context.Context, addresses []string) error
+	PublishThreadEvent(ctx context.Context, event entities.ThreadEvent) error
+	// blocks until events newer than the given stream id arrive or the block duration elapses
+	ReadThreadEvents(ctx context.Context, after string, block time.Duration) ([]entities.ThreadEvent, error)
+	// the events after the given stream id that are still retained by the stream, oldest first
+	GetThreadEvents(ctx context.Context, after string, limit int64) ([]entities.ThreadEvent, error)
 }
@@ -0,0 +1,20 @@
+package gateways
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+type WebhooksConfig struct {
+	Timeout time.Duration
+}
+
+type Webhooks interface {
+	Start(ctx context.Context, config WebhooksConfig)
+	Shutdown(ctx context.Context)
+	// Sends a single signed delivery attempt and returns the response status code, 0 if no response was received.
+	// Errors worth retrying are wrapped in common.ErrRetryable.
+	SendWebhook(ctx context.Context, webhook entities.Webhook, event entities.WebhookEvent) (int, error)
+}
@@ -0,0 +1,28 @@
+package gateways
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+type WebPushConfig struct {
+	// base64url encoded uncompressed P-256 public key, browsers subscribe with it as the applicationServerKey
+	VAPIDPublicKey string
+	// base64url encoded P-256 private key, web push is disabled without it
+	VAPIDPrivateKey string
+	// a mailto: or https: contact push services can reach us at
+	VAPIDSubject string
+	// how long push services hold messages for browsers that are offline
+	TTL     time.Duration
+	Timeout time.Duration
+}
+
+type WebPush interface {
+	Start(ctx context.Context, config WebPushConfig)
+	Shutdown(ctx context.Context)
+	// Encrypts the notification for the subscription and sends it to its push service.
+	// Returns common.ErrGone if the subscription expired or was revoked, errors worth retrying are wrapped in common.ErrRetryable.
+	SendPush(ctx context.Context, subscription entities.PushSubscription, notification entities.Notification) error
+}
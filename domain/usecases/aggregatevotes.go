@@ -14,12 +14,14 @@ type Buffer map[string]AggregateVotesInput
 type AggregateVotes struct {
 	logger   common.Logger
 	database gateways.Database
+	stream   gateways.Stream
 }
 
-func NewAggregateVotesUseCase(logger common.Logger, database gateways.Database) *AggregateVotes {
+func NewAggregateVotesUseCase(logger common.Logger, database gateways.Database, stream gateways.Stream) *AggregateVotes {
 	return &AggregateVotes{
 		logger,
 		database,
+		stream,
 	}
 }
 
@@ -67,7 +69,9 @@ func (u *AggregateVotes) Execute(ctx context.Context, input AggregateVotesInput)
 	for id := range dirtyThreads {
 		if err := u.database.AggregateVotes(ctx, id, entities.ThreadVote); err != nil {
 			u.logger.Error(ctx).Err(err).Msgf("error aggregating thread votes for %v", id)
+			continue
 		}
+		u.publishThreadVotes(ctx, id)
 	}
 
 	if len(dirtyComments) > 0 {
@@ -76,6 +80,42 @@ func (u *AggregateVotes) Execute(ctx context.Context, input AggregateVotesInput)
 	for id := range dirtyComments {
 		if err := u.database.AggregateVotes(ctx, id, entities.CommentVote); err != nil {
 			u.logger.Error(ctx).Err(err).Msgf("error aggregating comment votes for %v", id)
+			continue
 		}
+		u.publishCommentVotes(ctx, id)
 	}
 }
+
+// the aggregated counts are read back so the published events carry the persisted totals
+func (u *AggregateVotes) publishThreadVotes(ctx context.Context, id int64) {
+	thread, err := u.database.GetThreadById(ctx, id)
+
+	if err != nil {
+		u.logger.Warn(ctx).Err(err).Msgf("skipping votes event for thread %v", id)
+		return
+	}
+
+	votes := thread.Votes()
+	publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+		Type:     entities.VotesUpdatedEvent,
+		ThreadId: id,
+		Votes:    &votes,
+	})
+}
+
+func (u *AggregateVotes) publishCommentVotes(ctx context.Context, id int64) {
+	comment, err := u.database.GetCommentById(ctx, id)
+
+	if err != nil {
+		u.logger.Warn(ctx).Err(err).Msgf("skipping votes event for comment %v", id)
+		return
+	}
+
+	votes := comment.Votes()
+	publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+		Type:      entities.VotesUpdatedEvent,
+		ThreadId:  comment.ThreadId(),
+		CommentId: &id,
+		Votes:     &votes,
+	})
+}
@@ -0,0 +1,62 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ArchiveThread struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewArchiveThreadUseCase(validator common.Validator, database gateways.Database) *ArchiveThread {
+	return &ArchiveThread{
+		validator,
+		database,
+	}
+}
+
+type ArchiveThreadInput struct {
+	ThreadId         int64  `validate:"gt=0"`
+	ModeratorAddress string `validate:"eth_addr"`
+	ModeratorRoles   []entities.Role
+	// false unarchives the thread
+	Archived bool
+	Reason   string `validate:"max=1000"`
+}
+
+func (u *ArchiveThread) Execute(ctx context.Context, input ArchiveThreadInput) (entities.Thread, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Thread{}, err
+	}
+
+	thread, err := getModeratedThread(ctx, u.database, input.ThreadId, input.ModeratorRoles)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	// archiving an archived thread keeps its original archive time
+	action := entities.ArchiveModerationAction
+	archivedAt := thread.ArchivedAt()
+	if !input.Archived {
+		action = entities.UnarchiveModerationAction
+		archivedAt = nil
+	} else if archivedAt == nil {
+		now := time.Now().UTC()
+		archivedAt = &now
+	}
+
+	log := threadStateLog(thread, action, input.ModeratorAddress, input.Reason, entities.Snapshot{"archivedAt": archivedAt})
+
+	if err := u.database.SetThreadArchived(ctx, input.ThreadId, input.Archived, log); err != nil {
+		return entities.Thread{}, err
+	}
+
+	return u.database.GetThreadById(ctx, input.ThreadId)
+}
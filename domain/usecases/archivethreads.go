@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ArchiveThreads struct {
+	logger    common.Logger
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewArchiveThreadsUseCase(logger common.Logger, validator common.Validator, database gateways.Database) *ArchiveThreads {
+	return &ArchiveThreads{
+		logger,
+		validator,
+		database,
+	}
+}
+
+type ArchiveThreadsInput struct {
+	// threads without activity for this long are archived
+	Inactivity time.Duration `validate:"gt=0"`
+}
+
+// Archives every thread that has been inactive for longer than the inactivity period.
+// Pinned threads are kept open until they are unpinned.
+func (u *ArchiveThreads) Execute(ctx context.Context, input ArchiveThreadsInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	count, err := u.database.ArchiveInactiveThreads(ctx, time.Now().Add(-input.Inactivity))
+
+	if err != nil {
+		return err
+	}
+
+	u.logger.Info(ctx).Msgf("archived %v inactive threads", count)
+
+	return nil
+}
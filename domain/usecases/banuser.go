@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type BanUser struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewBanUserUseCase(validator common.Validator, database gateways.Database) *BanUser {
+	return &BanUser{
+		validator,
+		database,
+	}
+}
+
+type BanUserInput struct {
+	// addresses are checksummed so they match the addresses users sign in with
+	Address string           `validate:"eth_addr_checksum"`
+	Type    entities.BanType `validate:"oneof=hard shadow"`
+	Reason  string           `validate:"max=1000"`
+	// nil for a permanent ban
+	ExpiresAt     *time.Time
+	IssuerAddress string `validate:"eth_addr_checksum"`
+}
+
+func (u *BanUser) Execute(ctx context.Context, input BanUserInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return fmt.Errorf("ban expiry must be in the future: %w", common.ErrValidation)
+	}
+
+	if input.Address == input.IssuerAddress {
+		return fmt.Errorf("cannot ban yourself: %w", common.ErrValidation)
+	}
+
+	before, err := u.database.GetActiveBan(ctx, input.Address)
+
+	if err != nil {
+		return err
+	}
+
+	ban := entities.NewBan(entities.BanParams{
+		Address:   input.Address,
+		Type:      input.Type,
+		Reason:    input.Reason,
+		ExpiresAt: input.ExpiresAt,
+		IssuedBy:  input.IssuerAddress,
+	})
+
+	log := entities.NewModerationLog(entities.ModerationLogParams{
+		Action:     entities.BanModerationAction,
+		Actor:      input.IssuerAddress,
+		TargetType: entities.UserModerationTarget,
+		TargetId:   input.Address,
+		Reason:     input.Reason,
+		Before:     banSnapshot(before),
+		After:      banSnapshot(&ban),
+	})
+
+	return u.database.CreateBan(ctx, ban, log)
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type CountUnreadNotifications struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewCountUnreadNotificationsUseCase(validator common.Validator, database gateways.Database) *CountUnreadNotifications {
+	return &CountUnreadNotifications{
+		validator,
+		database,
+	}
+}
+
+type CountUnreadNotificationsInput struct {
+	Recipient string `validate:"eth_addr"`
+}
+
+func (u *CountUnreadNotifications) Execute(ctx context.Context, input CountUnreadNotificationsInput) (int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return -1, err
+	}
+
+	return u.database.CountUnreadNotifications(ctx, input.Recipient)
+}
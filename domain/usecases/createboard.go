@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type CreateBoard struct {
+	validator common.Validator
+	images    gateways.Images
+	database  gateways.Database
+}
+
+func NewCreateBoardUseCase(validator common.Validator, images gateways.Images, database gateways.Database) *CreateBoard {
+	return &CreateBoard{
+		validator,
+		images,
+		database,
+	}
+}
+
+type CreateBoardInput struct {
+	Address     string `validate:"eth_addr"`
+	Slug        string `validate:"min=2,max=32,lowercase,alphanum"`
+	Title       string `validate:"min=1,max=100"`
+	Description string `validate:"max=1000"`
+	Rules       string `validate:"max=5000"`
+	// boards are created without an image when empty
+	ImageFileName string `validate:"max=100"`
+}
+
+func (u *CreateBoard) Execute(ctx context.Context, input CreateBoardInput) (entities.Board, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Board{}, err
+	}
+
+	_, err := u.database.GetBoardBySlug(ctx, input.Slug)
+
+	if err == nil {
+		return entities.Board{}, fmt.Errorf("board %v already exists", input.Slug)
+	}
+
+	if !errors.Is(err, common.ErrNotFound) {
+		return entities.Board{}, err
+	}
+
+	var image *entities.Image
+	if input.ImageFileName != "" {
+		image, err = u.images.GetImageByFileName(ctx, input.ImageFileName)
+
+		if err != nil {
+			return entities.Board{}, err
+		}
+
+		if image == nil {
+			return entities.Board{}, fmt.Errorf("image not found %w", common.ErrNotFound)
+		}
+	}
+
+	return u.database.CreateBoard(ctx, input.Slug, input.Title, input.Description, input.Rules, input.Address, image)
+}
@@ -10,15 +10,19 @@ import (
 )
 
 type CreateComment struct {
+	logger    common.Logger
 	database  gateways.Database
 	images    gateways.Images
+	stream    gateways.Stream
 	validator common.Validator
 }
 
-func NewCreateCommentUseCase(database gateways.Database, images gateways.Images, validator common.Validator) *CreateComment {
+func NewCreateCommentUseCase(logger common.Logger, database gateways.Database, images gateways.Images, stream gateways.Stream, validator common.Validator) *CreateComment {
 	return &CreateComment{
+		logger,
 		database,
 		images,
+		stream,
 		validator,
 	}
 }
@@ -36,6 +40,26 @@ func (u *CreateComment) Execute(ctx context.Context, input CreateCommentInput) (
 		return entities.Comment{}, err
 	}
 
+	ban, err := rejectHardBan(ctx, u.database, input.Address)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	thread, err := u.database.GetThreadById(ctx, input.ThreadId)
+
+	if err != nil {
+		return entities.Comment{}, fmt.Errorf("thread %v: %w", input.ThreadId, err)
+	}
+
+	if thread.IsLocked() {
+		return entities.Comment{}, common.ErrThreadLocked
+	}
+
+	if thread.IsArchived() {
+		return entities.Comment{}, common.ErrThreadArchived
+	}
+
 	image, err := u.images.GetImageByFileName(ctx, input.ImageFileName)
 
 	if err != nil {
@@ -46,5 +70,26 @@ func (u *CreateComment) Execute(ctx context.Context, input CreateCommentInput) (
 		return entities.Comment{}, fmt.Errorf("image not found %w", common.ErrNotFound)
 	}
 
-	return u.database.CreateComment(ctx, input.ThreadId, input.Address, input.RepliedToCommentId, input.Content, image)
+	comment, err := u.database.CreateComment(ctx, input.ThreadId, input.Address, input.RepliedToCommentId, input.Content, image)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	// comments of shadow banned addresses are hidden from everyone else so we do not announce them
+	if ban == nil {
+		commentId := comment.Id()
+		publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+			Type:      entities.CommentCreatedEvent,
+			ThreadId:  comment.ThreadId(),
+			CommentId: &commentId,
+		})
+
+		// the comment is already saved so failing to notify others should not fail the request
+		if err := u.stream.PublishCommentCreated(ctx, comment); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error publishing comment created %v", comment.Id())
+		}
+	}
+
+	return comment, nil
 }
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type CreateNotifications struct {
+	logger   common.Logger
+	database gateways.Database
+}
+
+func NewCreateNotificationsUseCase(logger common.Logger, database gateways.Database) *CreateNotifications {
+	return &CreateNotifications{
+		logger,
+		database,
+	}
+}
+
+type CreateNotificationsInput struct {
+	CommentIds []int64
+}
+
+// Notifies the recipients of each comment as resolved by getNotificationRecipients.
+// Each recipient is notified once per comment for the most relevant reason.
+// Commenters start following the thread they comment on.
+// Failures are logged per comment so one bad comment does not block the rest.
+func (u *CreateNotifications) Execute(ctx context.Context, input CreateNotificationsInput) {
+	if len(input.CommentIds) > 0 {
+		u.logger.Info(ctx).Msgf("creating notifications for %v comments", len(input.CommentIds))
+	}
+
+	for _, commentId := range input.CommentIds {
+		if err := u.notify(ctx, commentId); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error creating notifications for comment %v", commentId)
+		}
+	}
+}
+
+func (u *CreateNotifications) notify(ctx context.Context, commentId int64) error {
+	comment, err := u.database.GetCommentById(ctx, commentId)
+
+	if err != nil {
+		return err
+	}
+
+	recipients, err := getNotificationRecipients(ctx, u.database, comment)
+
+	if err != nil {
+		return err
+	}
+
+	author := comment.User()
+	notifications := []entities.Notification{}
+	for recipient, notificationType := range recipients {
+		notifications = append(notifications, entities.NewNotification(entities.NotificationParams{
+			Recipient: recipient,
+			Type:      notificationType,
+			Actor:     author.Address(),
+			ThreadId:  comment.ThreadId(),
+			CommentId: comment.Id(),
+		}))
+	}
+
+	if err := u.database.CreateNotifications(ctx, notifications); err != nil {
+		return err
+	}
+
+	return u.database.SubscribeToThread(ctx, author.Address(), comment.ThreadId())
+}
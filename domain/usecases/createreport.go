@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type CreateReport struct {
+	logger    common.Logger
+	validator common.Validator
+	database  gateways.Database
+	stream    gateways.Stream
+}
+
+func NewCreateReportUseCase(logger common.Logger, validator common.Validator, database gateways.Database, stream gateways.Stream) *CreateReport {
+	return &CreateReport{
+		logger,
+		validator,
+		database,
+		stream,
+	}
+}
+
+type CreateReportInput struct {
+	ReporterAddress string                    `validate:"eth_addr"`
+	TargetType      entities.ReportTargetType `validate:"oneof=thread comment user"`
+	// the id of the thread or comment, or the address of the user
+	TargetId string                `validate:"min=1,max=42"`
+	Reason   entities.ReportReason `validate:"oneof=spam abuse illegal other"`
+	Details  string                `validate:"max=1000"`
+	// threads and comments with this many open reports are hidden until reviewed, zero never hides
+	Threshold int64 `validate:"gte=0"`
+}
+
+// Reporting a target the reporter already has an open report for is a no-op
+func (u *CreateReport) Execute(ctx context.Context, input CreateReportInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	if _, err := getReportAuthor(ctx, u.database, input.TargetType, input.TargetId); err != nil {
+		return err
+	}
+
+	count, err := u.database.CreateReport(ctx, entities.NewReport(entities.ReportParams{
+		Reporter:   input.ReporterAddress,
+		TargetType: input.TargetType,
+		TargetId:   input.TargetId,
+		Reason:     input.Reason,
+		Details:    input.Details,
+	}))
+
+	if err != nil {
+		return err
+	}
+
+	if input.Threshold == 0 || count < input.Threshold {
+		return nil
+	}
+
+	if err := setReportTargetHidden(ctx, u.database, input.TargetType, input.TargetId, true); err != nil {
+		return fmt.Errorf("failed to hide reported %v: %w", input.TargetType, err)
+	}
+
+	u.logger.Info(ctx).Msgf("hid %v %v after %v reports", input.TargetType, input.TargetId, count)
+
+	publishReportTargetEvent(ctx, u.logger, u.database, u.stream, input.TargetType, input.TargetId, entities.CommentHiddenEvent)
+
+	return nil
+}
+
+// Returns the address of the author of a reported thread or comment, or the address of a reported user.
+// Reports can only target threads, comments and users that exist.
+func getReportAuthor(ctx context.Context, database gateways.Database, targetType entities.ReportTargetType, targetId string) (string, error) {
+	switch targetType {
+	case entities.ThreadReportTarget:
+		id, err := parseReportTargetId(targetId)
+		if err != nil {
+			return "", err
+		}
+		thread, err := database.GetThreadById(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		user := thread.User()
+		return user.Address(), nil
+	case entities.CommentReportTarget:
+		id, err := parseReportTargetId(targetId)
+		if err != nil {
+			return "", err
+		}
+		comment, err := database.GetCommentById(ctx, id)
+		if err != nil {
+			return "", err
+		}
+		user := comment.User()
+		return user.Address(), nil
+	case entities.UserReportTarget:
+		user, err := database.GetUserByAddress(ctx, targetId)
+		if err != nil {
+			return "", err
+		}
+		return user.Address(), nil
+	default:
+		return "", fmt.Errorf("invalid report target type: %v", targetType)
+	}
+}
+
+// users cannot be hidden so only threads and comments are updated
+func setReportTargetHidden(ctx context.Context, database gateways.Database, targetType entities.ReportTargetType, targetId string, hidden bool) error {
+	switch targetType {
+	case entities.ThreadReportTarget:
+		id, err := parseReportTargetId(targetId)
+		if err != nil {
+			return err
+		}
+		return database.SetThreadHidden(ctx, id, hidden)
+	case entities.CommentReportTarget:
+		id, err := parseReportTargetId(targetId)
+		if err != nil {
+			return err
+		}
+		return database.SetCommentHidden(ctx, id, hidden)
+	default:
+		return nil
+	}
+}
+
+// Clients watching the thread of a reported comment are told when moderation changes whether it is shown.
+// Like every thread event this is best effort, so failing to find the thread of the comment is only logged.
+func publishReportTargetEvent(ctx context.Context, logger common.Logger, database gateways.Database, stream gateways.Stream, targetType entities.ReportTargetType, targetId string, eventType entities.ThreadEventType) {
+	if targetType != entities.CommentReportTarget {
+		return
+	}
+
+	id, err := parseReportTargetId(targetId)
+
+	if err != nil {
+		return
+	}
+
+	comment, err := database.GetCommentById(ctx, id)
+
+	if err != nil {
+		logger.Error(ctx).Err(err).Msgf("error getting comment %v to publish %v event", id, eventType)
+		return
+	}
+
+	publishThreadEvent(ctx, logger, stream, entities.ThreadEventParams{
+		Type:      eventType,
+		ThreadId:  comment.ThreadId(),
+		CommentId: &id,
+	})
+}
+
+func parseReportTargetId(targetId string) (int64, error) {
+	id, err := strconv.ParseInt(targetId, 10, 64)
+
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid report target id %v: %w", targetId, common.ErrValidation)
+	}
+
+	return id, nil
+}
@@ -14,19 +14,22 @@ type CreateThread struct {
 	validator common.Validator
 	images    gateways.Images
 	database  gateways.Database
+	stream    gateways.Stream
 }
 
-func NewCreateThreadUseCase(logger common.Logger, validator common.Validator, images gateways.Images, database gateways.Database) *CreateThread {
+func NewCreateThreadUseCase(logger common.Logger, validator common.Validator, images gateways.Images, database gateways.Database, stream gateways.Stream) *CreateThread {
 	return &CreateThread{
 		logger,
 		validator,
 		images,
 		database,
+		stream,
 	}
 }
 
 type CreateThreadInput struct {
 	Address       string `validate:"eth_addr"`
+	BoardSlug     string `validate:"min=1,max=32"`
 	Title         string `validate:"max=100"`
 	Content       string `validate:"max=1000"`
 	ImageFileName string `validate:"max=100"`
@@ -37,6 +40,18 @@ func (u *CreateThread) Execute(ctx context.Context, input CreateThreadInput) (en
 		return entities.Thread{}, err
 	}
 
+	ban, err := rejectHardBan(ctx, u.database, input.Address)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	board, err := u.database.GetBoardBySlug(ctx, input.BoardSlug)
+
+	if err != nil {
+		return entities.Thread{}, fmt.Errorf("board %v: %w", input.BoardSlug, err)
+	}
+
 	image, err := u.images.GetImageByFileName(ctx, input.ImageFileName)
 
 	if err != nil {
@@ -47,5 +62,18 @@ func (u *CreateThread) Execute(ctx context.Context, input CreateThreadInput) (en
 		return entities.Thread{}, fmt.Errorf("image not found %w", common.ErrNotFound)
 	}
 
-	return u.database.CreateThread(ctx, input.Address, input.Title, input.Content, image)
+	thread, err := u.database.CreateThread(ctx, input.Address, board.Id(), input.Title, input.Content, image)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	// threads of shadow banned addresses are hidden from everyone else so we do not announce them
+	if ban == nil {
+		if err := u.stream.PublishThreadCreated(ctx, thread); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error publishing thread created %v", thread.Id())
+		}
+	}
+
+	return thread, nil
 }
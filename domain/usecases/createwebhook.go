@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type CreateWebhook struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewCreateWebhookUseCase(validator common.Validator, database gateways.Database) *CreateWebhook {
+	return &CreateWebhook{
+		validator,
+		database,
+	}
+}
+
+type CreateWebhookInput struct {
+	Url    string                      `validate:"url,startswith=https://,max=2048"`
+	Events []entities.WebhookEventType `validate:"min=1,max=4,dive,oneof=thread.created comment.created vote.created reputation.updated"`
+	// shared with the receiver to verify the signature of each payload
+	Secret    string `validate:"min=16,max=256"`
+	CreatedBy string `validate:"eth_addr"`
+}
+
+// The url is requested by the server with signed payloads so it must be https and resolve to a public address.
+func (u *CreateWebhook) Execute(ctx context.Context, input CreateWebhookInput) (entities.Webhook, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	if err := common.ValidatePublicURL(ctx, input.Url); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	seen := map[entities.WebhookEventType]bool{}
+	events := []entities.WebhookEventType{}
+	for _, event := range input.Events {
+		if !seen[event] {
+			seen[event] = true
+			events = append(events, event)
+		}
+	}
+
+	return u.database.CreateWebhook(ctx, input.Url, events, input.Secret, input.CreatedBy)
+}
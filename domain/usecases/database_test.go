@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+// An in memory database for use case tests.
+// Only the reads and writes the tested use cases make are implemented, any other call panics.
+type testDatabase struct {
+	gateways.Database
+	users     map[string]entities.User
+	threads   map[int64]entities.Thread
+	comments  map[int64]entities.Comment
+	bans      map[string]entities.Ban
+	followers map[int64][]string
+	ensNames  map[string]string
+	decisions []entities.VoteDecision
+}
+
+func (d *testDatabase) GetUserByAddress(ctx context.Context, address string) (entities.User, error) {
+	if user, ok := d.users[address]; ok {
+		return user, nil
+	}
+	return entities.User{}, common.ErrNotFound
+}
+
+func (d *testDatabase) GetThreadById(ctx context.Context, id int64) (entities.Thread, error) {
+	if thread, ok := d.threads[id]; ok && !thread.IsDeleted() {
+		return thread, nil
+	}
+	return entities.Thread{}, common.ErrNotFound
+}
+
+func (d *testDatabase) GetDeletedThreadById(ctx context.Context, id int64) (entities.Thread, error) {
+	if thread, ok := d.threads[id]; ok && thread.IsDeleted() {
+		return thread, nil
+	}
+	return entities.Thread{}, common.ErrNotFound
+}
+
+func (d *testDatabase) GetCommentById(ctx context.Context, id int64) (entities.Comment, error) {
+	if comment, ok := d.comments[id]; ok {
+		return comment, nil
+	}
+	return entities.Comment{}, common.ErrNotFound
+}
+
+func (d *testDatabase) GetActiveBan(ctx context.Context, address string) (*entities.Ban, error) {
+	if ban, ok := d.bans[address]; ok {
+		return &ban, nil
+	}
+	return nil, nil
+}
+
+func (d *testDatabase) GetThreadSubscribers(ctx context.Context, threadId int64) ([]string, error) {
+	return d.followers[threadId], nil
+}
+
+func (d *testDatabase) GetAddressesByEnsNames(ctx context.Context, ensNames []string) ([]string, error) {
+	addresses := []string{}
+	for _, name := range ensNames {
+		if address, ok := d.ensNames[name]; ok {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses, nil
+}
+
+func (d *testDatabase) CreateVoteDecision(ctx context.Context, decision entities.VoteDecision) error {
+	d.decisions = append(d.decisions, decision)
+	return nil
+}
@@ -2,27 +2,35 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/gateways"
 )
 
 type DeleteComment struct {
+	logger    common.Logger
 	validator common.Validator
 	database  gateways.Database
+	stream    gateways.Stream
 }
 
-func NewDeleteCommentUseCase(validator common.Validator, database gateways.Database) *DeleteComment {
+func NewDeleteCommentUseCase(logger common.Logger, validator common.Validator, database gateways.Database, stream gateways.Stream) *DeleteComment {
 	return &DeleteComment{
+		logger,
 		validator,
 		database,
+		stream,
 	}
 }
 
 type DeleteCommentInput struct {
 	Id             int64  `validate:"gt=0"`
 	DeleterAddress string `validate:"eth_addr"`
+	DeleterRoles   []entities.Role
+	// only logged when a moderator deletes the comment of someone else
+	Reason string `validate:"max=1000"`
 }
 
 func (u *DeleteComment) Execute(ctx context.Context, input DeleteCommentInput) error {
@@ -36,10 +44,47 @@ func (u *DeleteComment) Execute(ctx context.Context, input DeleteCommentInput) e
 		return err
 	}
 
+	// authors can delete their own comments and moderators of the board any comment
 	user := comment.User()
-	if user.Address() != input.DeleterAddress {
-		return errors.New("comment does not belong to the user")
+	isAuthor := user.Address() == input.DeleterAddress
+	if !isAuthor {
+		thread, err := u.database.GetThreadById(ctx, comment.ThreadId())
+
+		if err != nil {
+			return err
+		}
+
+		boardId := thread.BoardId()
+		if !entities.HasRole(input.DeleterRoles, entities.ModeratorRole, &boardId) {
+			return fmt.Errorf("comment does not belong to the user: %w", common.ErrForbidden)
+		}
+	}
+
+	var log *entities.ModerationLog
+	if !isAuthor {
+		before := commentSnapshot(comment)
+		moderation := entities.NewModerationLog(entities.ModerationLogParams{
+			Action:     entities.DeleteModerationAction,
+			Actor:      input.DeleterAddress,
+			TargetType: entities.CommentModerationTarget,
+			TargetId:   fmt.Sprint(input.Id),
+			Reason:     input.Reason,
+			Before:     before,
+			After:      withChanges(before, entities.Snapshot{"isDeleted": true}),
+		})
+		log = &moderation
 	}
 
-	return u.database.DeleteComment(ctx, input.Id)
+	if err := u.database.DeleteComment(ctx, input.Id, input.DeleterAddress, log); err != nil {
+		return err
+	}
+
+	commentId := comment.Id()
+	publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+		Type:      entities.CommentDeletedEvent,
+		ThreadId:  comment.ThreadId(),
+		CommentId: &commentId,
+	})
+
+	return nil
 }
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type DeletePushSubscription struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewDeletePushSubscriptionUseCase(validator common.Validator, database gateways.Database) *DeletePushSubscription {
+	return &DeletePushSubscription{
+		validator,
+		database,
+	}
+}
+
+type DeletePushSubscriptionInput struct {
+	Address  string `validate:"eth_addr"`
+	Endpoint string `validate:"url,max=2048"`
+}
+
+func (u *DeletePushSubscription) Execute(ctx context.Context, input DeletePushSubscriptionInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	return u.database.DeletePushSubscription(ctx, input.Endpoint, input.Address)
+}
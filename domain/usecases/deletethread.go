@@ -2,9 +2,10 @@ package usecases
 
 import (
 	"context"
-	"errors"
+	"fmt"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/domain/gateways"
 )
 
@@ -23,6 +24,9 @@ func NewDeleteThreadUseCase(validator common.Validator, database gateways.Databa
 type DeleteThreadInput struct {
 	ThreadId       int64  `validate:"gt=0"`
 	DeleterAddress string `validate:"eth_addr"`
+	DeleterRoles   []entities.Role
+	// only logged when a moderator deletes the thread of someone else
+	Reason string `validate:"max=1000"`
 }
 
 func (u *DeleteThread) Execute(ctx context.Context, input DeleteThreadInput) error {
@@ -36,10 +40,28 @@ func (u *DeleteThread) Execute(ctx context.Context, input DeleteThreadInput) err
 		return err
 	}
 
+	// authors can delete their own threads and moderators of the board any thread
 	user := thread.User()
-	if user.Address() != input.DeleterAddress {
-		return errors.New("thread does not belong to the user")
+	boardId := thread.BoardId()
+	isAuthor := user.Address() == input.DeleterAddress
+	if !isAuthor && !entities.HasRole(input.DeleterRoles, entities.ModeratorRole, &boardId) {
+		return fmt.Errorf("thread does not belong to the user: %w", common.ErrForbidden)
 	}
 
-	return u.database.DeleteThread(ctx, input.ThreadId)
+	var log *entities.ModerationLog
+	if !isAuthor {
+		before := threadSnapshot(thread)
+		moderation := entities.NewModerationLog(entities.ModerationLogParams{
+			Action:     entities.DeleteModerationAction,
+			Actor:      input.DeleterAddress,
+			TargetType: entities.ThreadModerationTarget,
+			TargetId:   fmt.Sprint(input.ThreadId),
+			Reason:     input.Reason,
+			Before:     before,
+			After:      withChanges(before, entities.Snapshot{"isDeleted": true}),
+		})
+		log = &moderation
+	}
+
+	return u.database.DeleteThread(ctx, input.ThreadId, input.DeleterAddress, log)
 }
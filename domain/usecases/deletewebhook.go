@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type DeleteWebhook struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewDeleteWebhookUseCase(validator common.Validator, database gateways.Database) *DeleteWebhook {
+	return &DeleteWebhook{
+		validator,
+		database,
+	}
+}
+
+type DeleteWebhookInput struct {
+	Id int64 `validate:"gt=0"`
+}
+
+func (u *DeleteWebhook) Execute(ctx context.Context, input DeleteWebhookInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	return u.database.DeleteWebhook(ctx, input.Id)
+}
@@ -0,0 +1,160 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type DeliverWebhooks struct {
+	logger   common.Logger
+	database gateways.Database
+	webhooks gateways.Webhooks
+}
+
+func NewDeliverWebhooksUseCase(logger common.Logger, database gateways.Database, webhooks gateways.Webhooks) *DeliverWebhooks {
+	return &DeliverWebhooks{
+		logger,
+		database,
+		webhooks,
+	}
+}
+
+type DeliverWebhooksInput struct {
+	Events []entities.WebhookEvent
+	// webhooks are disabled once this many deliveries in a row have failed
+	MaxFailures int64
+	// deliveries still pending after the timeout are logged as failed without being sent
+	Timeout time.Duration
+	// called once every delivery of the event has been logged, possibly concurrently
+	OnDelivered func(event entities.WebhookEvent)
+}
+
+// Delivers each event to the enabled webhooks subscribed to its type.
+// Webhooks are delivered to concurrently but each webhook receives its events one at a time and in order.
+// Every delivery is retried with exponential backoff and logged once its retries are exhausted or the timeout is reached,
+// so one slow webhook can not hold up the events of the others past the timeout.
+// Returns an error only if the context is done before every delivery was logged, so the undelivered events can be redelivered.
+func (u *DeliverWebhooks) Execute(ctx context.Context, input DeliverWebhooksInput) error {
+	subscribers := map[entities.WebhookEventType][]entities.Webhook{}
+	webhooks := map[int64]entities.Webhook{}
+	// indexes into the events
+	pending := map[int64][]int{}
+	remaining := make([]int, len(input.Events))
+
+	for i, event := range input.Events {
+		active, ok := subscribers[event.Type()]
+
+		if !ok {
+			var err error
+			active, err = u.database.GetActiveWebhooks(ctx, event.Type())
+
+			if err != nil {
+				u.logger.Error(ctx).Err(err).Msgf("error getting webhooks for event %v", event.Type())
+				continue
+			}
+
+			subscribers[event.Type()] = active
+		}
+
+		for _, webhook := range active {
+			webhooks[webhook.Id()] = webhook
+			pending[webhook.Id()] = append(pending[webhook.Id()], i)
+			remaining[i]++
+		}
+	}
+
+	// events without any deliveries are done right away
+	for i, event := range input.Events {
+		if remaining[i] == 0 {
+			input.OnDelivered(event)
+		}
+	}
+
+	var mu sync.Mutex
+	done := func(i int) {
+		mu.Lock()
+		remaining[i]--
+		finished := remaining[i] == 0
+		mu.Unlock()
+
+		if finished {
+			input.OnDelivered(input.Events[i])
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, input.Timeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for id, indexes := range pending {
+		wg.Add(1)
+		go u.deliver(ctx, sendCtx, &wg, webhooks[id], input.Events, indexes, input.MaxFailures, done)
+	}
+	wg.Wait()
+
+	return ctx.Err()
+}
+
+// Stops delivering to the webhook once it is disabled, the rest of its events are done without being logged.
+// Deliveries are sent with the send context and logged with the parent context so they are still logged after the timeout.
+func (u *DeliverWebhooks) deliver(ctx context.Context, sendCtx context.Context, wg *sync.WaitGroup, webhook entities.Webhook, events []entities.WebhookEvent, indexes []int, maxFailures int64, done func(int)) {
+	defer wg.Done()
+
+	for n, i := range indexes {
+		event := events[i]
+		attempts := int32(0)
+		statusCode, err := common.FunctionRetrier(sendCtx, func() (int, error) {
+			attempts++
+			return u.webhooks.SendWebhook(sendCtx, webhook, event)
+		})
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		params := entities.WebhookDeliveryParams{
+			WebhookId: webhook.Id(),
+			EventType: event.Type(),
+			EventId:   event.Id(),
+			Attempts:  attempts,
+			Succeeded: err == nil,
+		}
+
+		if statusCode > 0 {
+			code := int32(statusCode)
+			params.StatusCode = &code
+		}
+
+		if err != nil && sendCtx.Err() != nil {
+			err = fmt.Errorf("timed out after %v attempts", attempts)
+		}
+
+		if err != nil {
+			params.Error = err.Error()
+		}
+
+		disabled, err := u.database.CreateWebhookDelivery(ctx, entities.NewWebhookDelivery(params), maxFailures)
+
+		if err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error logging delivery of event %v to webhook %v", event.Id(), webhook.Id())
+			done(i)
+			continue
+		}
+
+		if disabled {
+			u.logger.Warn(ctx).Msgf("disabled webhook %v after %v consecutive failures", webhook.Id(), maxFailures)
+			for _, j := range indexes[n:] {
+				done(j)
+			}
+			return
+		}
+
+		done(i)
+	}
+}
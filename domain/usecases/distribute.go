@@ -2,42 +2,183 @@ package usecases
 
 import (
 	"context"
+	"fmt"
+	"math/big"
+	"sort"
 	"time"
 
 	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
 )
 
 type Distribute struct {
-	logger common.Logger
+	logger   common.Logger
+	database gateways.Database
 }
 
 func NewDistribute(
 	logger common.Logger,
+	database gateways.Database,
 ) *Distribute {
 	return &Distribute{
 		logger,
+		database,
 	}
 }
 
 type DistributeInput struct {
 	Interval time.Duration
+	Formula  entities.DistributionFormula
 }
 
-// Read the last distribution record from the database or create one if it doesn't exist.
-// Calculate the next earliest time to run a new distribution based on the interval.
-// If the last run time is within the next run interval, we do not run a new distribution.
-// If the alst run time is not within the next run interval, we do run a new distribution.
-// E.g Suppose, the run interval is 5 minutes.
-// If the last run distribution time was recorded at 12:03:23 then the next time to run is any time greater than 12:05:00.
-// If the current time is 12:03:45, then we should not run.
-// But if the current time is 12:05:07, then we should run.
+// Runs the distribution round of the current interval, rounds start at multiples of the interval.
+// E.g with an interval of 5 minutes, a run at 12:07:23 is for the round of 12:05:00
+// and is skipped if the last distribution already ran for that round or a later one.
 //
-// When the next distribution round runs, the votes that are accepted and not associated
-// with a distribution are processed and then tied to a distribution through FK.
+// The vote decisions made before the start of the round that are not part of a distribution yet are split into allocations,
+// only the latest accepted decision of each vote counts and only once across rounds.
+// The allocations are the leaves of a merkle tree whose root and nodes are stored with the round,
+// so authors can later fetch a proof and claim their amount on chain.
+// The round, its allocations and tree, and the consumed decisions are written in a single transaction keyed by the round,
+// so a round that failed part way is simply run again and a round another replica already ran is skipped.
 func (u *Distribute) Execute(ctx context.Context, input DistributeInput) error {
-	next := time.Now().Truncate(input.Interval).Add(input.Interval)
+	round := distributionRound(time.Now(), input.Interval)
 
-	u.logger.Info(ctx).Msgf("next distribution will run in %s", time.Until(next))
+	last, err := u.database.GetLastDistribution(ctx)
 
-	return common.ErrNotDistributionTime
+	if err != nil {
+		return fmt.Errorf("failed to get last distribution: %w", err)
+	}
+
+	if last != nil && !last.Round().Before(round) {
+		next := round.Add(input.Interval)
+
+		u.logger.Info(ctx).Msgf("next distribution will run in %s", time.Until(next))
+
+		return common.ErrNotDistributionTime
+	}
+
+	votes, err := u.database.GetUndistributedVotes(ctx, round)
+
+	if err != nil {
+		return fmt.Errorf("failed to get undistributed votes: %w", err)
+	}
+
+	allocations := allocate(votes, input.Formula)
+
+	tree, err := buildMerkleTree(allocations)
+
+	if err != nil {
+		return fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	nodes := []string{}
+	for _, node := range tree.Nodes() {
+		nodes = append(nodes, node.Hex())
+	}
+
+	root := tree.Root()
+	distribution, err := u.database.CreateDistribution(ctx, round, votes, allocations, root.Hex(), nodes)
+
+	if err != nil {
+		return fmt.Errorf("failed to create distribution: %w", err)
+	}
+
+	u.logger.Info(ctx).Msgf("distributed %v to %v authors from %v votes in round %v", distribution.Amount(), len(allocations), distribution.Votes(), distribution.Round())
+
+	return nil
+}
+
+// Rounds start at multiples of the interval, the decisions made before the start of a round are consumed by it
+func distributionRound(now time.Time, interval time.Duration) time.Time {
+	return now.UTC().Truncate(interval)
+}
+
+// Splits the pool between the authors voted on as described by the formula.
+// Shares are rounded down so the allocations never exceed the pool.
+// The result only depends on the votes and formula and is ordered by address.
+func allocate(votes []entities.DistributionVote, formula entities.DistributionFormula) []entities.Allocation {
+	scores := map[string]*big.Rat{}
+	counts := map[string]int64{}
+
+	for _, vote := range votes {
+		if !vote.Counts() {
+			continue
+		}
+
+		decision := vote.Decision()
+		author := *decision.Author()
+
+		var sign int64
+		switch decision.Value() {
+		case entities.Upvote:
+			sign = 1
+		case entities.Downvote:
+			sign = -1
+		default:
+			continue
+		}
+
+		weight := new(big.Rat).Mul(new(big.Rat).SetInt(decision.Reputation()), formula.ReputationWeight)
+		weight.Add(weight, formula.VoteWeight)
+		weight.Mul(weight, big.NewRat(sign, 1))
+
+		if _, ok := scores[author]; !ok {
+			scores[author] = new(big.Rat)
+		}
+		scores[author].Add(scores[author], weight)
+		counts[author]++
+	}
+
+	total := new(big.Rat)
+	addresses := []string{}
+	for address, score := range scores {
+		if score.Sign() > 0 {
+			total.Add(total, score)
+			addresses = append(addresses, address)
+		}
+	}
+	sort.Strings(addresses)
+
+	allocations := []entities.Allocation{}
+
+	if total.Sign() == 0 {
+		return allocations
+	}
+
+	for _, address := range addresses {
+		share := new(big.Rat).Mul(new(big.Rat).SetInt(formula.Pool), scores[address])
+		share.Quo(share, total)
+
+		amount := new(big.Int).Quo(share.Num(), share.Denom())
+
+		if amount.Sign() == 0 {
+			continue
+		}
+
+		allocations = append(allocations, entities.NewAllocation(entities.AllocationParams{
+			Address: address,
+			Amount:  amount,
+			Votes:   counts[address],
+		}))
+	}
+
+	return allocations
+}
+
+// Every allocation is a leaf so authors can claim their amount on chain with a proof against the root
+func buildMerkleTree(allocations []entities.Allocation) (common.MerkleTree, error) {
+	leaves := []common.MerkleHash{}
+	for _, allocation := range allocations {
+		leaf, err := common.MerkleLeaf(allocation.Address(), allocation.Amount())
+
+		if err != nil {
+			return common.MerkleTree{}, err
+		}
+
+		leaves = append(leaves, leaf)
+	}
+
+	return common.NewMerkleTree(leaves), nil
 }
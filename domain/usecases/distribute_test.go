@@ -0,0 +1,305 @@
+package usecases
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+const (
+	alice = "0x1111111111111111111111111111111111111111"
+	bob   = "0x2222222222222222222222222222222222222222"
+	carol = "0x3333333333333333333333333333333333333333"
+	voter = "0x9999999999999999999999999999999999999999"
+)
+
+type testVote struct {
+	author        string
+	value         entities.VoteValue
+	reputation    int64
+	reason        *entities.VoteDecisionReason
+	superseded    bool
+	countedBefore bool
+}
+
+func toDistributionVotes(votes []testVote) []entities.DistributionVote {
+	distributionVotes := []entities.DistributionVote{}
+	for i, vote := range votes {
+		author := vote.author
+		distributionVotes = append(distributionVotes, entities.NewDistributionVote(entities.DistributionVoteParams{
+			DecisionId: int64(i + 1),
+			Decision: entities.NewVoteDecision(entities.VoteDecisionParams{
+				Type:       entities.ThreadVote,
+				TargetId:   int64(i + 1),
+				Voter:      voter,
+				Author:     &author,
+				Value:      vote.value,
+				Reputation: big.NewInt(vote.reputation),
+				Reason:     vote.reason,
+			}),
+			Latest:        !vote.superseded,
+			CountedBefore: vote.countedBefore,
+		}))
+	}
+	return distributionVotes
+}
+
+func reason(r entities.VoteDecisionReason) *entities.VoteDecisionReason {
+	return &r
+}
+
+func TestAllocate(t *testing.T) {
+	type expected struct {
+		amount int64
+		votes  int64
+	}
+
+	tests := []struct {
+		name             string
+		pool             int64
+		voteWeight       *big.Rat
+		reputationWeight *big.Rat
+		votes            []testVote
+		// the number of votes the round records as counted
+		counted  int
+		expected map[string]expected
+	}{
+		{
+			name:             "no votes",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes:            []testVote{},
+			counted:          0,
+			expected:         map[string]expected{},
+		},
+		{
+			name:             "no votes that count",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote, reason: reason(entities.SelfVote)},
+				{author: alice, value: entities.Upvote, superseded: true},
+				{author: bob, value: entities.Upvote, countedBefore: true},
+				{author: bob, value: entities.Unvote},
+			},
+			counted:  0,
+			expected: map[string]expected{},
+		},
+		{
+			name:             "single author takes the pool",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: alice, value: entities.Upvote},
+			},
+			counted: 2,
+			expected: map[string]expected{
+				alice: {amount: 100, votes: 2},
+			},
+		},
+		{
+			name:             "shares are rounded down",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: bob, value: entities.Upvote},
+				{author: carol, value: entities.Upvote},
+			},
+			counted: 3,
+			expected: map[string]expected{
+				alice: {amount: 33, votes: 1},
+				bob:   {amount: 33, votes: 1},
+				carol: {amount: 33, votes: 1},
+			},
+		},
+		{
+			name:             "shares rounded down to zero are skipped",
+			pool:             2,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: bob, value: entities.Upvote},
+				{author: bob, value: entities.Upvote},
+			},
+			counted: 3,
+			expected: map[string]expected{
+				bob: {amount: 1, votes: 2},
+			},
+		},
+		{
+			name:             "reputation adds weight",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(1, 2),
+			votes: []testVote{
+				// 1 + 6/2 = 4
+				{author: alice, value: entities.Upvote, reputation: 6},
+				// 1 + 0/2 = 1
+				{author: bob, value: entities.Upvote},
+			},
+			counted: 2,
+			expected: map[string]expected{
+				alice: {amount: 80, votes: 1},
+				bob:   {amount: 20, votes: 1},
+			},
+		},
+		{
+			name:             "downvotes reduce the score",
+			pool:             90,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: alice, value: entities.Upvote},
+				{author: alice, value: entities.Upvote},
+				{author: alice, value: entities.Downvote},
+				{author: bob, value: entities.Upvote},
+			},
+			counted: 5,
+			expected: map[string]expected{
+				alice: {amount: 60, votes: 4},
+				bob:   {amount: 30, votes: 1},
+			},
+		},
+		{
+			name:             "negative scores get nothing and do not shrink the other shares",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: bob, value: entities.Downvote},
+				{author: bob, value: entities.Downvote},
+				{author: carol, value: entities.Upvote},
+				{author: carol, value: entities.Downvote},
+			},
+			counted: 5,
+			expected: map[string]expected{
+				alice: {amount: 100, votes: 1},
+			},
+		},
+		{
+			name:             "only negative scores",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Downvote},
+			},
+			counted:  1,
+			expected: map[string]expected{},
+		},
+		{
+			name:             "votes that do not count are ignored",
+			pool:             100,
+			voteWeight:       big.NewRat(1, 1),
+			reputationWeight: big.NewRat(0, 1),
+			votes: []testVote{
+				{author: alice, value: entities.Upvote},
+				{author: alice, value: entities.Upvote, reason: reason(entities.InsufficientReputation)},
+				{author: alice, value: entities.Upvote, superseded: true},
+				{author: alice, value: entities.Upvote, countedBefore: true},
+				{author: alice, value: entities.Unvote},
+				{author: bob, value: entities.Upvote},
+				{author: bob, value: entities.Downvote, reason: reason(entities.BannedVoter)},
+			},
+			counted: 2,
+			expected: map[string]expected{
+				alice: {amount: 50, votes: 1},
+				bob:   {amount: 50, votes: 1},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			votes := toDistributionVotes(test.votes)
+			allocations := allocate(votes, entities.DistributionFormula{
+				Pool:             big.NewInt(test.pool),
+				VoteWeight:       test.voteWeight,
+				ReputationWeight: test.reputationWeight,
+			})
+
+			// the round records every vote that counts as consumed and counted
+			counted := 0
+			for _, vote := range votes {
+				if vote.Counts() {
+					counted++
+				}
+			}
+			if counted != test.counted {
+				t.Errorf("expected %v counted votes but got %v", test.counted, counted)
+			}
+
+			if len(allocations) != len(test.expected) {
+				t.Fatalf("expected %v allocations but got %v", len(test.expected), len(allocations))
+			}
+
+			total := big.NewInt(0)
+			allocatedVotes := int64(0)
+			for i, allocation := range allocations {
+				if i > 0 && allocations[i-1].Address() >= allocation.Address() {
+					t.Errorf("expected allocations to be ordered by address")
+				}
+
+				expected, ok := test.expected[allocation.Address()]
+				if !ok {
+					t.Errorf("unexpected allocation to %v", allocation.Address())
+					continue
+				}
+				if allocation.Amount().Cmp(big.NewInt(expected.amount)) != 0 {
+					t.Errorf("expected %v to get %v but got %v", allocation.Address(), expected.amount, allocation.Amount())
+				}
+				if allocation.Votes() != expected.votes {
+					t.Errorf("expected %v to have %v votes but got %v", allocation.Address(), expected.votes, allocation.Votes())
+				}
+
+				total.Add(total, allocation.Amount())
+				allocatedVotes += allocation.Votes()
+			}
+
+			if total.Cmp(big.NewInt(test.pool)) > 0 {
+				t.Errorf("expected allocations to not exceed the pool %v but got %v", test.pool, total)
+			}
+
+			if allocatedVotes > int64(counted) {
+				t.Errorf("expected allocations to have at most %v votes but got %v", counted, allocatedVotes)
+			}
+		})
+	}
+}
+
+func TestAllocateIsDeterministic(t *testing.T) {
+	votes := toDistributionVotes([]testVote{
+		{author: carol, value: entities.Upvote, reputation: 3},
+		{author: alice, value: entities.Upvote, reputation: 5},
+		{author: bob, value: entities.Upvote, reputation: 7},
+	})
+	reversed := []entities.DistributionVote{votes[2], votes[1], votes[0]}
+	formula := entities.DistributionFormula{
+		Pool:             big.NewInt(1_000_000),
+		VoteWeight:       big.NewRat(1, 1),
+		ReputationWeight: big.NewRat(1, 10),
+	}
+
+	first, err := buildMerkleTree(allocate(votes, formula))
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := buildMerkleTree(allocate(reversed, formula))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Root() != second.Root() {
+		t.Errorf("expected the same root regardless of the order of the votes")
+	}
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type EditComment struct {
+	validator common.Validator
+	images    gateways.Images
+	database  gateways.Database
+}
+
+func NewEditCommentUseCase(validator common.Validator, images gateways.Images, database gateways.Database) *EditComment {
+	return &EditComment{
+		validator,
+		images,
+		database,
+	}
+}
+
+// Nil fields are left unchanged
+type EditCommentInput struct {
+	CommentId     int64   `validate:"gt=0"`
+	EditorAddress string  `validate:"eth_addr"`
+	Content       *string `validate:"omitempty,max=1000"`
+	ImageFileName *string `validate:"omitempty,max=100"`
+	// how long after creation the author can edit the comment
+	Window time.Duration `validate:"gt=0"`
+}
+
+func (u *EditComment) Execute(ctx context.Context, input EditCommentInput) (entities.Comment, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Comment{}, err
+	}
+
+	comment, err := u.database.GetCommentById(ctx, input.CommentId)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	user := comment.User()
+	if user.Address() != input.EditorAddress {
+		return entities.Comment{}, fmt.Errorf("comment does not belong to the user: %w", common.ErrForbidden)
+	}
+
+	if comment.IsDeleted() {
+		return entities.Comment{}, errors.New("comment is deleted")
+	}
+
+	if time.Since(comment.CreatedAt()) > input.Window {
+		return entities.Comment{}, errors.New("comment can no longer be edited")
+	}
+
+	content := comment.Content()
+	if input.Content != nil {
+		content = *input.Content
+	}
+
+	image := comment.Image()
+	if input.ImageFileName != nil {
+		image, err = u.images.GetImageByFileName(ctx, *input.ImageFileName)
+
+		if err != nil {
+			return entities.Comment{}, err
+		}
+
+		if image == nil {
+			return entities.Comment{}, fmt.Errorf("image not found %w", common.ErrNotFound)
+		}
+	}
+
+	return u.database.UpdateComment(ctx, input.CommentId, content, image)
+}
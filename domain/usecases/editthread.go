@@ -0,0 +1,87 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type EditThread struct {
+	validator common.Validator
+	images    gateways.Images
+	database  gateways.Database
+}
+
+func NewEditThreadUseCase(validator common.Validator, images gateways.Images, database gateways.Database) *EditThread {
+	return &EditThread{
+		validator,
+		images,
+		database,
+	}
+}
+
+// Nil fields are left unchanged
+type EditThreadInput struct {
+	ThreadId      int64   `validate:"gt=0"`
+	EditorAddress string  `validate:"eth_addr"`
+	Title         *string `validate:"omitempty,max=100"`
+	Content       *string `validate:"omitempty,max=1000"`
+	ImageFileName *string `validate:"omitempty,max=100"`
+	// how long after creation the author can edit the thread
+	Window time.Duration `validate:"gt=0"`
+}
+
+func (u *EditThread) Execute(ctx context.Context, input EditThreadInput) (entities.Thread, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Thread{}, err
+	}
+
+	thread, err := u.database.GetThreadById(ctx, input.ThreadId)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	user := thread.User()
+	if user.Address() != input.EditorAddress {
+		return entities.Thread{}, fmt.Errorf("thread does not belong to the user: %w", common.ErrForbidden)
+	}
+
+	if thread.IsDeleted() {
+		return entities.Thread{}, errors.New("thread is deleted")
+	}
+
+	if time.Since(thread.CreatedAt()) > input.Window {
+		return entities.Thread{}, errors.New("thread can no longer be edited")
+	}
+
+	title := thread.Title()
+	if input.Title != nil {
+		title = *input.Title
+	}
+
+	content := thread.Content()
+	if input.Content != nil {
+		content = *input.Content
+	}
+
+	image := thread.Image()
+	if input.ImageFileName != nil {
+		image, err = u.images.GetImageByFileName(ctx, *input.ImageFileName)
+
+		if err != nil {
+			return entities.Thread{}, err
+		}
+
+		if image == nil {
+			return entities.Thread{}, fmt.Errorf("image not found %w", common.ErrNotFound)
+		}
+	}
+
+	return u.database.UpdateThread(ctx, input.ThreadId, title, content, image)
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type EnableWebhook struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewEnableWebhookUseCase(validator common.Validator, database gateways.Database) *EnableWebhook {
+	return &EnableWebhook{
+		validator,
+		database,
+	}
+}
+
+type EnableWebhookInput struct {
+	Id int64 `validate:"gt=0"`
+}
+
+// Re-enables a webhook that was disabled after repeated failures.
+// Events published while it was disabled are not redelivered.
+func (u *EnableWebhook) Execute(ctx context.Context, input EnableWebhookInput) (entities.Webhook, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return u.database.EnableWebhook(ctx, input.Id)
+}
@@ -0,0 +1,51 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetBan struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetBanUseCase(validator common.Validator, database gateways.Database) *GetBan {
+	return &GetBan{
+		validator,
+		database,
+	}
+}
+
+type GetBanInput struct {
+	Address string `validate:"eth_addr"`
+}
+
+// nil if the address has no active ban
+func (u *GetBan) Execute(ctx context.Context, input GetBanInput) (*entities.Ban, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return u.database.GetActiveBan(ctx, input.Address)
+}
+
+// Shadow banned addresses are let through since their posts are filtered when read.
+// The active ban is returned so callers can tell shadow banned addresses apart.
+func rejectHardBan(ctx context.Context, database gateways.Database, address string) (*entities.Ban, error) {
+	ban, err := database.GetActiveBan(ctx, address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if ban != nil && ban.Type() == entities.HardBan {
+		return nil, fmt.Errorf("address %v is banned: %w", address, common.ErrForbidden)
+	}
+
+	return ban, nil
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetBoard struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetBoardUseCase(validator common.Validator, database gateways.Database) *GetBoard {
+	return &GetBoard{
+		validator,
+		database,
+	}
+}
+
+type GetBoardInput struct {
+	Slug string `validate:"min=1,max=32"`
+}
+
+func (u *GetBoard) Execute(ctx context.Context, input GetBoardInput) (entities.Board, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Board{}, err
+	}
+
+	return u.database.GetBoardBySlug(ctx, input.Slug)
+}
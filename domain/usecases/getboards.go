@@ -0,0 +1,22 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetBoards struct {
+	database gateways.Database
+}
+
+func NewGetBoardsUseCase(database gateways.Database) *GetBoards {
+	return &GetBoards{
+		database,
+	}
+}
+
+func (u *GetBoards) Execute(ctx context.Context) ([]entities.Board, error) {
+	return u.database.GetBoards(ctx)
+}
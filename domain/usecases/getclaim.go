@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetClaim struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetClaimUseCase(validator common.Validator, database gateways.Database) *GetClaim {
+	return &GetClaim{
+		validator,
+		database,
+	}
+}
+
+type GetClaimInput struct {
+	DistributionId int64  `validate:"gt=0"`
+	Address        string `validate:"eth_addr"`
+}
+
+// The allocation of the address in the distribution along with its merkle proof.
+// common.ErrNotFound if the address has no allocation in the distribution or the distribution has no merkle tree.
+func (u *GetClaim) Execute(ctx context.Context, input GetClaimInput) (entities.Claim, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Claim{}, err
+	}
+
+	distribution, err := u.database.GetDistributionById(ctx, input.DistributionId)
+
+	if err != nil {
+		return entities.Claim{}, err
+	}
+
+	allocation, err := u.database.GetAllocation(ctx, input.DistributionId, input.Address)
+
+	if err != nil {
+		return entities.Claim{}, err
+	}
+
+	nodes, err := u.database.GetMerkleTree(ctx, input.DistributionId)
+
+	if err != nil {
+		return entities.Claim{}, err
+	}
+
+	hashes := []common.MerkleHash{}
+	for _, node := range nodes {
+		hash, err := common.ParseMerkleHash(node)
+
+		if err != nil {
+			return entities.Claim{}, err
+		}
+
+		hashes = append(hashes, hash)
+	}
+
+	leaf, err := common.MerkleLeaf(allocation.Address(), allocation.Amount())
+
+	if err != nil {
+		return entities.Claim{}, err
+	}
+
+	tree := common.NewMerkleTreeFromNodes(hashes)
+	proof, ok := tree.Proof(leaf)
+
+	if !ok {
+		return entities.Claim{}, fmt.Errorf("allocation of %v is not in the tree of distribution %v: %w", input.Address, input.DistributionId, common.ErrNotFound)
+	}
+
+	proofHex := []string{}
+	for _, hash := range proof {
+		proofHex = append(proofHex, hash.Hex())
+	}
+
+	return entities.NewClaim(allocation, distribution.MerkleRoot(), proofHex), nil
+}
@@ -22,14 +22,94 @@ func NewGetCommentsUseCase(validator common.Validator, database gateways.Databas
 
 type GetCommentsInput struct {
 	ThreadId int64 `validate:"gt=0"`
-	Offset   int64 `validate:"gte=0"`
-	Limit    int64 `validate:"gt=0,lte=100"`
+	// offset pagination is kept for older clients, cursor pagination is used when nil
+	Offset *int64 `validate:"omitempty,gte=0"`
+	// nil when fetching the first page
+	Cursor *entities.CommentCursor
+	Limit  int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
 }
 
-func (u *GetComments) Execute(ctx context.Context, input GetCommentsInput) ([]entities.Comment, int64, error) {
+// CommentsPage describes how to fetch the pages around a page of comments.
+// Count is only known for offset pages, cursors are only set for cursor pages.
+type CommentsPage struct {
+	Count int64
+	Next  *entities.CommentCursor
+	Prev  *entities.CommentCursor
+}
+
+func (u *GetComments) Execute(ctx context.Context, input GetCommentsInput) ([]entities.Comment, CommentsPage, error) {
 	if err := u.validator.ValidateStruct(input); err != nil {
-		return nil, -1, err
+		return nil, CommentsPage{}, err
+	}
+
+	if input.Offset != nil {
+		comments, count, err := u.database.GetComments(ctx, input.ThreadId, *input.Offset, input.Limit, input.Viewer)
+
+		if err != nil {
+			return nil, CommentsPage{}, err
+		}
+
+		if err := setCommentVotes(ctx, u.database, input.Viewer, comments); err != nil {
+			return nil, CommentsPage{}, err
+		}
+
+		return comments, CommentsPage{Count: count}, nil
+	}
+
+	// fetch one extra comment to know if there is another page in the walked direction
+	comments, err := u.database.GetCommentsByCursor(ctx, gateways.CommentsSpec{
+		ThreadId: input.ThreadId,
+		Cursor:   input.Cursor,
+		Limit:    input.Limit + 1,
+		Viewer:   input.Viewer,
+	})
+
+	if err != nil {
+		return nil, CommentsPage{}, err
+	}
+
+	hasMore := int64(len(comments)) > input.Limit
+	if hasMore {
+		comments = comments[:input.Limit]
+	}
+
+	if err := setCommentVotes(ctx, u.database, input.Viewer, comments); err != nil {
+		return nil, CommentsPage{}, err
+	}
+
+	page := CommentsPage{}
+
+	if input.Cursor != nil && input.Cursor.Direction() == entities.PrevCursorDirection {
+		// newer comments are walked in ascending order so flip them back to newest first
+		for i, j := 0, len(comments)-1; i < j; i, j = i+1, j-1 {
+			comments[i], comments[j] = comments[j], comments[i]
+		}
+		if hasMore {
+			page.Prev = toCommentCursor(comments[0], entities.PrevCursorDirection)
+		}
+		if len(comments) > 0 {
+			page.Next = toCommentCursor(comments[len(comments)-1], entities.NextCursorDirection)
+		}
+		return comments, page, nil
 	}
 
-	return u.database.GetComments(ctx, input.ThreadId, input.Offset, input.Limit)
+	if hasMore {
+		page.Next = toCommentCursor(comments[len(comments)-1], entities.NextCursorDirection)
+	}
+	if input.Cursor != nil && len(comments) > 0 {
+		page.Prev = toCommentCursor(comments[0], entities.PrevCursorDirection)
+	}
+
+	return comments, page, nil
+}
+
+func toCommentCursor(comment entities.Comment, direction entities.CursorDirection) *entities.CommentCursor {
+	cursor := entities.NewCommentCursor(entities.CommentCursorParams{
+		Id:        comment.Id(),
+		CreatedAt: comment.CreatedAt(),
+		Direction: direction,
+	})
+	return &cursor
 }
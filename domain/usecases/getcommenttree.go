@@ -0,0 +1,77 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetCommentTree struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetCommentTreeUseCase(validator common.Validator, database gateways.Database) *GetCommentTree {
+	return &GetCommentTree{
+		validator,
+		database,
+	}
+}
+
+type GetCommentTreeInput struct {
+	ThreadId int64 `validate:"gt=0"`
+	// nil for the top level comments of the thread
+	ParentId *int64 `validate:"omitempty,gt=0"`
+	// nil when fetching the first page of replies
+	Cursor     *entities.CommentCursor
+	Limit      int64 `validate:"gt=0,lte=100"`
+	MaxDepth   int32 `validate:"gt=0,lte=10"`
+	ReplyLimit int64 `validate:"gt=0,lte=20"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
+}
+
+// Returns a page of replies to the parent comment (or top level comments) with their nested replies hydrated
+// and the cursor of the next page.
+func (u *GetCommentTree) Execute(ctx context.Context, input GetCommentTreeInput) ([]entities.Comment, *entities.CommentCursor, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, nil, err
+	}
+
+	if input.ParentId != nil {
+		parent, err := u.database.GetCommentById(ctx, *input.ParentId)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if parent.ThreadId() != input.ThreadId {
+			return nil, nil, common.ErrNotFound
+		}
+	}
+
+	// fetch one extra comment to know if there is a next page
+	comments, err := u.database.GetCommentTree(ctx, gateways.CommentTreeSpec{
+		ThreadId:   input.ThreadId,
+		ParentId:   input.ParentId,
+		Cursor:     input.Cursor,
+		Limit:      input.Limit + 1,
+		MaxDepth:   input.MaxDepth,
+		ReplyLimit: input.ReplyLimit,
+		Viewer:     input.Viewer,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if int64(len(comments)) <= input.Limit {
+		return comments, nil, nil
+	}
+
+	comments = comments[:input.Limit]
+
+	return comments, toCommentCursor(comments[len(comments)-1], entities.NextCursorDirection), nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetDistribution struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetDistributionUseCase(validator common.Validator, database gateways.Database) *GetDistribution {
+	return &GetDistribution{
+		validator,
+		database,
+	}
+}
+
+type GetDistributionInput struct {
+	DistributionId int64 `validate:"gt=0"`
+	Offset         int64 `validate:"gte=0"`
+	Limit          int64 `validate:"gt=0,lte=100"`
+}
+
+// The round along with a page of its allocations, largest first
+func (u *GetDistribution) Execute(ctx context.Context, input GetDistributionInput) (entities.Distribution, []entities.Allocation, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Distribution{}, nil, -1, err
+	}
+
+	distribution, err := u.database.GetDistributionById(ctx, input.DistributionId)
+
+	if err != nil {
+		return entities.Distribution{}, nil, -1, err
+	}
+
+	allocations, count, err := u.database.GetAllocationsByDistribution(ctx, input.DistributionId, input.Offset, input.Limit)
+
+	if err != nil {
+		return entities.Distribution{}, nil, -1, err
+	}
+
+	return distribution, allocations, count, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetDistributions struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetDistributionsUseCase(validator common.Validator, database gateways.Database) *GetDistributions {
+	return &GetDistributions{
+		validator,
+		database,
+	}
+}
+
+type GetDistributionsInput struct {
+	Offset int64 `validate:"gte=0"`
+	Limit  int64 `validate:"gt=0,lte=100"`
+}
+
+// The latest rounds first
+func (u *GetDistributions) Execute(ctx context.Context, input GetDistributionsInput) ([]entities.Distribution, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, -1, err
+	}
+
+	return u.database.GetDistributions(ctx, input.Offset, input.Limit)
+}
@@ -0,0 +1,34 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetModerationLog struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetModerationLogUseCase(validator common.Validator, database gateways.Database) *GetModerationLog {
+	return &GetModerationLog{
+		validator,
+		database,
+	}
+}
+
+type GetModerationLogInput struct {
+	Offset int64 `validate:"gte=0"`
+	Limit  int64 `validate:"gt=0,lte=100"`
+}
+
+func (u *GetModerationLog) Execute(ctx context.Context, input GetModerationLogInput) ([]entities.ModerationLog, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, -1, err
+	}
+
+	return u.database.GetModerationLog(ctx, input.Offset, input.Limit)
+}
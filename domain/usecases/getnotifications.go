@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetNotifications struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetNotificationsUseCase(validator common.Validator, database gateways.Database) *GetNotifications {
+	return &GetNotifications{
+		validator,
+		database,
+	}
+}
+
+type GetNotificationsInput struct {
+	Recipient  string `validate:"eth_addr"`
+	UnreadOnly bool
+	Offset     int64 `validate:"gte=0"`
+	Limit      int64 `validate:"gt=0,lte=100"`
+}
+
+func (u *GetNotifications) Execute(ctx context.Context, input GetNotificationsInput) ([]entities.Notification, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, -1, err
+	}
+
+	return u.database.GetNotifications(ctx, input.Recipient, input.UnreadOnly, input.Offset, input.Limit)
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetReports struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetReportsUseCase(validator common.Validator, database gateways.Database) *GetReports {
+	return &GetReports{
+		validator,
+		database,
+	}
+}
+
+type GetReportsInput struct {
+	Offset int64 `validate:"gte=0"`
+	Limit  int64 `validate:"gt=0,lte=100"`
+}
+
+// The moderation queue holds the open reports grouped by target with the most reported targets first
+func (u *GetReports) Execute(ctx context.Context, input GetReportsInput) ([]entities.ReportGroup, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, -1, err
+	}
+
+	return u.database.GetOpenReports(ctx, input.Offset, input.Limit)
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetRevisions struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetRevisionsUseCase(validator common.Validator, database gateways.Database) *GetRevisions {
+	return &GetRevisions{
+		validator,
+		database,
+	}
+}
+
+type GetRevisionsInput struct {
+	Id   int64                 `validate:"gt=0"`
+	Type entities.RevisionType `validate:"oneof=thread comment"`
+}
+
+// Returns the prior versions of a thread or comment, most recently replaced first.
+// Revisions of deleted threads and comments are not returned.
+func (u *GetRevisions) Execute(ctx context.Context, input GetRevisionsInput) ([]entities.Revision, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	if input.Type == entities.ThreadRevision {
+		thread, err := u.database.GetThreadById(ctx, input.Id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if thread.IsDeleted() {
+			return []entities.Revision{}, nil
+		}
+
+		return u.database.GetThreadRevisions(ctx, input.Id)
+	}
+
+	comment, err := u.database.GetCommentById(ctx, input.Id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.IsDeleted() {
+		return []entities.Revision{}, nil
+	}
+
+	return u.database.GetCommentRevisions(ctx, input.Id)
+}
@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetRoles struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetRolesUseCase(validator common.Validator, database gateways.Database) *GetRoles {
+	return &GetRoles{
+		validator,
+		database,
+	}
+}
+
+type GetRolesInput struct {
+	Address string `validate:"eth_addr"`
+}
+
+func (u *GetRoles) Execute(ctx context.Context, input GetRolesInput) ([]entities.Role, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	return u.database.GetRolesByAddress(ctx, input.Address)
+}
@@ -11,32 +11,37 @@ import (
 )
 
 type GetThread struct {
-	validator common.Validator
-	database  gateways.Database
+	validator   common.Validator
+	database    gateways.Database
+	getComments *GetComments
 }
 
-func NewGetThreadUseCase(validator common.Validator, database gateways.Database) *GetThread {
+func NewGetThreadUseCase(validator common.Validator, database gateways.Database, getComments *GetComments) *GetThread {
 	return &GetThread{
 		validator,
 		database,
+		getComments,
 	}
 }
 
 type GetThreadInput struct {
-	ThreadId      int64 `validate:"gt=0"`
-	CommentOffset int64 `validate:"gte=0"`
+	ThreadId      int64  `validate:"gt=0"`
+	CommentOffset *int64 `validate:"omitempty,gte=0"`
+	CommentCursor *entities.CommentCursor
 	CommentLimit  int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
 }
 
-func (u *GetThread) Execute(ctx context.Context, input GetThreadInput) (entities.Thread, int64, error) {
+func (u *GetThread) Execute(ctx context.Context, input GetThreadInput) (entities.Thread, CommentsPage, error) {
 	if err := u.validator.ValidateStruct(input); err != nil {
-		return entities.Thread{}, -1, fmt.Errorf("invalid input: %w", err)
+		return entities.Thread{}, CommentsPage{}, fmt.Errorf("invalid input: %w", err)
 	}
 
 	// threads and comments can be fetched concurrently
 	var thread entities.Thread
 	var comments []entities.Comment
-	var commentsCount int64
+	var commentsPage CommentsPage
 	var threadErr error
 	var commentsErr error
 
@@ -48,19 +53,31 @@ func (u *GetThread) Execute(ctx context.Context, input GetThreadInput) (entities
 	}()
 	go func() {
 		defer wg.Done()
-		comments, commentsCount, commentsErr = u.database.GetComments(ctx, input.ThreadId, input.CommentOffset, input.CommentLimit)
+		comments, commentsPage, commentsErr = u.getComments.Execute(ctx, GetCommentsInput{
+			ThreadId: input.ThreadId,
+			Offset:   input.CommentOffset,
+			Cursor:   input.CommentCursor,
+			Limit:    input.CommentLimit,
+			Viewer:   input.Viewer,
+		})
 	}()
 	wg.Wait()
 
 	if threadErr != nil {
-		return entities.Thread{}, -1, fmt.Errorf("failed to fetch thread: %w", threadErr)
+		return entities.Thread{}, CommentsPage{}, fmt.Errorf("failed to fetch thread: %w", threadErr)
 	}
 
 	if commentsErr != nil {
-		return entities.Thread{}, -1, fmt.Errorf("failed to fetch comments: %w", commentsErr)
+		return entities.Thread{}, CommentsPage{}, fmt.Errorf("failed to fetch comments: %w", commentsErr)
 	}
 
+	threads := []entities.Thread{thread}
+	if err := setThreadVotes(ctx, u.database, input.Viewer, threads); err != nil {
+		return entities.Thread{}, CommentsPage{}, fmt.Errorf("failed to fetch votes: %w", err)
+	}
+	thread = threads[0]
+
 	thread.SetComments(&comments)
 
-	return thread, commentsCount, nil
+	return thread, commentsPage, nil
 }
@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetThreadEvents struct {
+	validator common.Validator
+	stream    gateways.Stream
+}
+
+func NewGetThreadEventsUseCase(validator common.Validator, stream gateways.Stream) *GetThreadEvents {
+	return &GetThreadEvents{
+		validator,
+		stream,
+	}
+}
+
+type GetThreadEventsInput struct {
+	ThreadId int64  `validate:"gt=0"`
+	After    string `validate:"min=1,max=64"`
+}
+
+// Replays the events of a thread published after the given stream id.
+// The stream is capped so events that have been trimmed since are silently skipped.
+func (u *GetThreadEvents) Execute(ctx context.Context, input GetThreadEventsInput) ([]entities.ThreadEvent, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, err
+	}
+
+	events, err := u.stream.GetThreadEvents(ctx, input.After, 10000)
+
+	if err != nil {
+		return nil, err
+	}
+
+	threadEvents := []entities.ThreadEvent{}
+	for _, event := range events {
+		if event.ThreadId() == input.ThreadId {
+			threadEvents = append(threadEvents, event)
+		}
+	}
+
+	return threadEvents, nil
+}
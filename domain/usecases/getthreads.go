@@ -2,6 +2,7 @@ package usecases
 
 import (
 	"context"
+	"time"
 
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/entities"
@@ -23,14 +24,78 @@ func NewGetThreadsUseCase(logger common.Logger, validator common.Validator, data
 }
 
 type GetThreadsInput struct {
-	Limit int64 `validate:"gte=0,lte=100"`
+	// nil for the feed of every board
+	BoardSlug *string               `validate:"omitempty,min=1,max=32"`
+	Sort      entities.ThreadSort   `validate:"oneof=hot new top active"`
+	Window    entities.ThreadWindow `validate:"oneof=day week all"`
+	Cursor    *entities.ThreadCursor
+	Limit     int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
 }
 
-// Threads returned are random and thus the concept of pages/offset/count is not relevant
-func (u *GetThreads) Execute(ctx context.Context, input GetThreadsInput) ([]entities.Thread, error) {
+// Threads are keyset paginated so the returned cursor points at the last thread of the page.
+// We fetch one more thread than requested to know if there is a next page without counting the feed.
+// The returned cursor is nil when there are no more threads.
+func (u *GetThreads) Execute(ctx context.Context, input GetThreadsInput) ([]entities.Thread, *entities.ThreadCursor, error) {
 	if err := u.validator.ValidateStruct(input); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return u.database.GetThreads(ctx, input.Limit)
+	var boardId *int64
+	if input.BoardSlug != nil {
+		board, err := u.database.GetBoardBySlug(ctx, *input.BoardSlug)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id := board.Id()
+		boardId = &id
+	}
+
+	// the window slides with time so later pages keep the window of the first page,
+	// otherwise threads leaving the window in between would shift the pages and be skipped or repeated
+	since := input.Window.Since(time.Now())
+	if input.Cursor != nil && !input.Cursor.Since().IsZero() {
+		since = input.Cursor.Since()
+	}
+
+	threads, err := u.database.GetThreads(ctx, gateways.ThreadsSpec{
+		BoardId: boardId,
+		Sort:    input.Sort,
+		Since:   since,
+		Cursor:  input.Cursor,
+		Limit:   input.Limit + 1,
+		Viewer:  input.Viewer,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cursor *entities.ThreadCursor
+	if int64(len(threads)) > input.Limit {
+		threads = threads[:input.Limit]
+		last := threads[len(threads)-1]
+		c := last.Cursor(since)
+		cursor = &c
+	}
+
+	// pinned threads lead the first page and are left out of the feed itself
+	if input.Cursor == nil {
+		pinned, err := u.database.GetPinnedThreads(ctx, boardId, input.Viewer)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		threads = append(pinned, threads...)
+	}
+
+	if err := setThreadVotes(ctx, u.database, input.Viewer, threads); err != nil {
+		return nil, nil, err
+	}
+
+	return threads, cursor, nil
 }
@@ -22,6 +22,8 @@ func NewGetUserUseCase(logger common.Logger, database gateways.Database) *GetUse
 
 type GetUserInput struct {
 	Address string `validate:"eth_addr"`
+	// counting the activity of the user is skipped unless requested
+	WithStats bool
 }
 
 func (g *GetUser) Execute(ctx context.Context, input GetUserInput) (entities.User, error) {
@@ -31,5 +33,17 @@ func (g *GetUser) Execute(ctx context.Context, input GetUserInput) (entities.Use
 		return entities.User{}, err
 	}
 
+	if !input.WithStats {
+		return user, nil
+	}
+
+	stats, err := g.database.GetUserStats(ctx, input.Address)
+
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	user.SetStats(&stats)
+
 	return user, nil
 }
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetUserAllocations struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetUserAllocationsUseCase(validator common.Validator, database gateways.Database) *GetUserAllocations {
+	return &GetUserAllocations{
+		validator,
+		database,
+	}
+}
+
+type GetUserAllocationsInput struct {
+	Address string `validate:"eth_addr"`
+	Offset  int64  `validate:"gte=0"`
+	Limit   int64  `validate:"gt=0,lte=100"`
+}
+
+// The lifetime earnings of the address along with a page of its allocations, latest rounds first
+func (u *GetUserAllocations) Execute(ctx context.Context, input GetUserAllocationsInput) (entities.Earnings, []entities.Allocation, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Earnings{}, nil, -1, err
+	}
+
+	earnings, err := u.database.GetEarnings(ctx, input.Address)
+
+	if err != nil {
+		return entities.Earnings{}, nil, -1, err
+	}
+
+	allocations, count, err := u.database.GetAllocationsByAddress(ctx, input.Address, input.Offset, input.Limit)
+
+	if err != nil {
+		return entities.Earnings{}, nil, -1, err
+	}
+
+	return earnings, allocations, count, nil
+}
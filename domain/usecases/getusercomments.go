@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetUserComments struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetUserCommentsUseCase(validator common.Validator, database gateways.Database) *GetUserComments {
+	return &GetUserComments{
+		validator,
+		database,
+	}
+}
+
+type GetUserCommentsInput struct {
+	Address string                `validate:"eth_addr"`
+	Sort    entities.ActivitySort `validate:"oneof=new top"`
+	Cursor  *entities.ActivityCursor
+	Limit   int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
+}
+
+// Deleted comments are not part of the activity of a user.
+// The returned cursor is nil when there are no more comments.
+func (u *GetUserComments) Execute(ctx context.Context, input GetUserCommentsInput) ([]entities.Comment, *entities.ActivityCursor, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, nil, err
+	}
+
+	comments, err := u.database.GetCommentsByAddress(ctx, gateways.ActivitySpec{
+		Address: input.Address,
+		Sort:    input.Sort,
+		Cursor:  input.Cursor,
+		Limit:   input.Limit + 1,
+		Viewer:  input.Viewer,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cursor *entities.ActivityCursor
+	if int64(len(comments)) > input.Limit {
+		comments = comments[:input.Limit]
+		last := comments[len(comments)-1]
+		c := entities.NewActivityCursor(entities.ActivityCursorParams{
+			Id:        last.Id(),
+			Votes:     last.Votes(),
+			CreatedAt: last.CreatedAt(),
+		})
+		cursor = &c
+	}
+
+	if err := setCommentVotes(ctx, u.database, input.Viewer, comments); err != nil {
+		return nil, nil, err
+	}
+
+	return comments, cursor, nil
+}
@@ -0,0 +1,68 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetUserThreads struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetUserThreadsUseCase(validator common.Validator, database gateways.Database) *GetUserThreads {
+	return &GetUserThreads{
+		validator,
+		database,
+	}
+}
+
+type GetUserThreadsInput struct {
+	Address string                `validate:"eth_addr"`
+	Sort    entities.ActivitySort `validate:"oneof=new top"`
+	Cursor  *entities.ActivityCursor
+	Limit   int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
+}
+
+// Deleted threads are not part of the activity of a user.
+// The returned cursor is nil when there are no more threads.
+func (u *GetUserThreads) Execute(ctx context.Context, input GetUserThreadsInput) ([]entities.Thread, *entities.ActivityCursor, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, nil, err
+	}
+
+	threads, err := u.database.GetThreadsByAddress(ctx, gateways.ActivitySpec{
+		Address: input.Address,
+		Sort:    input.Sort,
+		Cursor:  input.Cursor,
+		Limit:   input.Limit + 1,
+		Viewer:  input.Viewer,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var cursor *entities.ActivityCursor
+	if int64(len(threads)) > input.Limit {
+		threads = threads[:input.Limit]
+		last := threads[len(threads)-1]
+		c := entities.NewActivityCursor(entities.ActivityCursorParams{
+			Id:        last.Id(),
+			Votes:     last.Votes(),
+			CreatedAt: last.CreatedAt(),
+		})
+		cursor = &c
+	}
+
+	if err := setThreadVotes(ctx, u.database, input.Viewer, threads); err != nil {
+		return nil, nil, err
+	}
+
+	return threads, cursor, nil
+}
@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetWebhookDeliveries struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGetWebhookDeliveriesUseCase(validator common.Validator, database gateways.Database) *GetWebhookDeliveries {
+	return &GetWebhookDeliveries{
+		validator,
+		database,
+	}
+}
+
+type GetWebhookDeliveriesInput struct {
+	WebhookId int64 `validate:"gt=0"`
+	Offset    int64 `validate:"gte=0"`
+	Limit     int64 `validate:"gt=0,lte=100"`
+}
+
+func (u *GetWebhookDeliveries) Execute(ctx context.Context, input GetWebhookDeliveriesInput) ([]entities.WebhookDelivery, int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, -1, err
+	}
+
+	return u.database.GetWebhookDeliveries(ctx, input.WebhookId, input.Offset, input.Limit)
+}
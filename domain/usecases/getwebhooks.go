@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GetWebhooks struct {
+	database gateways.Database
+}
+
+func NewGetWebhooksUseCase(database gateways.Database) *GetWebhooks {
+	return &GetWebhooks{
+		database,
+	}
+}
+
+// disabled webhooks are included so they can be re-enabled
+func (u *GetWebhooks) Execute(ctx context.Context) ([]entities.Webhook, error) {
+	return u.database.GetWebhooks(ctx)
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type GrantRole struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewGrantRoleUseCase(validator common.Validator, database gateways.Database) *GrantRole {
+	return &GrantRole{
+		validator,
+		database,
+	}
+}
+
+type GrantRoleInput struct {
+	// addresses are checksummed so they match the addresses users sign in with
+	Address string            `validate:"eth_addr_checksum"`
+	Role    entities.RoleType `validate:"oneof=moderator admin"`
+	// nil for a global role
+	BoardSlug      *string `validate:"omitempty,min=1,max=32"`
+	GranterAddress string  `validate:"eth_addr"`
+}
+
+func (u *GrantRole) Execute(ctx context.Context, input GrantRoleInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	boardId, err := getRoleBoardId(ctx, u.database, input.Role, input.BoardSlug)
+
+	if err != nil {
+		return err
+	}
+
+	log := entities.NewModerationLog(entities.ModerationLogParams{
+		Action:     entities.GrantRoleModerationAction,
+		Actor:      input.GranterAddress,
+		TargetType: entities.UserModerationTarget,
+		TargetId:   input.Address,
+		After:      roleSnapshot(input.Role, boardId),
+	})
+
+	return u.database.GrantRole(ctx, input.Address, input.Role, boardId, input.GranterAddress, &log)
+}
+
+// admins manage every board so only moderators can be scoped to a board
+func getRoleBoardId(ctx context.Context, database gateways.Database, role entities.RoleType, boardSlug *string) (*int64, error) {
+	if boardSlug == nil {
+		return nil, nil
+	}
+
+	if role == entities.AdminRole {
+		return nil, errors.New("admin roles cannot be scoped to a board")
+	}
+
+	board, err := database.GetBoardBySlug(ctx, *boardSlug)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get board: %w", err)
+	}
+
+	id := board.Id()
+	return &id, nil
+}
@@ -15,15 +15,18 @@ const ZeroAddress = "0x0000000000000000000000000000000000000000"
 type IndexReputation struct {
 	logger   common.Logger
 	database gateways.Database
+	stream   gateways.Stream
 }
 
 func NewIndexReputationUseCase(
 	logger common.Logger,
 	database gateways.Database,
+	stream gateways.Stream,
 ) *IndexReputation {
 	return &IndexReputation{
 		logger,
 		database,
+		stream,
 	}
 }
 
@@ -31,6 +34,7 @@ func NewIndexReputationUseCase(
 // Insert new transfers.
 // Track dirty addresses and set new reputation values.
 // We must zero all addresses first, as an address could have a negative transfer record but not positive and vise versa, throwing off the math.
+// The updated addresses are announced afterwards, failing to do so does not fail the indexing.
 func (u *IndexReputation) Execute(ctx context.Context, from *big.Int, to *big.Int, transfers []entities.Transfer) error {
 
 	err := u.database.InsertTransferEvents(ctx, from, to, transfers)
@@ -61,5 +65,11 @@ func (u *IndexReputation) Execute(ctx context.Context, from *big.Int, to *big.In
 		return fmt.Errorf("failed to update reputation: %w", err)
 	}
 
+	if len(addresses) > 0 {
+		if err := u.stream.PublishReputationUpdated(ctx, addresses); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error publishing %v reputation updates", len(addresses))
+		}
+	}
+
 	return nil
 }
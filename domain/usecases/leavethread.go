@@ -0,0 +1,59 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type LeaveThread struct {
+	logger    common.Logger
+	validator common.Validator
+	cache     gateways.Cache
+	stream    gateways.Stream
+}
+
+func NewLeaveThreadUseCase(logger common.Logger, validator common.Validator, cache gateways.Cache, stream gateways.Stream) *LeaveThread {
+	return &LeaveThread{
+		logger,
+		validator,
+		cache,
+		stream,
+	}
+}
+
+type LeaveThreadInput struct {
+	ThreadId int64  `validate:"gt=0"`
+	Address  string `validate:"eth_addr"`
+	// the address is still viewing the thread until its last connection leaves
+	Connection string `validate:"min=1,max=64"`
+}
+
+// Viewers that disconnect without leaving are dropped once their presence expires
+func (u *LeaveThread) Execute(ctx context.Context, input LeaveThreadInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	removed, err := u.cache.RemovePresence(ctx, presenceKey(input.ThreadId), input.Address, input.Connection)
+
+	if err != nil || !removed {
+		return err
+	}
+
+	viewers, err := u.cache.CountPresence(ctx, presenceKey(input.ThreadId))
+
+	if err != nil {
+		return err
+	}
+
+	publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+		Type:     entities.PresenceUpdatedEvent,
+		ThreadId: input.ThreadId,
+		Viewers:  &viewers,
+	})
+
+	return nil
+}
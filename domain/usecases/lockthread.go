@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type LockThread struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewLockThreadUseCase(validator common.Validator, database gateways.Database) *LockThread {
+	return &LockThread{
+		validator,
+		database,
+	}
+}
+
+type LockThreadInput struct {
+	ThreadId         int64  `validate:"gt=0"`
+	ModeratorAddress string `validate:"eth_addr"`
+	ModeratorRoles   []entities.Role
+	// false unlocks the thread
+	Locked bool
+	Reason string `validate:"max=1000"`
+}
+
+func (u *LockThread) Execute(ctx context.Context, input LockThreadInput) (entities.Thread, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Thread{}, err
+	}
+
+	thread, err := getModeratedThread(ctx, u.database, input.ThreadId, input.ModeratorRoles)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	action := entities.LockModerationAction
+	if !input.Locked {
+		action = entities.UnlockModerationAction
+	}
+
+	log := threadStateLog(thread, action, input.ModeratorAddress, input.Reason, entities.Snapshot{"isLocked": input.Locked})
+
+	if err := u.database.SetThreadLocked(ctx, input.ThreadId, input.Locked, log); err != nil {
+		return entities.Thread{}, err
+	}
+
+	return u.database.GetThreadById(ctx, input.ThreadId)
+}
+
+// Only moderators of the board of the thread can change its state
+func getModeratedThread(ctx context.Context, database gateways.Database, threadId int64, roles []entities.Role) (entities.Thread, error) {
+	thread, err := database.GetThreadById(ctx, threadId)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	boardId := thread.BoardId()
+	if !entities.HasRole(roles, entities.ModeratorRole, &boardId) {
+		return entities.Thread{}, fmt.Errorf("user does not moderate the board of the thread: %w", common.ErrForbidden)
+	}
+
+	return thread, nil
+}
+
+// Logs the state change of the thread with the changed fields of its snapshot
+func threadStateLog(thread entities.Thread, action entities.ModerationAction, actor string, reason string, changes entities.Snapshot) entities.ModerationLog {
+	before := threadSnapshot(thread)
+	return entities.NewModerationLog(entities.ModerationLogParams{
+		Action:     action,
+		Actor:      actor,
+		TargetType: entities.ThreadModerationTarget,
+		TargetId:   fmt.Sprint(thread.Id()),
+		Reason:     reason,
+		Before:     before,
+		After:      withChanges(before, changes),
+	})
+}
@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"github.com/daochanio/backend/domain/entities"
+)
+
+func threadSnapshot(thread entities.Thread) entities.Snapshot {
+	user := thread.User()
+	return entities.Snapshot{
+		"id":          thread.Id(),
+		"boardId":     thread.BoardId(),
+		"address":     user.Address(),
+		"title":       thread.Title(),
+		"content":     thread.Content(),
+		"votes":       thread.Votes(),
+		"isDeleted":   thread.IsDeleted(),
+		"isHidden":    thread.IsHidden(),
+		"isLocked":    thread.IsLocked(),
+		"pinnedUntil": thread.PinnedUntil(),
+		"archivedAt":  thread.ArchivedAt(),
+	}
+}
+
+func commentSnapshot(comment entities.Comment) entities.Snapshot {
+	user := comment.User()
+	return entities.Snapshot{
+		"id":        comment.Id(),
+		"threadId":  comment.ThreadId(),
+		"address":   user.Address(),
+		"content":   comment.Content(),
+		"votes":     comment.Votes(),
+		"isDeleted": comment.IsDeleted(),
+		"isHidden":  comment.IsHidden(),
+	}
+}
+
+// nil if there is no ban
+func banSnapshot(ban *entities.Ban) entities.Snapshot {
+	if ban == nil {
+		return nil
+	}
+	return entities.Snapshot{
+		"type":      ban.Type(),
+		"reason":    ban.Reason(),
+		"expiresAt": ban.ExpiresAt(),
+		"issuedBy":  ban.IssuedBy(),
+	}
+}
+
+func roleSnapshot(role entities.RoleType, boardId *int64) entities.Snapshot {
+	return entities.Snapshot{
+		"role":    role,
+		"boardId": boardId,
+	}
+}
+
+// copies the snapshot with the changed fields overwritten
+func withChanges(snapshot entities.Snapshot, changes entities.Snapshot) entities.Snapshot {
+	changed := entities.Snapshot{}
+	for key, value := range snapshot {
+		changed[key] = value
+	}
+	for key, value := range changes {
+		changed[key] = value
+	}
+	return changed
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+// The votes of the requesting user are fetched in a single query for the whole page.
+// Anonymous requests are left without votes.
+func setThreadVotes(ctx context.Context, database gateways.Database, viewer *string, threads []entities.Thread) error {
+	if viewer == nil || len(threads) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(threads))
+	for i := range threads {
+		ids[i] = threads[i].Id()
+	}
+
+	votes, err := database.GetVotesByAddress(ctx, *viewer, ids, entities.ThreadVote)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range threads {
+		if vote, ok := votes[threads[i].Id()]; ok {
+			threads[i].SetMyVote(&vote)
+		}
+	}
+
+	return nil
+}
+
+func setCommentVotes(ctx context.Context, database gateways.Database, viewer *string, comments []entities.Comment) error {
+	if viewer == nil || len(comments) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(comments))
+	for i := range comments {
+		ids[i] = comments[i].Id()
+	}
+
+	votes, err := database.GetVotesByAddress(ctx, *viewer, ids, entities.CommentVote)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range comments {
+		if vote, ok := votes[comments[i].Id()]; ok {
+			comments[i].SetMyVote(&vote)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+var mentionRegex = regexp.MustCompile(`(?i)(?:^|[^\w.])@((?:[a-z0-9-]+\.)+eth)\b`)
+
+// The recipients of the notifications of a comment and the most relevant reason each of them is notified for:
+// the author of the comment replied to, the author of the thread while they follow it, any users mentioned by ens name
+// and everyone following the thread. Authors are never notified of their own comments.
+// In app and push notifications are both resolved from these recipients so they never disagree.
+func getNotificationRecipients(ctx context.Context, database gateways.Database, comment entities.Comment) (map[string]entities.NotificationType, error) {
+	thread, err := database.GetThreadById(ctx, comment.ThreadId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	// less relevant reasons are assigned first so more relevant ones override them
+	recipients := map[string]entities.NotificationType{}
+
+	followers, err := database.GetThreadSubscribers(ctx, comment.ThreadId())
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range followers {
+		recipients[address] = entities.SubscriptionNotification
+	}
+
+	mentioned, err := database.GetAddressesByEnsNames(ctx, parseMentions(comment.Content()))
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range mentioned {
+		recipients[address] = entities.MentionNotification
+	}
+
+	// authors follow their threads when they create them, so they stop being notified once they unsubscribe
+	threadAuthor := thread.User()
+	for _, address := range followers {
+		if address == threadAuthor.Address() {
+			recipients[address] = entities.ThreadReplyNotification
+		}
+	}
+
+	if repliedToComment := comment.RepliedToComment(); repliedToComment != nil {
+		repliedTo, err := database.GetCommentById(ctx, repliedToComment.Id())
+
+		if err != nil {
+			return nil, err
+		}
+
+		repliedToAuthor := repliedTo.User()
+		recipients[repliedToAuthor.Address()] = entities.ReplyNotification
+	}
+
+	author := comment.User()
+	delete(recipients, author.Address())
+
+	return recipients, nil
+}
+
+// the distinct lower cased ens names mentioned as @name.eth
+func parseMentions(content string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, match := range mentionRegex.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(match[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+func TestGetNotificationRecipients(t *testing.T) {
+	const (
+		dave  = "0x4444444444444444444444444444444444444444"
+		erin  = "0x5555555555555555555555555555555555555555"
+		frank = "0x6666666666666666666666666666666666666666"
+	)
+
+	user := func(address string) entities.User {
+		return entities.NewUser(entities.UserParams{Address: address, Reputation: big.NewInt(0)})
+	}
+	repliedTo := entities.NewComment(entities.CommentParams{Id: 1, ThreadId: 1, User: user(bob)})
+	comment := entities.NewComment(entities.CommentParams{
+		Id:               2,
+		ThreadId:         1,
+		User:             user(voter),
+		Content:          "@Carol.eth @dave.eth @self.eth",
+		RepliedToComment: &repliedTo,
+	})
+
+	database := &testDatabase{
+		threads:  map[int64]entities.Thread{1: entities.NewThread(entities.ThreadParams{Id: 1, User: user(alice)})},
+		comments: map[int64]entities.Comment{1: repliedTo, 2: comment},
+		// every recipient follows the thread so their more relevant reason has to win
+		followers: map[int64][]string{1: {alice, bob, carol, dave, erin, voter}},
+		ensNames:  map[string]string{"carol.eth": carol, "dave.eth": dave, "self.eth": voter, "frank.eth": frank},
+	}
+
+	recipients, err := getNotificationRecipients(context.Background(), database, comment)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]entities.NotificationType{
+		alice: entities.ThreadReplyNotification,
+		bob:   entities.ReplyNotification,
+		carol: entities.MentionNotification,
+		dave:  entities.MentionNotification,
+		erin:  entities.SubscriptionNotification,
+	}
+
+	if len(recipients) != len(expected) {
+		t.Errorf("expected %v recipients but got %v", len(expected), recipients)
+	}
+
+	for address, notificationType := range expected {
+		if recipients[address] != notificationType {
+			t.Errorf("expected %v to be notified for %v but got %v", address, notificationType, recipients[address])
+		}
+	}
+}
+
+func TestGetNotificationRecipientsUnsubscribedAuthor(t *testing.T) {
+	user := func(address string) entities.User {
+		return entities.NewUser(entities.UserParams{Address: address, Reputation: big.NewInt(0)})
+	}
+	thread := entities.NewThread(entities.ThreadParams{Id: 1, User: user(alice)})
+
+	tests := []struct {
+		name     string
+		content  string
+		expected map[string]entities.NotificationType
+	}{
+		{
+			name:     "not notified",
+			content:  "a comment",
+			expected: map[string]entities.NotificationType{bob: entities.SubscriptionNotification},
+		},
+		{
+			name:     "still notified when mentioned",
+			content:  "@alice.eth",
+			expected: map[string]entities.NotificationType{alice: entities.MentionNotification, bob: entities.SubscriptionNotification},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			comment := entities.NewComment(entities.CommentParams{Id: 1, ThreadId: 1, User: user(voter), Content: test.content})
+			database := &testDatabase{
+				threads:   map[int64]entities.Thread{1: thread},
+				followers: map[int64][]string{1: {bob}},
+				ensNames:  map[string]string{"alice.eth": alice},
+			}
+
+			recipients, err := getNotificationRecipients(context.Background(), database, comment)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(recipients) != len(test.expected) {
+				t.Errorf("expected %v recipients but got %v", len(test.expected), recipients)
+			}
+
+			for address, notificationType := range test.expected {
+				if recipients[address] != notificationType {
+					t.Errorf("expected %v to be notified for %v but got %v", address, notificationType, recipients[address])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type PinThread struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewPinThreadUseCase(validator common.Validator, database gateways.Database) *PinThread {
+	return &PinThread{
+		validator,
+		database,
+	}
+}
+
+type PinThreadInput struct {
+	ThreadId         int64  `validate:"gt=0"`
+	ModeratorAddress string `validate:"eth_addr"`
+	ModeratorRoles   []entities.Role
+	// nil unpins the thread
+	Until  *time.Time
+	Reason string `validate:"max=1000"`
+}
+
+func (u *PinThread) Execute(ctx context.Context, input PinThreadInput) (entities.Thread, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Thread{}, err
+	}
+
+	if input.Until != nil && !input.Until.After(time.Now()) {
+		return entities.Thread{}, fmt.Errorf("pin expiry must be in the future: %w", common.ErrValidation)
+	}
+
+	thread, err := getModeratedThread(ctx, u.database, input.ThreadId, input.ModeratorRoles)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	action := entities.PinModerationAction
+	if input.Until == nil {
+		action = entities.UnpinModerationAction
+	}
+
+	log := threadStateLog(thread, action, input.ModeratorAddress, input.Reason, entities.Snapshot{"pinnedUntil": input.Until})
+
+	if err := u.database.SetThreadPinned(ctx, input.ThreadId, input.Until, log); err != nil {
+		return entities.Thread{}, err
+	}
+
+	return u.database.GetThreadById(ctx, input.ThreadId)
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type PreviewDistribution struct {
+	logger   common.Logger
+	database gateways.Database
+}
+
+func NewPreviewDistribution(logger common.Logger, database gateways.Database) *PreviewDistribution {
+	return &PreviewDistribution{
+		logger,
+		database,
+	}
+}
+
+type PreviewDistributionInput struct {
+	Formula entities.DistributionFormula
+	// the interval of the rounds, used to find where a round running now would cut off the votes
+	Interval time.Duration
+	// nil to preview a round running now over the votes not consumed by a distribution yet
+	Range *gateways.VoteDecisionsSpec
+}
+
+// Runs the same computation as a distribution round without writing anything.
+// Returns the allocations, every vote looked at along with the reason it was discarded if it was, and the merkle root of the allocations.
+func (u *PreviewDistribution) Execute(ctx context.Context, input PreviewDistributionInput) ([]entities.Allocation, []entities.DistributionVote, string, error) {
+	var votes []entities.DistributionVote
+	var err error
+
+	if input.Range == nil {
+		votes, err = u.database.GetUndistributedVotes(ctx, distributionRound(time.Now(), input.Interval))
+	} else {
+		votes, err = u.database.GetVoteDecisions(ctx, *input.Range)
+	}
+
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to get votes: %w", err)
+	}
+
+	allocations := allocate(votes, input.Formula)
+
+	tree, err := buildMerkleTree(allocations)
+
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to build merkle tree: %w", err)
+	}
+
+	root := tree.Root()
+
+	return allocations, votes, root.Hex(), nil
+}
@@ -1,26 +1,149 @@
 package usecases
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
 
 type ProcessVote struct {
+	logger   common.Logger
+	database gateways.Database
 }
 
-func NewProcessVote() *ProcessVote {
-	return &ProcessVote{}
+func NewProcessVote(logger common.Logger, database gateways.Database) *ProcessVote {
+	return &ProcessVote{
+		logger,
+		database,
+	}
 }
 
 type ProcessVoteInput struct {
+	Vote entities.Vote
+	// voters with less reputation have their votes discarded
+	MinReputation *big.Int
+	// votes on content older than this when the vote was cast are discarded, zero for no limit
+	MaxContentAge time.Duration
 }
 
 // Make decisions on whether the vote should be counted towards a distribution or not and create a vote record for it.
-// We always record a record regardless of whether it is counted or not with some kind of accepted/discarded flag
-// hydrate the vote along with its thread/comment
-// accept or reject the vote based on criteria
-// - address must have a certain amount of reputation to have their votes counted
-// - if the vote is on a comment/thread that is older than a certain cuttoff, it is rejected
-// - if the vote is from the same address as the comment/thread author, it is rejected
-// - if the vote is from a blacklisted address, it is rejected
-// - if the vote is on a deleted thread/comment, it is rejected
-func (u *ProcessVote) Execute(ctx context.Context) error {
+// We always record a record regardless of whether it is counted or not with an accepted/discarded flag and the reason it was discarded.
+// The vote is hydrated along with its thread/comment and rejected if
+// - the thread/comment is deleted
+// - the vote is from the same address as the comment/thread author
+// - the vote is from a banned address
+// - the address has less reputation than the minimum
+// - the comment/thread was older than the cutoff when the vote was cast
+func (u *ProcessVote) Execute(ctx context.Context, input ProcessVoteInput) error {
+	vote := input.Vote
+	votedAt := time.UnixMilli(vote.UpdatedAt()).UTC()
+
+	voter, err := u.database.GetUserByAddress(ctx, vote.Address())
+
+	if err != nil {
+		return fmt.Errorf("failed to get voter %v: %w", vote.Address(), err)
+	}
+
+	author, createdAt, deleted, err := u.getContent(ctx, vote)
+
+	if err != nil {
+		return fmt.Errorf("failed to get %v %v: %w", vote.Type(), vote.Id(), err)
+	}
+
+	ban, err := u.database.GetActiveBan(ctx, vote.Address())
+
+	if err != nil {
+		return fmt.Errorf("failed to get ban for voter %v: %w", vote.Address(), err)
+	}
+
+	var reason *entities.VoteDecisionReason
+	discard := func(value entities.VoteDecisionReason) {
+		if reason == nil {
+			reason = &value
+		}
+	}
+
+	if author == nil || deleted {
+		discard(entities.DeletedContent)
+	}
+	if author != nil && *author == vote.Address() {
+		discard(entities.SelfVote)
+	}
+	if ban != nil {
+		discard(entities.BannedVoter)
+	}
+	if input.MinReputation != nil && voter.Reputation().Cmp(input.MinReputation) < 0 {
+		discard(entities.InsufficientReputation)
+	}
+	if author != nil && input.MaxContentAge > 0 && votedAt.Sub(createdAt) > input.MaxContentAge {
+		discard(entities.ContentTooOld)
+	}
+
+	decision := entities.NewVoteDecision(entities.VoteDecisionParams{
+		Type:       vote.Type(),
+		TargetId:   vote.Id(),
+		Voter:      vote.Address(),
+		Author:     author,
+		Value:      vote.Value(),
+		Reputation: voter.Reputation(),
+		Reason:     reason,
+		VotedAt:    votedAt,
+	})
+
+	if err := u.database.CreateVoteDecision(ctx, decision); err != nil {
+		return fmt.Errorf("failed to create vote decision: %w", err)
+	}
+
+	if reason != nil {
+		u.logger.Info(ctx).Msgf("discarded %v vote on %v %v from %v: %v", vote.Value(), vote.Type(), vote.Id(), vote.Address(), *reason)
+	}
+
 	return nil
 }
+
+// The author is nil if the content does not exist.
+// Deleted threads are only returned by their own query while deleted comments are returned as is.
+func (u *ProcessVote) getContent(ctx context.Context, vote entities.Vote) (*string, time.Time, bool, error) {
+	switch vote.Type() {
+	case entities.ThreadVote:
+		thread, err := u.database.GetThreadById(ctx, vote.Id())
+
+		if errors.Is(err, common.ErrNotFound) {
+			thread, err = u.database.GetDeletedThreadById(ctx, vote.Id())
+		}
+
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, time.Time{}, true, nil
+		}
+
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+
+		user := thread.User()
+		address := user.Address()
+		return &address, thread.CreatedAt(), thread.IsDeleted(), nil
+	case entities.CommentVote:
+		comment, err := u.database.GetCommentById(ctx, vote.Id())
+
+		if errors.Is(err, common.ErrNotFound) {
+			return nil, time.Time{}, true, nil
+		}
+
+		if err != nil {
+			return nil, time.Time{}, false, err
+		}
+
+		user := comment.User()
+		address := user.Address()
+		return &address, comment.CreatedAt(), comment.IsDeleted(), nil
+	default:
+		return nil, time.Time{}, false, fmt.Errorf("invalid vote type %v", vote.Type())
+	}
+}
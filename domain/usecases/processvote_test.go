@@ -0,0 +1,301 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+)
+
+func TestProcessVote(t *testing.T) {
+	ctx := context.Background()
+	logger := common.NewLogger()
+	logger.Start(ctx, common.LoggerConfig{Env: "test"})
+
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	maxContentAge := time.Hour * 24
+	minReputation := big.NewInt(10)
+
+	author := func(address string) entities.User {
+		return entities.NewUser(entities.UserParams{Address: address, Reputation: big.NewInt(0)})
+	}
+	thread := func(address string, deleted bool) entities.Thread {
+		return entities.NewThread(entities.ThreadParams{Id: 1, User: author(address), IsDeleted: deleted, CreatedAt: createdAt})
+	}
+	comment := func(address string, deleted bool) entities.Comment {
+		return entities.NewComment(entities.CommentParams{Id: 1, ThreadId: 1, User: author(address), IsDeleted: deleted, CreatedAt: createdAt})
+	}
+	hardBan := entities.NewBan(entities.BanParams{Address: voter, Type: entities.HardBan})
+	shadowBan := entities.NewBan(entities.BanParams{Address: voter, Type: entities.ShadowBan})
+
+	tests := []struct {
+		name          string
+		voteType      entities.VoteType
+		thread        *entities.Thread
+		comment       *entities.Comment
+		ban           *entities.Ban
+		reputation    int64
+		minReputation *big.Int
+		// how long after the content was created the vote was cast
+		age time.Duration
+		// votes are processed without a max content age
+		noMaxContentAge bool
+		// nil if the vote is accepted
+		reason *entities.VoteDecisionReason
+		// nil if the content does not exist
+		author *string
+	}{
+		{
+			name:          "accepted thread vote",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, false)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			author:        ref(alice),
+		},
+		{
+			name:          "accepted comment vote",
+			voteType:      entities.CommentVote,
+			comment:       ref(comment(alice, false)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			author:        ref(alice),
+		},
+		{
+			name:          "deleted thread",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, true)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.DeletedContent),
+			author:        ref(alice),
+		},
+		{
+			name:          "missing thread",
+			voteType:      entities.ThreadVote,
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.DeletedContent),
+		},
+		{
+			name:          "deleted comment",
+			voteType:      entities.CommentVote,
+			comment:       ref(comment(alice, true)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.DeletedContent),
+			author:        ref(alice),
+		},
+		{
+			name:          "missing comment",
+			voteType:      entities.CommentVote,
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.DeletedContent),
+		},
+		{
+			name:          "self vote",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(voter, false)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.SelfVote),
+			author:        ref(voter),
+		},
+		{
+			name:          "hard banned voter",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, false)),
+			ban:           &hardBan,
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.BannedVoter),
+			author:        ref(alice),
+		},
+		{
+			name:          "shadow banned voter",
+			voteType:      entities.CommentVote,
+			comment:       ref(comment(alice, false)),
+			ban:           &shadowBan,
+			reputation:    100,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.BannedVoter),
+			author:        ref(alice),
+		},
+		{
+			name:          "reputation at the minimum",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, false)),
+			reputation:    10,
+			minReputation: minReputation,
+			age:           time.Hour,
+			author:        ref(alice),
+		},
+		{
+			name:          "reputation under the minimum",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, false)),
+			reputation:    9,
+			minReputation: minReputation,
+			age:           time.Hour,
+			reason:        reason(entities.InsufficientReputation),
+			author:        ref(alice),
+		},
+		{
+			name:       "no minimum reputation",
+			voteType:   entities.ThreadVote,
+			thread:     ref(thread(alice, false)),
+			reputation: 0,
+			age:        time.Hour,
+			author:     ref(alice),
+		},
+		{
+			name:          "content at the max age",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(alice, false)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           maxContentAge,
+			author:        ref(alice),
+		},
+		{
+			name:          "content over the max age",
+			voteType:      entities.CommentVote,
+			comment:       ref(comment(alice, false)),
+			reputation:    100,
+			minReputation: minReputation,
+			age:           maxContentAge + time.Millisecond,
+			reason:        reason(entities.ContentTooOld),
+			author:        ref(alice),
+		},
+		{
+			name:            "no max content age",
+			voteType:        entities.ThreadVote,
+			thread:          ref(thread(alice, false)),
+			reputation:      100,
+			minReputation:   minReputation,
+			age:             maxContentAge * 365,
+			noMaxContentAge: true,
+			author:          ref(alice),
+		},
+		{
+			name:          "the first rule broken is the reason",
+			voteType:      entities.ThreadVote,
+			thread:        ref(thread(voter, false)),
+			ban:           &hardBan,
+			reputation:    0,
+			minReputation: minReputation,
+			age:           maxContentAge * 2,
+			reason:        reason(entities.SelfVote),
+			author:        ref(voter),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			database := &testDatabase{
+				users:    map[string]entities.User{voter: entities.NewUser(entities.UserParams{Address: voter, Reputation: big.NewInt(test.reputation)})},
+				threads:  map[int64]entities.Thread{},
+				comments: map[int64]entities.Comment{},
+				bans:     map[string]entities.Ban{},
+			}
+			if test.ban != nil {
+				database.bans[voter] = *test.ban
+			}
+			if test.thread != nil {
+				database.threads[1] = *test.thread
+			}
+			if test.comment != nil {
+				database.comments[1] = *test.comment
+			}
+
+			input := ProcessVoteInput{
+				MinReputation: test.minReputation,
+				MaxContentAge: maxContentAge,
+			}
+			if test.noMaxContentAge {
+				input.MaxContentAge = 0
+			}
+
+			votedAt := createdAt.Add(test.age)
+			input.Vote = entities.NewVote(1, voter, entities.Upvote, test.voteType, votedAt.UnixMilli())
+			err := NewProcessVote(logger, database).Execute(ctx, input)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(database.decisions) != 1 {
+				t.Fatalf("expected 1 decision but got %v", len(database.decisions))
+			}
+
+			decision := database.decisions[0]
+
+			if (decision.Reason() == nil) != (test.reason == nil) || (test.reason != nil && *decision.Reason() != *test.reason) {
+				t.Errorf("expected reason %v but got %v", deref(test.reason), deref(decision.Reason()))
+			}
+
+			if decision.IsAccepted() != (test.reason == nil) {
+				t.Errorf("expected accepted %v but got %v", test.reason == nil, decision.IsAccepted())
+			}
+
+			if deref(decision.Author()) != deref(test.author) {
+				t.Errorf("expected author %v but got %v", deref(test.author), deref(decision.Author()))
+			}
+
+			if decision.Reputation().Cmp(big.NewInt(test.reputation)) != 0 {
+				t.Errorf("expected reputation %v but got %v", test.reputation, decision.Reputation())
+			}
+
+			if !decision.VotedAt().Equal(votedAt) {
+				t.Errorf("expected voted at %v but got %v", votedAt, decision.VotedAt())
+			}
+		})
+	}
+}
+
+func TestProcessVoteInvalidType(t *testing.T) {
+	ctx := context.Background()
+	logger := common.NewLogger()
+	logger.Start(ctx, common.LoggerConfig{Env: "test"})
+
+	database := &testDatabase{
+		users: map[string]entities.User{voter: entities.NewUser(entities.UserParams{Address: voter, Reputation: big.NewInt(100)})},
+	}
+
+	err := NewProcessVote(logger, database).Execute(ctx, ProcessVoteInput{
+		Vote: entities.NewVote(1, voter, entities.Upvote, entities.VoteType("board"), time.Now().UnixMilli()),
+	})
+
+	if err == nil || errors.Is(err, common.ErrNotFound) {
+		t.Errorf("expected an invalid vote type error but got %v", err)
+	}
+
+	if len(database.decisions) != 0 {
+		t.Errorf("expected no decision for an invalid vote type")
+	}
+}
+
+func ref[T any](value T) *T {
+	return &value
+}
+
+func deref[T any](value *T) any {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
@@ -13,13 +13,15 @@ type CreateVote struct {
 	logger    common.Logger
 	validator common.Validator
 	stream    gateways.Stream
+	database  gateways.Database
 }
 
-func NewCreateVoteUseCase(logger common.Logger, validator common.Validator, stream gateways.Stream) *CreateVote {
+func NewCreateVoteUseCase(logger common.Logger, validator common.Validator, stream gateways.Stream, database gateways.Database) *CreateVote {
 	return &CreateVote{
 		logger,
 		validator,
 		stream,
+		database,
 	}
 }
 
@@ -35,6 +37,10 @@ func (u *CreateVote) Execute(ctx context.Context, input CreateVoteInput) error {
 		return err
 	}
 
+	if _, err := rejectHardBan(ctx, u.database, input.Address); err != nil {
+		return err
+	}
+
 	vote := entities.NewVote(input.Id, input.Address, input.Value, input.Type, time.Now().UnixMilli())
 
 	return u.stream.PublishVote(ctx, vote)
@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ReadNotifications struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewReadNotificationsUseCase(validator common.Validator, database gateways.Database) *ReadNotifications {
+	return &ReadNotifications{
+		validator,
+		database,
+	}
+}
+
+type ReadNotificationsInput struct {
+	Recipient string `validate:"eth_addr"`
+	// nil to mark every notification of the recipient as read
+	Id *int64 `validate:"omitempty,gt=0"`
+}
+
+// Returns the number of notifications that were marked as read.
+// A single notification that was already read still counts so clients can tell it apart from a missing one.
+func (u *ReadNotifications) Execute(ctx context.Context, input ReadNotificationsInput) (int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return -1, err
+	}
+
+	if input.Id == nil {
+		return u.database.ReadAllNotifications(ctx, input.Recipient)
+	}
+
+	if err := u.database.ReadNotification(ctx, *input.Id, input.Recipient); err != nil {
+		return -1, err
+	}
+
+	return 1, nil
+}
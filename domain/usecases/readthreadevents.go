@@ -0,0 +1,38 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ReadThreadEvents struct {
+	stream gateways.Stream
+}
+
+func NewReadThreadEventsUseCase(stream gateways.Stream) *ReadThreadEvents {
+	return &ReadThreadEvents{
+		stream,
+	}
+}
+
+type ReadThreadEventsInput struct {
+	// the stream id of the last event read, $ to only read events published from now on
+	After string
+	Block time.Duration
+}
+
+// Events of every thread are returned, it is up to the caller to route them to the clients watching each thread
+func (u *ReadThreadEvents) Execute(ctx context.Context, input ReadThreadEventsInput) ([]entities.ThreadEvent, error) {
+	return u.stream.ReadThreadEvents(ctx, input.After, input.Block)
+}
+
+// Events are best effort, failing to publish one should not fail the write that caused it
+func publishThreadEvent(ctx context.Context, logger common.Logger, stream gateways.Stream, params entities.ThreadEventParams) {
+	if err := stream.PublishThreadEvent(ctx, entities.NewThreadEvent(params)); err != nil {
+		logger.Error(ctx).Err(err).Msgf("error publishing %v event for thread %v", params.Type, params.ThreadId)
+	}
+}
@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type RegisterPushSubscription struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewRegisterPushSubscriptionUseCase(validator common.Validator, database gateways.Database) *RegisterPushSubscription {
+	return &RegisterPushSubscription{
+		validator,
+		database,
+	}
+}
+
+type RegisterPushSubscriptionInput struct {
+	Address  string `validate:"eth_addr"`
+	Endpoint string `validate:"url,startswith=https://,max=2048"`
+	P256dh   string `validate:"min=1,max=128"`
+	Auth     string `validate:"min=1,max=64"`
+}
+
+// The keys are checked up front so a malformed subscription can not fail every push sent to it.
+// The endpoint is requested by the server so it must be https and resolve to a public address.
+func (u *RegisterPushSubscription) Execute(ctx context.Context, input RegisterPushSubscriptionInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	if err := common.ValidatePublicURL(ctx, input.Endpoint); err != nil {
+		return err
+	}
+
+	// an uncompressed P-256 point
+	if key, err := decodePushKey(input.P256dh); err != nil || len(key) != 65 || key[0] != 0x04 {
+		return fmt.Errorf("invalid p256dh key %w", common.ErrValidation)
+	}
+
+	if secret, err := decodePushKey(input.Auth); err != nil || len(secret) != 16 {
+		return fmt.Errorf("invalid auth secret %w", common.ErrValidation)
+	}
+
+	return u.database.CreatePushSubscription(ctx, entities.NewPushSubscription(entities.PushSubscriptionParams{
+		Endpoint: input.Endpoint,
+		Address:  input.Address,
+		P256dh:   input.P256dh,
+		Auth:     input.Auth,
+	}))
+}
+
+// browsers encode keys as base64url, with or without padding
+func decodePushKey(key string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(key, "="))
+}
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ResolveReports struct {
+	logger    common.Logger
+	validator common.Validator
+	database  gateways.Database
+	stream    gateways.Stream
+}
+
+func NewResolveReportsUseCase(logger common.Logger, validator common.Validator, database gateways.Database, stream gateways.Stream) *ResolveReports {
+	return &ResolveReports{
+		logger,
+		validator,
+		database,
+		stream,
+	}
+}
+
+type ResolveReportsInput struct {
+	TargetType      entities.ReportTargetType `validate:"oneof=thread comment user"`
+	TargetId        string                    `validate:"min=1,max=42"`
+	Action          entities.ReportResolution `validate:"oneof=dismiss delete ban"`
+	ResolverAddress string                    `validate:"eth_addr"`
+	// only used when banning the author, a nil expiry bans forever
+	BanReason    string `validate:"max=1000"`
+	BanExpiresAt *time.Time
+}
+
+// Every open report of the target is resolved with the same action.
+// Dismissing unhides the target, deleting removes the thread or comment and banning hard bans its author.
+func (u *ResolveReports) Execute(ctx context.Context, input ResolveReportsInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	count, err := u.database.CountOpenReports(ctx, input.TargetType, input.TargetId)
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return fmt.Errorf("no open reports for %v %v: %w", input.TargetType, input.TargetId, common.ErrNotFound)
+	}
+
+	// users cannot be deleted, ban them instead
+	if input.Action == entities.DeleteReportResolution && input.TargetType == entities.UserReportTarget {
+		return fmt.Errorf("users cannot be deleted: %w", common.ErrValidation)
+	}
+
+	if input.TargetType != entities.UserReportTarget {
+		if _, err := parseReportTargetId(input.TargetId); err != nil {
+			return err
+		}
+	}
+
+	after := entities.Snapshot{"openReports": 0, "resolution": input.Action}
+
+	var ban *entities.Ban
+	if input.Action == entities.BanReportResolution {
+		address, err := getReportAuthor(ctx, u.database, input.TargetType, input.TargetId)
+
+		if err != nil {
+			return err
+		}
+
+		if input.BanExpiresAt != nil && input.BanExpiresAt.Before(time.Now()) {
+			return errors.New("ban expiry must be in the future")
+		}
+
+		authorBan := entities.NewBan(entities.BanParams{
+			Address:   address,
+			Type:      entities.HardBan,
+			Reason:    input.BanReason,
+			ExpiresAt: input.BanExpiresAt,
+			IssuedBy:  input.ResolverAddress,
+		})
+		ban = &authorBan
+
+		after["ban"] = banSnapshot(ban)
+	}
+
+	if err := u.database.ResolveReports(ctx, gateways.ResolveReportsSpec{
+		TargetType: input.TargetType,
+		TargetId:   input.TargetId,
+		Resolution: input.Action,
+		ResolvedBy: input.ResolverAddress,
+		Ban:        ban,
+		Log: entities.NewModerationLog(entities.ModerationLogParams{
+			Action:     entities.ResolveReportsModerationAction,
+			Actor:      input.ResolverAddress,
+			TargetType: entities.ModerationTargetType(input.TargetType),
+			TargetId:   input.TargetId,
+			Reason:     input.BanReason,
+			Before:     entities.Snapshot{"openReports": count},
+			After:      after,
+		}),
+	}); err != nil {
+		return err
+	}
+
+	u.logger.Info(ctx).Msgf("resolved %v reports for %v %v with %v", count, input.TargetType, input.TargetId, input.Action)
+
+	switch input.Action {
+	case entities.DismissReportResolution:
+		publishReportTargetEvent(ctx, u.logger, u.database, u.stream, input.TargetType, input.TargetId, entities.CommentUnhiddenEvent)
+	case entities.DeleteReportResolution:
+		publishReportTargetEvent(ctx, u.logger, u.database, u.stream, input.TargetType, input.TargetId, entities.CommentDeletedEvent)
+	}
+
+	return nil
+}
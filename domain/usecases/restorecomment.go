@@ -0,0 +1,82 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type RestoreComment struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewRestoreCommentUseCase(validator common.Validator, database gateways.Database) *RestoreComment {
+	return &RestoreComment{
+		validator,
+		database,
+	}
+}
+
+type RestoreCommentInput struct {
+	Id              int64  `validate:"gt=0"`
+	RestorerAddress string `validate:"eth_addr"`
+	RestorerRoles   []entities.Role
+	Reason          string `validate:"max=1000"`
+	// how long after deleting it the author can restore their comment
+	Window time.Duration `validate:"gt=0"`
+}
+
+func (u *RestoreComment) Execute(ctx context.Context, input RestoreCommentInput) (entities.Comment, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Comment{}, err
+	}
+
+	comment, err := u.database.GetCommentById(ctx, input.Id)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	if !comment.IsDeleted() {
+		return entities.Comment{}, fmt.Errorf("comment is not deleted: %w", common.ErrNotFound)
+	}
+
+	// authors can restore the comments they deleted themselves within the window and moderators of the board any comment
+	user := comment.User()
+	isAuthor := isAuthorRestore(user.Address(), comment.DeletedBy(), comment.DeletedAt(), input.RestorerAddress, input.Window)
+	if !isAuthor {
+		thread, err := u.database.GetThreadById(ctx, comment.ThreadId())
+
+		if err != nil {
+			return entities.Comment{}, err
+		}
+
+		boardId := thread.BoardId()
+		if !entities.HasRole(input.RestorerRoles, entities.ModeratorRole, &boardId) {
+			return entities.Comment{}, fmt.Errorf("comment cannot be restored by the user: %w", common.ErrForbidden)
+		}
+	}
+
+	// votes recomputed by the restore are not part of the logged state
+	var log *entities.ModerationLog
+	if !isAuthor {
+		before := commentSnapshot(comment)
+		moderation := entities.NewModerationLog(entities.ModerationLogParams{
+			Action:     entities.RestoreModerationAction,
+			Actor:      input.RestorerAddress,
+			TargetType: entities.CommentModerationTarget,
+			TargetId:   fmt.Sprint(input.Id),
+			Reason:     input.Reason,
+			Before:     before,
+			After:      withChanges(before, entities.Snapshot{"isDeleted": false}),
+		})
+		log = &moderation
+	}
+
+	return u.database.RestoreComment(ctx, input.Id, input.RestorerAddress, input.Reason, log)
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type RestoreThread struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewRestoreThreadUseCase(validator common.Validator, database gateways.Database) *RestoreThread {
+	return &RestoreThread{
+		validator,
+		database,
+	}
+}
+
+type RestoreThreadInput struct {
+	ThreadId        int64  `validate:"gt=0"`
+	RestorerAddress string `validate:"eth_addr"`
+	RestorerRoles   []entities.Role
+	Reason          string `validate:"max=1000"`
+	// how long after deleting it the author can restore their thread
+	Window time.Duration `validate:"gt=0"`
+}
+
+func (u *RestoreThread) Execute(ctx context.Context, input RestoreThreadInput) (entities.Thread, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return entities.Thread{}, err
+	}
+
+	thread, err := u.database.GetDeletedThreadById(ctx, input.ThreadId)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	// authors can restore the threads they deleted themselves within the window and moderators of the board any thread
+	user := thread.User()
+	boardId := thread.BoardId()
+	isAuthor := isAuthorRestore(user.Address(), thread.DeletedBy(), thread.DeletedAt(), input.RestorerAddress, input.Window)
+	if !isAuthor && !entities.HasRole(input.RestorerRoles, entities.ModeratorRole, &boardId) {
+		return entities.Thread{}, fmt.Errorf("thread cannot be restored by the user: %w", common.ErrForbidden)
+	}
+
+	// votes recomputed by the restore are not part of the logged state
+	var log *entities.ModerationLog
+	if !isAuthor {
+		before := threadSnapshot(thread)
+		moderation := entities.NewModerationLog(entities.ModerationLogParams{
+			Action:     entities.RestoreModerationAction,
+			Actor:      input.RestorerAddress,
+			TargetType: entities.ThreadModerationTarget,
+			TargetId:   fmt.Sprint(input.ThreadId),
+			Reason:     input.Reason,
+			Before:     before,
+			After:      withChanges(before, entities.Snapshot{"isDeleted": false}),
+		})
+		log = &moderation
+	}
+
+	return u.database.RestoreThread(ctx, input.ThreadId, input.RestorerAddress, input.Reason, log)
+}
+
+// Authors cannot undo deletes made by moderators
+func isAuthorRestore(author string, deletedBy *string, deletedAt *time.Time, restorer string, window time.Duration) bool {
+	if author != restorer || deletedBy == nil || *deletedBy != restorer || deletedAt == nil {
+		return false
+	}
+	return time.Since(*deletedAt) <= window
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type RevokeRole struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewRevokeRoleUseCase(validator common.Validator, database gateways.Database) *RevokeRole {
+	return &RevokeRole{
+		validator,
+		database,
+	}
+}
+
+type RevokeRoleInput struct {
+	// addresses are checksummed so they match the addresses roles are granted to
+	Address string            `validate:"eth_addr_checksum"`
+	Role    entities.RoleType `validate:"oneof=moderator admin"`
+	// nil for a global role
+	BoardSlug      *string `validate:"omitempty,min=1,max=32"`
+	RevokerAddress string  `validate:"eth_addr"`
+}
+
+func (u *RevokeRole) Execute(ctx context.Context, input RevokeRoleInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	boardId, err := getRoleBoardId(ctx, u.database, input.Role, input.BoardSlug)
+
+	if err != nil {
+		return err
+	}
+
+	log := entities.NewModerationLog(entities.ModerationLogParams{
+		Action:     entities.RevokeRoleModerationAction,
+		Actor:      input.RevokerAddress,
+		TargetType: entities.UserModerationTarget,
+		TargetId:   input.Address,
+		Before:     roleSnapshot(input.Role, boardId),
+	})
+
+	return u.database.RevokeRole(ctx, input.Address, input.Role, boardId, input.RevokerAddress, log)
+}
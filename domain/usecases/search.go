@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type Search struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewSearchUseCase(validator common.Validator, database gateways.Database) *Search {
+	return &Search{
+		validator,
+		database,
+	}
+}
+
+type SearchInput struct {
+	Query         string  `validate:"min=1,max=200"`
+	Author        *string `validate:"omitempty,eth_addr"`
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	Cursor        *entities.SearchCursor
+	Limit         int64 `validate:"gt=0,lte=100"`
+	// the address of the requesting user, nil if anonymous
+	Viewer *string
+}
+
+// Results are keyset paginated on their rank so the returned cursor points at the last result of the page.
+// The returned cursor is nil when there are no more results.
+func (u *Search) Execute(ctx context.Context, input SearchInput) ([]entities.SearchResult, *entities.SearchCursor, error) {
+	input.Query = strings.TrimSpace(input.Query)
+
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return nil, nil, err
+	}
+
+	if input.CreatedAfter != nil && input.CreatedBefore != nil && !input.CreatedAfter.Before(*input.CreatedBefore) {
+		return nil, nil, errors.New("created after must be before created before")
+	}
+
+	results, err := u.database.Search(ctx, gateways.SearchSpec{
+		Query:         input.Query,
+		Author:        input.Author,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		Cursor:        input.Cursor,
+		Limit:         input.Limit + 1,
+		Viewer:        input.Viewer,
+	})
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if int64(len(results)) <= input.Limit {
+		return results, nil, nil
+	}
+
+	results = results[:input.Limit]
+	last := results[len(results)-1]
+	cursor := last.Cursor()
+
+	return results, &cursor, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type SeedAdmins struct {
+	logger    common.Logger
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewSeedAdminsUseCase(logger common.Logger, validator common.Validator, database gateways.Database) *SeedAdmins {
+	return &SeedAdmins{
+		logger,
+		validator,
+		database,
+	}
+}
+
+type SeedAdminsInput struct {
+	Addresses []string `validate:"dive,eth_addr_checksum"`
+}
+
+// The configured addresses are granted the global admin role so there is always someone able to grant roles.
+// Grants made on startup are recorded as changes made by the zero address.
+func (u *SeedAdmins) Execute(ctx context.Context, input SeedAdminsInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	for _, address := range input.Addresses {
+		if err := u.database.GrantRole(ctx, address, entities.AdminRole, nil, ZeroAddress, nil); err != nil {
+			return err
+		}
+	}
+
+	u.logger.Info(ctx).Msgf("seeded %v admins", len(input.Addresses))
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type SendPushNotifications struct {
+	logger   common.Logger
+	database gateways.Database
+	webPush  gateways.WebPush
+}
+
+func NewSendPushNotificationsUseCase(logger common.Logger, database gateways.Database, webPush gateways.WebPush) *SendPushNotifications {
+	return &SendPushNotifications{
+		logger,
+		database,
+		webPush,
+	}
+}
+
+type SendPushNotificationsInput struct {
+	CommentIds []int64
+}
+
+// Pushes each comment to the browsers of the authors of the thread or comment it replies to.
+// Recipients are resolved like notifications, so followers and mentions that are also replied to are pushed once.
+// Pushes are retried with exponential backoff and subscriptions the push service reports as gone are removed.
+// Failures are logged per comment so one bad comment does not block the rest.
+func (u *SendPushNotifications) Execute(ctx context.Context, input SendPushNotificationsInput) {
+	for _, commentId := range input.CommentIds {
+		if err := u.push(ctx, commentId); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error sending push notifications for comment %v", commentId)
+		}
+	}
+}
+
+func (u *SendPushNotifications) push(ctx context.Context, commentId int64) error {
+	comment, err := u.database.GetCommentById(ctx, commentId)
+
+	if err != nil {
+		return err
+	}
+
+	recipients, err := getNotificationRecipients(ctx, u.database, comment)
+
+	if err != nil {
+		return err
+	}
+
+	// followers and mentions are only notified in app
+	addresses := []string{}
+	for address, notificationType := range recipients {
+		if notificationType == entities.ReplyNotification || notificationType == entities.ThreadReplyNotification {
+			addresses = append(addresses, address)
+		}
+	}
+
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	subscriptions, err := u.database.GetPushSubscriptionsByAddresses(ctx, addresses)
+
+	if err != nil {
+		return err
+	}
+
+	author := comment.User()
+
+	var wg sync.WaitGroup
+	for _, subscription := range subscriptions {
+		wg.Add(1)
+		go u.send(ctx, &wg, subscription, entities.NewNotification(entities.NotificationParams{
+			Recipient: subscription.Address(),
+			Type:      recipients[subscription.Address()],
+			Actor:     author.Address(),
+			ThreadId:  comment.ThreadId(),
+			CommentId: comment.Id(),
+		}))
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (u *SendPushNotifications) send(ctx context.Context, wg *sync.WaitGroup, subscription entities.PushSubscription, notification entities.Notification) {
+	defer wg.Done()
+
+	_, err := common.FunctionRetrier(ctx, func() (any, error) {
+		return nil, u.webPush.SendPush(ctx, subscription, notification)
+	})
+
+	if errors.Is(err, common.ErrGone) {
+		u.logger.Info(ctx).Msgf("removing expired push subscription of %v", subscription.Address())
+
+		if err := u.database.DeleteExpiredPushSubscription(ctx, subscription.Endpoint()); err != nil {
+			u.logger.Error(ctx).Err(err).Msgf("error removing expired push subscription of %v", subscription.Address())
+		}
+		return
+	}
+
+	if err != nil {
+		u.logger.Error(ctx).Err(err).Msgf("error sending push notification to %v", subscription.Address())
+	}
+}
@@ -51,6 +51,11 @@ func (u *Signin) Execute(ctx context.Context, input SigninInput) (string, error)
 		return "", fmt.Errorf("invalid signature %w", err)
 	}
 
+	// only checked once the caller has proven they own the address so bans can not be probed
+	if _, err := rejectHardBan(ctx, u.database, input.Address); err != nil {
+		return "", err
+	}
+
 	err = u.updateUser(ctx, input.Address)
 
 	if err != nil {
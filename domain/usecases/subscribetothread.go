@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type SubscribeToThread struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewSubscribeToThreadUseCase(validator common.Validator, database gateways.Database) *SubscribeToThread {
+	return &SubscribeToThread{
+		validator,
+		database,
+	}
+}
+
+type SubscribeToThreadInput struct {
+	ThreadId int64  `validate:"gt=0"`
+	Address  string `validate:"eth_addr"`
+	// false to stop following the thread
+	Subscribed bool
+}
+
+// Followers are notified of every new comment on the thread.
+// Unfollowing only lasts until the user comments on the thread again.
+func (u *SubscribeToThread) Execute(ctx context.Context, input SubscribeToThreadInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	if !input.Subscribed {
+		return u.database.UnsubscribeFromThread(ctx, input.Address, input.ThreadId)
+	}
+
+	if _, err := u.database.GetThreadById(ctx, input.ThreadId); err != nil {
+		return fmt.Errorf("thread %v: %w", input.ThreadId, err)
+	}
+
+	return u.database.SubscribeToThread(ctx, input.Address, input.ThreadId)
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type UnbanUser struct {
+	validator common.Validator
+	database  gateways.Database
+}
+
+func NewUnbanUserUseCase(validator common.Validator, database gateways.Database) *UnbanUser {
+	return &UnbanUser{
+		validator,
+		database,
+	}
+}
+
+type UnbanUserInput struct {
+	// addresses are checksummed so they match the addresses bans are issued to
+	Address        string `validate:"eth_addr_checksum"`
+	RevokerAddress string `validate:"eth_addr_checksum"`
+	Reason         string `validate:"max=1000"`
+}
+
+// Revokes every active ban of the address
+func (u *UnbanUser) Execute(ctx context.Context, input UnbanUserInput) error {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return err
+	}
+
+	before, err := u.database.GetActiveBan(ctx, input.Address)
+
+	if err != nil {
+		return err
+	}
+
+	log := entities.NewModerationLog(entities.ModerationLogParams{
+		Action:     entities.UnbanModerationAction,
+		Actor:      input.RevokerAddress,
+		TargetType: entities.UserModerationTarget,
+		TargetId:   input.Address,
+		Reason:     input.Reason,
+		Before:     banSnapshot(before),
+	})
+
+	return u.database.RevokeBans(ctx, input.Address, input.RevokerAddress, log)
+}
@@ -0,0 +1,71 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type ViewThread struct {
+	logger    common.Logger
+	validator common.Validator
+	cache     gateways.Cache
+	stream    gateways.Stream
+}
+
+func NewViewThreadUseCase(logger common.Logger, validator common.Validator, cache gateways.Cache, stream gateways.Stream) *ViewThread {
+	return &ViewThread{
+		logger,
+		validator,
+		cache,
+		stream,
+	}
+}
+
+type ViewThreadInput struct {
+	ThreadId int64  `validate:"gt=0"`
+	Address  string `validate:"eth_addr"`
+	// the same address can view the thread from several connections
+	Connection string `validate:"min=1,max=64"`
+	// viewers are counted until they have not been refreshed for this long
+	TTL time.Duration `validate:"gt=0"`
+}
+
+// Marks the connection of the address as viewing the thread and returns the number of viewers.
+// Viewers are expected to call this again before the ttl elapses to stay counted.
+// Only new viewers are announced to everyone watching the thread.
+func (u *ViewThread) Execute(ctx context.Context, input ViewThreadInput) (int64, error) {
+	if err := u.validator.ValidateStruct(input); err != nil {
+		return 0, err
+	}
+
+	added, err := u.cache.SetPresence(ctx, presenceKey(input.ThreadId), input.Address, input.Connection, input.TTL)
+
+	if err != nil {
+		return 0, err
+	}
+
+	viewers, err := u.cache.CountPresence(ctx, presenceKey(input.ThreadId))
+
+	if err != nil {
+		return 0, err
+	}
+
+	if added {
+		publishThreadEvent(ctx, u.logger, u.stream, entities.ThreadEventParams{
+			Type:     entities.PresenceUpdatedEvent,
+			ThreadId: input.ThreadId,
+			Viewers:  &viewers,
+		})
+	}
+
+	return viewers, nil
+}
+
+func presenceKey(threadId int64) string {
+	return fmt.Sprintf("presence:thread:%v", threadId)
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *postgresGateway) CreateBan(ctx context.Context, ban entities.Ban, log entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	if err := createBan(ctx, qtx, ban); err != nil {
+		return err
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func createBan(ctx context.Context, queries *bindings.Queries, ban entities.Ban) error {
+	expiresAt := pgtype.Timestamp{}
+	if ban.ExpiresAt() != nil {
+		expiresAt = pgtype.Timestamp{Time: *ban.ExpiresAt(), Valid: true}
+	}
+
+	return queries.CreateBan(ctx, bindings.CreateBanParams{
+		Address:   ban.Address(),
+		Type:      string(ban.Type()),
+		Reason:    ban.Reason(),
+		ExpiresAt: expiresAt,
+		IssuedBy:  ban.IssuedBy(),
+	})
+}
+
+// nil if the address has no active ban
+func (p *postgresGateway) GetActiveBan(ctx context.Context, address string) (*entities.Ban, error) {
+	dbBan, err := p.queries.GetActiveBan(ctx, address)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	ban := entities.NewBan(entities.BanParams{
+		Address:   dbBan.Address,
+		Type:      entities.BanType(dbBan.Type),
+		Reason:    dbBan.Reason,
+		ExpiresAt: toTimePtr(dbBan.ExpiresAt),
+		IssuedBy:  dbBan.IssuedBy,
+		CreatedAt: dbBan.CreatedAt.Time,
+	})
+
+	return &ban, nil
+}
+
+func (p *postgresGateway) RevokeBans(ctx context.Context, address string, revokedBy string, log entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	rows, err := qtx.RevokeBans(ctx, bindings.RevokeBansParams{
+		RevokedBy: revokedBy,
+		Address:   address,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
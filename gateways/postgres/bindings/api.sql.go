@@ -28,19 +28,158 @@ func (q *Queries) AggregateCommentVotes(ctx context.Context, commentID int64) er
 
 const aggregateThreadVotes = `-- name: AggregateThreadVotes :exec
 UPDATE threads
-SET votes = (
-	SELECT COALESCE(SUM(vote), 0)
+SET
+	votes = sub.votes,
+	hot_score = SIGN(sub.votes) * LOG(GREATEST(ABS(sub.votes), 1)) + EXTRACT(EPOCH FROM threads.created_at) / 45000
+FROM (
+	SELECT COALESCE(SUM(vote), 0) AS votes
 	FROM thread_votes
 	WHERE thread_votes.thread_id = $1
-)
+) AS sub
 WHERE threads.id = $1
 `
 
+// hot score decays votes by the age of the thread and must be kept in sync with the votes
 func (q *Queries) AggregateThreadVotes(ctx context.Context, threadID int64) error {
 	_, err := q.db.Exec(ctx, aggregateThreadVotes, threadID)
 	return err
 }
 
+const archiveInactiveThreads = `-- name: ArchiveInactiveThreads :execrows
+UPDATE threads
+SET archived_at = NOW()
+WHERE archived_at IS NULL
+AND is_deleted = FALSE
+AND active_at < $1::timestamp
+AND (pinned_until IS NULL OR pinned_until <= NOW())
+`
+
+// pinned threads are left alone until they are unpinned
+func (q *Queries) ArchiveInactiveThreads(ctx context.Context, inactiveSince pgtype.Timestamp) (int64, error) {
+	result, err := q.db.Exec(ctx, archiveInactiveThreads, inactiveSince)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const countOpenReports = `-- name: CountOpenReports :one
+SELECT COUNT(*)
+FROM reports
+WHERE target_type = $1::varchar(16)
+AND target_id = $2::varchar(42)
+AND resolved_at IS NULL
+`
+
+type CountOpenReportsParams struct {
+	TargetType string
+	TargetID   string
+}
+
+func (q *Queries) CountOpenReports(ctx context.Context, arg CountOpenReportsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countOpenReports, arg.TargetType, arg.TargetID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUnreadNotifications = `-- name: CountUnreadNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE recipient = $1::varchar(42)
+AND read_at IS NULL
+`
+
+func (q *Queries) CountUnreadNotifications(ctx context.Context, recipient string) (int64, error) {
+	row := q.db.QueryRow(ctx, countUnreadNotifications, recipient)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAllocation = `-- name: CreateAllocation :exec
+INSERT INTO allocations (distribution_id, address, amount, votes)
+VALUES ($1::bigint, $2::varchar(42), $3::numeric, $4::bigint)
+`
+
+type CreateAllocationParams struct {
+	DistributionID int64
+	Address        string
+	Amount         pgtype.Numeric
+	Votes          int64
+}
+
+func (q *Queries) CreateAllocation(ctx context.Context, arg CreateAllocationParams) error {
+	_, err := q.db.Exec(ctx, createAllocation,
+		arg.DistributionID,
+		arg.Address,
+		arg.Amount,
+		arg.Votes,
+	)
+	return err
+}
+
+const createBan = `-- name: CreateBan :exec
+INSERT INTO bans (address, type, reason, expires_at, issued_by)
+VALUES ($1::varchar(42), $2::varchar(16), $3::text, $4::timestamp, $5::varchar(42))
+`
+
+type CreateBanParams struct {
+	Address   string
+	Type      string
+	Reason    string
+	ExpiresAt pgtype.Timestamp
+	IssuedBy  string
+}
+
+func (q *Queries) CreateBan(ctx context.Context, arg CreateBanParams) error {
+	_, err := q.db.Exec(ctx, createBan,
+		arg.Address,
+		arg.Type,
+		arg.Reason,
+		arg.ExpiresAt,
+		arg.IssuedBy,
+	)
+	return err
+}
+
+const createBoard = `-- name: CreateBoard :one
+INSERT INTO boards (slug, title, description, rules, created_by, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+RETURNING id
+`
+
+type CreateBoardParams struct {
+	Slug                      string
+	Title                     string
+	Description               string
+	Rules                     string
+	CreatedBy                 string
+	ImageFileName             pgtype.Text
+	ImageOriginalUrl          pgtype.Text
+	ImageOriginalContentType  pgtype.Text
+	ImageFormattedUrl         pgtype.Text
+	ImageFormattedContentType pgtype.Text
+}
+
+func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (int64, error) {
+	row := q.db.QueryRow(ctx, createBoard,
+		arg.Slug,
+		arg.Title,
+		arg.Description,
+		arg.Rules,
+		arg.CreatedBy,
+		arg.ImageFileName,
+		arg.ImageOriginalUrl,
+		arg.ImageOriginalContentType,
+		arg.ImageFormattedUrl,
+		arg.ImageFormattedContentType,
+	)
+	var id int64
+	err := row.Scan(&id)
+	return id, err
+}
+
 const createComment = `-- name: CreateComment :one
 INSERT INTO comments (address, thread_id, replied_to_comment_id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
@@ -94,6 +233,19 @@ func (q *Queries) CreateCommentDownVote(ctx context.Context, arg CreateCommentDo
 	return err
 }
 
+const createCommentRevision = `-- name: CreateCommentRevision :exec
+INSERT INTO comment_revisions (comment_id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at)
+SELECT id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, COALESCE(edited_at, created_at)
+FROM comments
+WHERE id = $1
+`
+
+// The current version of a comment is kept as a revision before it is edited
+func (q *Queries) CreateCommentRevision(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, createCommentRevision, id)
+	return err
+}
+
 const createCommentUnVote = `-- name: CreateCommentUnVote :exec
 INSERT INTO comment_votes (address, comment_id, vote)
 VALUES ($1, $2, 0)
@@ -130,14 +282,249 @@ func (q *Queries) CreateCommentUpVote(ctx context.Context, arg CreateCommentUpVo
 	return err
 }
 
+const createDistribution = `-- name: CreateDistribution :one
+INSERT INTO distributions (round, votes, amount, merkle_root, participants)
+VALUES ($1::timestamp, $2::bigint, $3::numeric, $4::varchar(66), $5::bigint)
+ON CONFLICT (round) DO NOTHING
+RETURNING id, round, votes, amount, created_at, merkle_root, participants
+`
+
+type CreateDistributionParams struct {
+	Round        pgtype.Timestamp
+	Votes        int64
+	Amount       pgtype.Numeric
+	MerkleRoot   string
+	Participants int64
+}
+
+// a round that already exists returns no rows
+func (q *Queries) CreateDistribution(ctx context.Context, arg CreateDistributionParams) (Distribution, error) {
+	row := q.db.QueryRow(ctx, createDistribution,
+		arg.Round,
+		arg.Votes,
+		arg.Amount,
+		arg.MerkleRoot,
+		arg.Participants,
+	)
+	var i Distribution
+	err := row.Scan(
+		&i.ID,
+		&i.Round,
+		&i.Votes,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.MerkleRoot,
+		&i.Participants,
+	)
+	return i, err
+}
+
+const createMerkleTree = `-- name: CreateMerkleTree :exec
+INSERT INTO merkle_trees (distribution_id, nodes)
+VALUES ($1::bigint, $2::varchar(66)[])
+`
+
+type CreateMerkleTreeParams struct {
+	DistributionID int64
+	Nodes          []string
+}
+
+func (q *Queries) CreateMerkleTree(ctx context.Context, arg CreateMerkleTreeParams) error {
+	_, err := q.db.Exec(ctx, createMerkleTree, arg.DistributionID, arg.Nodes)
+	return err
+}
+
+const createModerationLog = `-- name: CreateModerationLog :exec
+INSERT INTO moderation_log (action, actor, target_type, target_id, reason, before, after)
+VALUES ($1::varchar(16), $2::varchar(42), $3::varchar(16), $4::varchar(42), $5::text, $6::jsonb, $7::jsonb)
+`
+
+type CreateModerationLogParams struct {
+	Action     string
+	Actor      string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     []byte
+	After      []byte
+}
+
+func (q *Queries) CreateModerationLog(ctx context.Context, arg CreateModerationLogParams) error {
+	_, err := q.db.Exec(ctx, createModerationLog,
+		arg.Action,
+		arg.Actor,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Reason,
+		arg.Before,
+		arg.After,
+	)
+	return err
+}
+
+const createNotification = `-- name: CreateNotification :exec
+INSERT INTO notifications (recipient, type, actor, thread_id, comment_id)
+VALUES ($1::varchar(42), $2::varchar(16), $3::varchar(42), $4::bigint, $5::bigint)
+ON CONFLICT (recipient, comment_id) DO NOTHING
+`
+
+type CreateNotificationParams struct {
+	Recipient string
+	Type      string
+	Actor     string
+	ThreadID  int64
+	CommentID int64
+}
+
+// redelivered stream messages must not notify twice so duplicates are ignored
+func (q *Queries) CreateNotification(ctx context.Context, arg CreateNotificationParams) error {
+	_, err := q.db.Exec(ctx, createNotification,
+		arg.Recipient,
+		arg.Type,
+		arg.Actor,
+		arg.ThreadID,
+		arg.CommentID,
+	)
+	return err
+}
+
+const createPushSubscription = `-- name: CreatePushSubscription :execrows
+INSERT INTO push_subscriptions (endpoint, address, p256dh, auth)
+VALUES ($1::text, $2::varchar(42), $3::varchar(128), $4::varchar(64))
+ON CONFLICT (endpoint) DO UPDATE
+SET p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, created_at = NOW()
+WHERE push_subscriptions.address = EXCLUDED.address
+`
+
+type CreatePushSubscriptionParams struct {
+	Endpoint string
+	Address  string
+	P256dh   string
+	Auth     string
+}
+
+// An endpoint registered by another address is left untouched and no rows are affected
+func (q *Queries) CreatePushSubscription(ctx context.Context, arg CreatePushSubscriptionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createPushSubscription,
+		arg.Endpoint,
+		arg.Address,
+		arg.P256dh,
+		arg.Auth,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createReport = `-- name: CreateReport :exec
+INSERT INTO reports (reporter, target_type, target_id, reason, details)
+VALUES ($1::varchar(42), $2::varchar(16), $3::varchar(42), $4::varchar(16), $5::text)
+ON CONFLICT DO NOTHING
+`
+
+type CreateReportParams struct {
+	Reporter   string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Details    string
+}
+
+// reporting a target the reporter already has an open report for is a no-op
+func (q *Queries) CreateReport(ctx context.Context, arg CreateReportParams) error {
+	_, err := q.db.Exec(ctx, createReport,
+		arg.Reporter,
+		arg.TargetType,
+		arg.TargetID,
+		arg.Reason,
+		arg.Details,
+	)
+	return err
+}
+
+const createRestoration = `-- name: CreateRestoration :exec
+INSERT INTO restorations (target_type, target_id, restored_by, reason)
+VALUES ($1::varchar(16), $2::bigint, $3::varchar(42), $4::text)
+`
+
+type CreateRestorationParams struct {
+	TargetType string
+	TargetID   int64
+	RestoredBy string
+	Reason     string
+}
+
+func (q *Queries) CreateRestoration(ctx context.Context, arg CreateRestorationParams) error {
+	_, err := q.db.Exec(ctx, createRestoration,
+		arg.TargetType,
+		arg.TargetID,
+		arg.RestoredBy,
+		arg.Reason,
+	)
+	return err
+}
+
+const createRole = `-- name: CreateRole :execrows
+INSERT INTO roles (address, role, board_id, created_by)
+VALUES ($1::varchar(42), $2::varchar(16), $3::bigint, $4::varchar(42))
+ON CONFLICT DO NOTHING
+`
+
+type CreateRoleParams struct {
+	Address   string
+	Role      string
+	BoardID   pgtype.Int8
+	CreatedBy string
+}
+
+// granting a role that is already held is a no-op
+func (q *Queries) CreateRole(ctx context.Context, arg CreateRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createRole,
+		arg.Address,
+		arg.Role,
+		arg.BoardID,
+		arg.CreatedBy,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const createRoleChange = `-- name: CreateRoleChange :exec
+INSERT INTO role_changes (address, role, board_id, action, changed_by)
+VALUES ($1::varchar(42), $2::varchar(16), $3::bigint, $4::varchar(16), $5::varchar(42))
+`
+
+type CreateRoleChangeParams struct {
+	Address   string
+	Role      string
+	BoardID   pgtype.Int8
+	Action    string
+	ChangedBy string
+}
+
+func (q *Queries) CreateRoleChange(ctx context.Context, arg CreateRoleChangeParams) error {
+	_, err := q.db.Exec(ctx, createRoleChange,
+		arg.Address,
+		arg.Role,
+		arg.BoardID,
+		arg.Action,
+		arg.ChangedBy,
+	)
+	return err
+}
+
 const createThread = `-- name: CreateThread :one
-INSERT INTO threads (address, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO threads (address, board_id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, hot_score)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, EXTRACT(EPOCH FROM NOW()) / 45000)
 RETURNING id
 `
 
 type CreateThreadParams struct {
 	Address                   string
+	BoardID                   int64
 	Title                     string
 	Content                   string
 	ImageFileName             string
@@ -150,6 +537,7 @@ type CreateThreadParams struct {
 func (q *Queries) CreateThread(ctx context.Context, arg CreateThreadParams) (int64, error) {
 	row := q.db.QueryRow(ctx, createThread,
 		arg.Address,
+		arg.BoardID,
 		arg.Title,
 		arg.Content,
 		arg.ImageFileName,
@@ -183,6 +571,35 @@ func (q *Queries) CreateThreadDownVote(ctx context.Context, arg CreateThreadDown
 	return err
 }
 
+const createThreadRevision = `-- name: CreateThreadRevision :exec
+INSERT INTO thread_revisions (thread_id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at)
+SELECT id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, COALESCE(edited_at, created_at)
+FROM threads
+WHERE id = $1
+`
+
+// The current version of a thread is kept as a revision before it is edited
+func (q *Queries) CreateThreadRevision(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, createThreadRevision, id)
+	return err
+}
+
+const createThreadSubscription = `-- name: CreateThreadSubscription :exec
+INSERT INTO thread_subscriptions (address, thread_id)
+VALUES ($1::varchar(42), $2::bigint)
+ON CONFLICT (address, thread_id) DO NOTHING
+`
+
+type CreateThreadSubscriptionParams struct {
+	Address  string
+	ThreadID int64
+}
+
+func (q *Queries) CreateThreadSubscription(ctx context.Context, arg CreateThreadSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, createThreadSubscription, arg.Address, arg.ThreadID)
+	return err
+}
+
 const createThreadUnVote = `-- name: CreateThreadUnVote :exec
 INSERT INTO thread_votes (address, thread_id, vote)
 VALUES ($1, $2, 0)
@@ -219,172 +636,303 @@ func (q *Queries) CreateThreadUpVote(ctx context.Context, arg CreateThreadUpVote
 	return err
 }
 
+const createVoteDecision = `-- name: CreateVoteDecision :exec
+INSERT INTO vote_decisions (type, target_id, voter, author, vote, reputation, accepted, reason, voted_at)
+VALUES (
+	$1::varchar(16),
+	$2::bigint,
+	$3::varchar(42),
+	$4::varchar(42),
+	$5::smallint,
+	$6::numeric,
+	$7::boolean,
+	$8::varchar(32),
+	$9::timestamp
+)
+ON CONFLICT (type, target_id, voter, voted_at) DO NOTHING
+`
+
+type CreateVoteDecisionParams struct {
+	Type       string
+	TargetID   int64
+	Voter      string
+	Author     pgtype.Text
+	Vote       int16
+	Reputation pgtype.Numeric
+	Accepted   bool
+	Reason     pgtype.Text
+	VotedAt    pgtype.Timestamp
+}
+
+// a decision that already exists for the same vote is left as is
+func (q *Queries) CreateVoteDecision(ctx context.Context, arg CreateVoteDecisionParams) error {
+	_, err := q.db.Exec(ctx, createVoteDecision,
+		arg.Type,
+		arg.TargetID,
+		arg.Voter,
+		arg.Author,
+		arg.Vote,
+		arg.Reputation,
+		arg.Accepted,
+		arg.Reason,
+		arg.VotedAt,
+	)
+	return err
+}
+
+const createWebhook = `-- name: CreateWebhook :one
+INSERT INTO webhooks (url, events, secret, created_by)
+VALUES ($1::text, $2::varchar(32)[], $3::text, $4::varchar(42))
+RETURNING id, url, events, secret, created_by, consecutive_failures, disabled_at, created_at
+`
+
+type CreateWebhookParams struct {
+	Url       string
+	Events    []string
+	Secret    string
+	CreatedBy string
+}
+
+func (q *Queries) CreateWebhook(ctx context.Context, arg CreateWebhookParams) (Webhook, error) {
+	row := q.db.QueryRow(ctx, createWebhook,
+		arg.Url,
+		arg.Events,
+		arg.Secret,
+		arg.CreatedBy,
+	)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Events,
+		&i.Secret,
+		&i.CreatedBy,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createWebhookDelivery = `-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (webhook_id, event_type, event_id, status_code, error, attempts, succeeded)
+VALUES ($1::bigint, $2::varchar(32), $3::varchar(64), $4::int, $5::text, $6::int, $7::boolean)
+`
+
+type CreateWebhookDeliveryParams struct {
+	WebhookID  int64
+	EventType  string
+	EventID    string
+	StatusCode pgtype.Int4
+	Error      string
+	Attempts   int32
+	Succeeded  bool
+}
+
+func (q *Queries) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, createWebhookDelivery,
+		arg.WebhookID,
+		arg.EventType,
+		arg.EventID,
+		arg.StatusCode,
+		arg.Error,
+		arg.Attempts,
+		arg.Succeeded,
+	)
+	return err
+}
+
 const deleteComment = `-- name: DeleteComment :one
 UPDATE comments
-SET is_deleted = TRUE, deleted_at = NOW()
-WHERE id = $1
+SET is_deleted = TRUE, deleted_at = NOW(), deleted_by = $1::varchar(42)
+WHERE id = $2::bigint
 RETURNING id as comment_id
 `
 
-func (q *Queries) DeleteComment(ctx context.Context, id int64) (int64, error) {
-	row := q.db.QueryRow(ctx, deleteComment, id)
+type DeleteCommentParams struct {
+	DeletedBy string
+	ID        int64
+}
+
+func (q *Queries) DeleteComment(ctx context.Context, arg DeleteCommentParams) (int64, error) {
+	row := q.db.QueryRow(ctx, deleteComment, arg.DeletedBy, arg.ID)
 	var comment_id int64
 	err := row.Scan(&comment_id)
 	return comment_id, err
 }
 
+const deleteExpiredPushSubscription = `-- name: DeleteExpiredPushSubscription :exec
+DELETE FROM push_subscriptions
+WHERE endpoint = $1::text
+`
+
+func (q *Queries) DeleteExpiredPushSubscription(ctx context.Context, endpoint string) error {
+	_, err := q.db.Exec(ctx, deleteExpiredPushSubscription, endpoint)
+	return err
+}
+
+const deletePushSubscription = `-- name: DeletePushSubscription :execrows
+DELETE FROM push_subscriptions
+WHERE endpoint = $1::text
+AND address = $2::varchar(42)
+`
+
+type DeletePushSubscriptionParams struct {
+	Endpoint string
+	Address  string
+}
+
+func (q *Queries) DeletePushSubscription(ctx context.Context, arg DeletePushSubscriptionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePushSubscription, arg.Endpoint, arg.Address)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteRole = `-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE address = $1::varchar(42)
+AND role = $2::varchar(16)
+AND board_id IS NOT DISTINCT FROM $3::bigint
+`
+
+type DeleteRoleParams struct {
+	Address string
+	Role    string
+	BoardID pgtype.Int8
+}
+
+func (q *Queries) DeleteRole(ctx context.Context, arg DeleteRoleParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteRole, arg.Address, arg.Role, arg.BoardID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const deleteThread = `-- name: DeleteThread :one
 UPDATE threads
-SET is_deleted = TRUE, deleted_at = NOW()
-WHERE id = $1
+SET is_deleted = TRUE, deleted_at = NOW(), deleted_by = $1::varchar(42)
+WHERE id = $2::bigint
 RETURNING id as thread_id
 `
 
-func (q *Queries) DeleteThread(ctx context.Context, id int64) (int64, error) {
-	row := q.db.QueryRow(ctx, deleteThread, id)
+type DeleteThreadParams struct {
+	DeletedBy string
+	ID        int64
+}
+
+func (q *Queries) DeleteThread(ctx context.Context, arg DeleteThreadParams) (int64, error) {
+	row := q.db.QueryRow(ctx, deleteThread, arg.DeletedBy, arg.ID)
 	var thread_id int64
 	err := row.Scan(&thread_id)
 	return thread_id, err
 }
 
-const getChallenge = `-- name: GetChallenge :one
-SELECT address, message, expires_at
-FROM challenges
-WHERE address = $1
+const deleteThreadSubscription = `-- name: DeleteThreadSubscription :exec
+DELETE FROM thread_subscriptions
+WHERE address = $1::varchar(42)
+AND thread_id = $2::bigint
 `
 
-func (q *Queries) GetChallenge(ctx context.Context, address string) (Challenge, error) {
-	row := q.db.QueryRow(ctx, getChallenge, address)
-	var i Challenge
-	err := row.Scan(&i.Address, &i.Message, &i.ExpiresAt)
+type DeleteThreadSubscriptionParams struct {
+	Address  string
+	ThreadID int64
+}
+
+func (q *Queries) DeleteThreadSubscription(ctx context.Context, arg DeleteThreadSubscriptionParams) error {
+	_, err := q.db.Exec(ctx, deleteThreadSubscription, arg.Address, arg.ThreadID)
+	return err
+}
+
+const deleteWebhook = `-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE id = $1::bigint
+`
+
+func (q *Queries) DeleteWebhook(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteWebhook, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const distributeVoteDecisions = `-- name: DistributeVoteDecisions :execrows
+UPDATE vote_decisions
+SET distribution_id = $1::bigint, counted = id = ANY($2::bigint[])
+WHERE distribution_id IS NULL
+AND created_at <= $3::timestamp
+`
+
+type DistributeVoteDecisionsParams struct {
+	DistributionID int64
+	CountedIds     []int64
+	Cutoff         pgtype.Timestamp
+}
+
+func (q *Queries) DistributeVoteDecisions(ctx context.Context, arg DistributeVoteDecisionsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, distributeVoteDecisions, arg.DistributionID, arg.CountedIds, arg.Cutoff)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const enableWebhook = `-- name: EnableWebhook :one
+UPDATE webhooks
+SET disabled_at = NULL, consecutive_failures = 0
+WHERE id = $1::bigint
+RETURNING id, url, events, secret, created_by, consecutive_failures, disabled_at, created_at
+`
+
+func (q *Queries) EnableWebhook(ctx context.Context, id int64) (Webhook, error) {
+	row := q.db.QueryRow(ctx, enableWebhook, id)
+	var i Webhook
+	err := row.Scan(
+		&i.ID,
+		&i.Url,
+		&i.Events,
+		&i.Secret,
+		&i.CreatedBy,
+		&i.ConsecutiveFailures,
+		&i.DisabledAt,
+		&i.CreatedAt,
+	)
 	return i, err
 }
 
-const getComment = `-- name: GetComment :one
-SELECT
-	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at,
-	r.id as r_id,
-	r.address as r_address,
-	r.content as r_content,
-	r.image_file_name as r_image_file_name,
-	r.image_original_url as r_image_original_url,
-	r.image_original_content_type as r_image_original_content_type,
-	r.image_formatted_url as r_image_formatted_url,
-	r.image_formatted_content_type as r_image_formatted_content_type,
-	r.is_deleted as r_is_deleted,
-	r.created_at as r_created_at,
-	r.deleted_at as r_deleted_at,
-	u.address as address,
-	u.ens_name as ens_name,
-	u.ens_avatar_file_name as ens_avatar_file_name,
-	u.ens_avatar_original_url as ens_avatar_original_url,
-	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
-	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
-	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
-	u.reputation as reputation,
-	u.created_at as user_created_at,
-	u.updated_at as user_updated_at
-FROM comments c
-INNER JOIN users u on c.address = u.address
-LEFT JOIN comments r on c.replied_to_comment_id = r.id
-WHERE c.id = $1
+const getActiveBan = `-- name: GetActiveBan :one
+SELECT id, address, type, reason, expires_at, issued_by, created_at, revoked_at, revoked_by FROM bans
+WHERE address = $1
+AND revoked_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY type = 'hard' DESC, created_at DESC
+LIMIT 1
 `
 
-type GetCommentRow struct {
-	ID                            int64
-	ThreadID                      int64
-	RepliedToCommentID            pgtype.Int8
-	Address                       string
-	Content                       string
-	ImageFileName                 string
-	ImageOriginalUrl              string
-	ImageOriginalContentType      string
-	ImageFormattedUrl             string
-	ImageFormattedContentType     string
-	Votes                         int64
-	IsDeleted                     bool
-	CreatedAt                     pgtype.Timestamp
-	DeletedAt                     pgtype.Timestamp
-	RID                           pgtype.Int8
-	RAddress                      pgtype.Text
-	RContent                      pgtype.Text
-	RImageFileName                pgtype.Text
-	RImageOriginalUrl             pgtype.Text
-	RImageOriginalContentType     pgtype.Text
-	RImageFormattedUrl            pgtype.Text
-	RImageFormattedContentType    pgtype.Text
-	RIsDeleted                    pgtype.Bool
-	RCreatedAt                    pgtype.Timestamp
-	RDeletedAt                    pgtype.Timestamp
-	Address_2                     string
-	EnsName                       pgtype.Text
-	EnsAvatarFileName             pgtype.Text
-	EnsAvatarOriginalUrl          pgtype.Text
-	EnsAvatarOriginalContentType  pgtype.Text
-	EnsAvatarFormattedUrl         pgtype.Text
-	EnsAvatarFormattedContentType pgtype.Text
-	Reputation                    pgtype.Numeric
-	UserCreatedAt                 pgtype.Timestamp
-	UserUpdatedAt                 pgtype.Timestamp
-}
-
-func (q *Queries) GetComment(ctx context.Context, id int64) (GetCommentRow, error) {
-	row := q.db.QueryRow(ctx, getComment, id)
-	var i GetCommentRow
+// hard bans take precedence over shadow bans
+func (q *Queries) GetActiveBan(ctx context.Context, address string) (Ban, error) {
+	row := q.db.QueryRow(ctx, getActiveBan, address)
+	var i Ban
 	err := row.Scan(
 		&i.ID,
-		&i.ThreadID,
-		&i.RepliedToCommentID,
 		&i.Address,
-		&i.Content,
-		&i.ImageFileName,
-		&i.ImageOriginalUrl,
-		&i.ImageOriginalContentType,
-		&i.ImageFormattedUrl,
-		&i.ImageFormattedContentType,
-		&i.Votes,
-		&i.IsDeleted,
+		&i.Type,
+		&i.Reason,
+		&i.ExpiresAt,
+		&i.IssuedBy,
 		&i.CreatedAt,
-		&i.DeletedAt,
-		&i.RID,
-		&i.RAddress,
-		&i.RContent,
-		&i.RImageFileName,
-		&i.RImageOriginalUrl,
-		&i.RImageOriginalContentType,
-		&i.RImageFormattedUrl,
-		&i.RImageFormattedContentType,
-		&i.RIsDeleted,
-		&i.RCreatedAt,
-		&i.RDeletedAt,
-		&i.Address_2,
-		&i.EnsName,
-		&i.EnsAvatarFileName,
-		&i.EnsAvatarOriginalUrl,
-		&i.EnsAvatarOriginalContentType,
-		&i.EnsAvatarFormattedUrl,
-		&i.EnsAvatarFormattedContentType,
-		&i.Reputation,
-		&i.UserCreatedAt,
-		&i.UserUpdatedAt,
+		&i.RevokedAt,
+		&i.RevokedBy,
 	)
 	return i, err
 }
 
-const getComments = `-- name: GetComments :many
+const getActiveThreads = `-- name: GetActiveThreads :many
 SELECT
-	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at,
-	r.id as r_id,
-	r.address as r_address,
-	r.content as r_content,
-	r.image_file_name as r_image_file_name,
-	r.image_original_url as r_image_original_url,
-	r.image_original_content_type as r_image_original_content_type,
-	r.image_formatted_url as r_image_formatted_url,
-	r.image_formatted_content_type as r_image_formatted_content_type,
-	r.is_deleted as r_is_deleted,
-	r.created_at as r_created_at,
-	r.deleted_at as r_deleted_at,
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
 	u.address as address,
 	u.ens_name as ens_name,
 	u.ens_avatar_file_name as ens_avatar_file_name,
@@ -394,29 +942,37 @@ SELECT
 	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
 	u.reputation as reputation,
 	u.created_at as user_created_at,
-	u.updated_at as user_updated_at,
-	count(*) OVER() AS full_count
-FROM comments c
-INNER JOIN users u on c.address = u.address
-LEFT JOIN comments r on c.replied_to_comment_id = r.id
-WHERE c.thread_id = $1
-AND c.is_deleted = FALSE
-ORDER BY c.created_at DESC
-OFFSET $2::bigint
-LIMIT $3::bigint
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND ($1::bigint IS NULL OR t.board_id = $1::bigint)
+AND ($2::bigint IS NULL OR (t.active_at, t.id) < ($3::timestamp, $2::bigint))
+AND (t.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.active_at DESC, t.id DESC
+LIMIT $5::bigint
 `
 
-type GetCommentsParams struct {
-	ThreadID int64
-	Column2  int64
-	Column3  int64
+type GetActiveThreadsParams struct {
+	BoardID        pgtype.Int8
+	CursorID       pgtype.Int8
+	CursorActiveAt pgtype.Timestamp
+	Viewer         pgtype.Text
+	PageLimit      int64
 }
 
-type GetCommentsRow struct {
+type GetActiveThreadsRow struct {
 	ID                            int64
-	ThreadID                      int64
-	RepliedToCommentID            pgtype.Int8
 	Address                       string
+	Title                         string
 	Content                       string
 	ImageFileName                 string
 	ImageOriginalUrl              string
@@ -427,17 +983,16 @@ type GetCommentsRow struct {
 	IsDeleted                     bool
 	CreatedAt                     pgtype.Timestamp
 	DeletedAt                     pgtype.Timestamp
-	RID                           pgtype.Int8
-	RAddress                      pgtype.Text
-	RContent                      pgtype.Text
-	RImageFileName                pgtype.Text
-	RImageOriginalUrl             pgtype.Text
-	RImageOriginalContentType     pgtype.Text
-	RImageFormattedUrl            pgtype.Text
-	RImageFormattedContentType    pgtype.Text
-	RIsDeleted                    pgtype.Bool
-	RCreatedAt                    pgtype.Timestamp
-	RDeletedAt                    pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
 	Address_2                     string
 	EnsName                       pgtype.Text
 	EnsAvatarFileName             pgtype.Text
@@ -448,23 +1003,27 @@ type GetCommentsRow struct {
 	Reputation                    pgtype.Numeric
 	UserCreatedAt                 pgtype.Timestamp
 	UserUpdatedAt                 pgtype.Timestamp
-	FullCount                     int64
 }
 
-func (q *Queries) GetComments(ctx context.Context, arg GetCommentsParams) ([]GetCommentsRow, error) {
-	rows, err := q.db.Query(ctx, getComments, arg.ThreadID, arg.Column2, arg.Column3)
+func (q *Queries) GetActiveThreads(ctx context.Context, arg GetActiveThreadsParams) ([]GetActiveThreadsRow, error) {
+	rows, err := q.db.Query(ctx, getActiveThreads,
+		arg.BoardID,
+		arg.CursorID,
+		arg.CursorActiveAt,
+		arg.Viewer,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetCommentsRow
+	var items []GetActiveThreadsRow
 	for rows.Next() {
-		var i GetCommentsRow
+		var i GetActiveThreadsRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.ThreadID,
-			&i.RepliedToCommentID,
 			&i.Address,
+			&i.Title,
 			&i.Content,
 			&i.ImageFileName,
 			&i.ImageOriginalUrl,
@@ -475,17 +1034,16 @@ func (q *Queries) GetComments(ctx context.Context, arg GetCommentsParams) ([]Get
 			&i.IsDeleted,
 			&i.CreatedAt,
 			&i.DeletedAt,
-			&i.RID,
-			&i.RAddress,
-			&i.RContent,
-			&i.RImageFileName,
-			&i.RImageOriginalUrl,
-			&i.RImageOriginalContentType,
-			&i.RImageFormattedUrl,
-			&i.RImageFormattedContentType,
-			&i.RIsDeleted,
-			&i.RCreatedAt,
-			&i.RDeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
 			&i.Address_2,
 			&i.EnsName,
 			&i.EnsAvatarFileName,
@@ -496,7 +1054,6 @@ func (q *Queries) GetComments(ctx context.Context, arg GetCommentsParams) ([]Get
 			&i.Reputation,
 			&i.UserCreatedAt,
 			&i.UserUpdatedAt,
-			&i.FullCount,
 		); err != nil {
 			return nil, err
 		}
@@ -508,68 +1065,390 @@ func (q *Queries) GetComments(ctx context.Context, arg GetCommentsParams) ([]Get
 	return items, nil
 }
 
-const getThread = `-- name: GetThread :one
-SELECT 
-	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at,
-	u.address as address,
-	u.ens_name as ens_name,
-	u.ens_avatar_file_name as ens_avatar_file_name,
-	u.ens_avatar_original_url as ens_avatar_original_url,
-	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
-	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
-	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
-	u.reputation as reputation,
-	u.created_at as user_created_at,
-	u.updated_at as user_updated_at
-FROM threads t
-INNER JOIN users u on t.address = u.address
-WHERE t.id = $1
-AND t.is_deleted = FALSE
+const getActiveWebhooksByEvent = `-- name: GetActiveWebhooksByEvent :many
+SELECT id, url, events, secret, created_by, consecutive_failures, disabled_at, created_at
+FROM webhooks
+WHERE disabled_at IS NULL
+AND $1::varchar(32) = ANY(events)
 `
 
-type GetThreadRow struct {
-	ID                            int64
-	Address                       string
-	Title                         string
-	Content                       string
-	ImageFileName                 string
-	ImageOriginalUrl              string
-	ImageOriginalContentType      string
-	ImageFormattedUrl             string
-	ImageFormattedContentType     string
-	Votes                         int64
-	IsDeleted                     bool
-	CreatedAt                     pgtype.Timestamp
-	DeletedAt                     pgtype.Timestamp
-	Address_2                     string
-	EnsName                       pgtype.Text
-	EnsAvatarFileName             pgtype.Text
-	EnsAvatarOriginalUrl          pgtype.Text
-	EnsAvatarOriginalContentType  pgtype.Text
-	EnsAvatarFormattedUrl         pgtype.Text
-	EnsAvatarFormattedContentType pgtype.Text
-	Reputation                    pgtype.Numeric
-	UserCreatedAt                 pgtype.Timestamp
-	UserUpdatedAt                 pgtype.Timestamp
+func (q *Queries) GetActiveWebhooksByEvent(ctx context.Context, event string) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, getActiveWebhooksByEvent, event)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Events,
+			&i.Secret,
+			&i.CreatedBy,
+			&i.ConsecutiveFailures,
+			&i.DisabledAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
-func (q *Queries) GetThread(ctx context.Context, id int64) (GetThreadRow, error) {
-	row := q.db.QueryRow(ctx, getThread, id)
-	var i GetThreadRow
+const getAddressesByEnsNames = `-- name: GetAddressesByEnsNames :many
+SELECT address
+FROM users
+WHERE LOWER(ens_name) = ANY($1::text[])
+`
+
+// ens names are matched case insensitively
+func (q *Queries) GetAddressesByEnsNames(ctx context.Context, ensNames []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, getAddressesByEnsNames, ensNames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, err
+		}
+		items = append(items, address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllocation = `-- name: GetAllocation :one
+SELECT a.distribution_id, a.address, a.amount, a.votes, d.round
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.distribution_id = $1::bigint
+AND a.address = $2::varchar(42)
+`
+
+type GetAllocationParams struct {
+	DistributionID int64
+	Address        string
+}
+
+type GetAllocationRow struct {
+	DistributionID int64
+	Address        string
+	Amount         pgtype.Numeric
+	Votes          int64
+	Round          pgtype.Timestamp
+}
+
+func (q *Queries) GetAllocation(ctx context.Context, arg GetAllocationParams) (GetAllocationRow, error) {
+	row := q.db.QueryRow(ctx, getAllocation, arg.DistributionID, arg.Address)
+	var i GetAllocationRow
 	err := row.Scan(
-		&i.ID,
+		&i.DistributionID,
 		&i.Address,
-		&i.Title,
-		&i.Content,
-		&i.ImageFileName,
-		&i.ImageOriginalUrl,
-		&i.ImageOriginalContentType,
-		&i.ImageFormattedUrl,
-		&i.ImageFormattedContentType,
+		&i.Amount,
 		&i.Votes,
-		&i.IsDeleted,
-		&i.CreatedAt,
-		&i.DeletedAt,
+		&i.Round,
+	)
+	return i, err
+}
+
+const getAllocationsByAddress = `-- name: GetAllocationsByAddress :many
+SELECT a.distribution_id, a.address, a.amount, a.votes, d.round, COUNT(*) OVER() AS full_count
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.address = $1::varchar(42)
+ORDER BY d.round DESC
+LIMIT $2::bigint
+OFFSET $3::bigint
+`
+
+type GetAllocationsByAddressParams struct {
+	Address    string
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetAllocationsByAddressRow struct {
+	DistributionID int64
+	Address        string
+	Amount         pgtype.Numeric
+	Votes          int64
+	Round          pgtype.Timestamp
+	FullCount      int64
+}
+
+// latest rounds first
+func (q *Queries) GetAllocationsByAddress(ctx context.Context, arg GetAllocationsByAddressParams) ([]GetAllocationsByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getAllocationsByAddress, arg.Address, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllocationsByAddressRow
+	for rows.Next() {
+		var i GetAllocationsByAddressRow
+		if err := rows.Scan(
+			&i.DistributionID,
+			&i.Address,
+			&i.Amount,
+			&i.Votes,
+			&i.Round,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getAllocationsByDistribution = `-- name: GetAllocationsByDistribution :many
+SELECT a.distribution_id, a.address, a.amount, a.votes, d.round, COUNT(*) OVER() AS full_count
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.distribution_id = $1::bigint
+ORDER BY a.amount DESC, a.address ASC
+LIMIT $2::bigint
+OFFSET $3::bigint
+`
+
+type GetAllocationsByDistributionParams struct {
+	DistributionID int64
+	PageLimit      int64
+	PageOffset     int64
+}
+
+type GetAllocationsByDistributionRow struct {
+	DistributionID int64
+	Address        string
+	Amount         pgtype.Numeric
+	Votes          int64
+	Round          pgtype.Timestamp
+	FullCount      int64
+}
+
+// largest allocations first
+func (q *Queries) GetAllocationsByDistribution(ctx context.Context, arg GetAllocationsByDistributionParams) ([]GetAllocationsByDistributionRow, error) {
+	rows, err := q.db.Query(ctx, getAllocationsByDistribution, arg.DistributionID, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetAllocationsByDistributionRow
+	for rows.Next() {
+		var i GetAllocationsByDistributionRow
+		if err := rows.Scan(
+			&i.DistributionID,
+			&i.Address,
+			&i.Amount,
+			&i.Votes,
+			&i.Round,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getBoardBySlug = `-- name: GetBoardBySlug :one
+SELECT id, slug, title, description, rules, created_by, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at FROM boards
+WHERE slug = $1
+`
+
+func (q *Queries) GetBoardBySlug(ctx context.Context, slug string) (Board, error) {
+	row := q.db.QueryRow(ctx, getBoardBySlug, slug)
+	var i Board
+	err := row.Scan(
+		&i.ID,
+		&i.Slug,
+		&i.Title,
+		&i.Description,
+		&i.Rules,
+		&i.CreatedBy,
+		&i.ImageFileName,
+		&i.ImageOriginalUrl,
+		&i.ImageOriginalContentType,
+		&i.ImageFormattedUrl,
+		&i.ImageFormattedContentType,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getBoards = `-- name: GetBoards :many
+SELECT id, slug, title, description, rules, created_by, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at FROM boards
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) GetBoards(ctx context.Context) ([]Board, error) {
+	rows, err := q.db.Query(ctx, getBoards)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Board
+	for rows.Next() {
+		var i Board
+		if err := rows.Scan(
+			&i.ID,
+			&i.Slug,
+			&i.Title,
+			&i.Description,
+			&i.Rules,
+			&i.CreatedBy,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getChallenge = `-- name: GetChallenge :one
+SELECT address, message, expires_at
+FROM challenges
+WHERE address = $1
+`
+
+func (q *Queries) GetChallenge(ctx context.Context, address string) (Challenge, error) {
+	row := q.db.QueryRow(ctx, getChallenge, address)
+	var i Challenge
+	err := row.Scan(&i.Address, &i.Message, &i.ExpiresAt)
+	return i, err
+}
+
+const getComment = `-- name: GetComment :one
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.id = $1
+`
+
+type GetCommentRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetComment(ctx context.Context, id int64) (GetCommentRow, error) {
+	row := q.db.QueryRow(ctx, getComment, id)
+	var i GetCommentRow
+	err := row.Scan(
+		&i.ID,
+		&i.ThreadID,
+		&i.RepliedToCommentID,
+		&i.Address,
+		&i.Content,
+		&i.ImageFileName,
+		&i.ImageOriginalUrl,
+		&i.ImageOriginalContentType,
+		&i.ImageFormattedUrl,
+		&i.ImageFormattedContentType,
+		&i.Votes,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.EditedAt,
+		&i.SearchVector,
+		&i.IsHidden,
+		&i.DeletedBy,
+		&i.RID,
+		&i.RAddress,
+		&i.RContent,
+		&i.RImageFileName,
+		&i.RImageOriginalUrl,
+		&i.RImageOriginalContentType,
+		&i.RImageFormattedUrl,
+		&i.RImageFormattedContentType,
+		&i.RIsDeleted,
+		&i.RCreatedAt,
+		&i.RDeletedAt,
 		&i.Address_2,
 		&i.EnsName,
 		&i.EnsAvatarFileName,
@@ -584,9 +1463,114 @@ func (q *Queries) GetThread(ctx context.Context, id int64) (GetThreadRow, error)
 	return i, err
 }
 
-const getThreads = `-- name: GetThreads :many
+const getCommentRevisions = `-- name: GetCommentRevisions :many
+SELECT id, comment_id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at, revised_at FROM comment_revisions
+WHERE comment_id = $1
+ORDER BY revised_at DESC, id DESC
+`
+
+func (q *Queries) GetCommentRevisions(ctx context.Context, commentID int64) ([]CommentRevision, error) {
+	rows, err := q.db.Query(ctx, getCommentRevisions, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CommentRevision
+	for rows.Next() {
+		var i CommentRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.CommentID,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.CreatedAt,
+			&i.RevisedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getCommentTree = `-- name: GetCommentTree :many
+WITH RECURSIVE roots AS (
+	SELECT c.*
+	FROM comments c
+	WHERE c.thread_id = $1
+	AND c.replied_to_comment_id IS NOT DISTINCT FROM $2::bigint
+	AND ($3::bigint IS NULL OR (c.created_at, c.id) > ($4::timestamp, $3::bigint))
+	AND c.is_hidden = FALSE
+	AND (c.address = $5::varchar(42) OR NOT EXISTS (
+		SELECT 1 FROM bans b
+		WHERE b.address = c.address
+		AND b.type = 'shadow'
+		AND b.revoked_at IS NULL
+		AND (b.expires_at IS NULL OR b.expires_at > NOW())
+	))
+	ORDER BY c.created_at ASC, c.id ASC
+	LIMIT $6::bigint
+), tree AS (
+	SELECT roots.*, 1 AS depth
+	FROM roots
+	UNION ALL
+	SELECT c.*, tree.depth + 1
+	FROM tree
+	CROSS JOIN LATERAL (
+		SELECT r.*
+		FROM comments r
+		WHERE tree.depth < $7::int
+		AND r.replied_to_comment_id = tree.id
+		AND r.is_hidden = FALSE
+		AND (r.address = $5::varchar(42) OR NOT EXISTS (
+			SELECT 1 FROM bans b
+			WHERE b.address = r.address
+			AND b.type = 'shadow'
+			AND b.revoked_at IS NULL
+			AND (b.expires_at IS NULL OR b.expires_at > NOW())
+		))
+		ORDER BY r.created_at ASC, r.id ASC
+		LIMIT $8::bigint
+	) c
+)
 SELECT
-	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at,
+	t.id,
+	t.thread_id,
+	t.replied_to_comment_id,
+	t.address,
+	t.content,
+	t.image_file_name,
+	t.image_original_url,
+	t.image_original_content_type,
+	t.image_formatted_url,
+	t.image_formatted_content_type,
+	t.votes,
+	t.is_deleted,
+	t.created_at,
+	t.deleted_at,
+	t.edited_at,
+	t.is_hidden,
+	t.deleted_by,
+	t.depth::int as depth,
+	(
+		SELECT COUNT(*) FROM comments rc
+		WHERE rc.replied_to_comment_id = t.id
+		AND rc.is_hidden = FALSE
+		AND (rc.address = $5::varchar(42) OR NOT EXISTS (
+			SELECT 1 FROM bans b
+			WHERE b.address = rc.address
+			AND b.type = 'shadow'
+			AND b.revoked_at IS NULL
+			AND (b.expires_at IS NULL OR b.expires_at > NOW())
+		))
+	) as reply_count,
 	u.address as address,
 	u.ens_name as ens_name,
 	u.ens_avatar_file_name as ens_avatar_file_name,
@@ -597,17 +1581,27 @@ SELECT
 	u.reputation as reputation,
 	u.created_at as user_created_at,
 	u.updated_at as user_updated_at
-FROM threads t
+FROM tree t
 INNER JOIN users u on t.address = u.address
-WHERE t.is_deleted = FALSE
-ORDER BY RANDOM()
-LIMIT $1::bigint
+ORDER BY t.depth ASC, t.created_at ASC, t.id ASC
 `
 
-type GetThreadsRow struct {
+type GetCommentTreeParams struct {
+	ThreadID        int64
+	ParentID        pgtype.Int8
+	CursorID        pgtype.Int8
+	CursorCreatedAt pgtype.Timestamp
+	Viewer          pgtype.Text
+	PageLimit       int64
+	MaxDepth        int32
+	ReplyLimit      int64
+}
+
+type GetCommentTreeRow struct {
 	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
 	Address                       string
-	Title                         string
 	Content                       string
 	ImageFileName                 string
 	ImageOriginalUrl              string
@@ -618,6 +1612,11 @@ type GetThreadsRow struct {
 	IsDeleted                     bool
 	CreatedAt                     pgtype.Timestamp
 	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	Depth                         int32
+	ReplyCount                    int64
 	Address_2                     string
 	EnsName                       pgtype.Text
 	EnsAvatarFileName             pgtype.Text
@@ -630,23 +1629,32 @@ type GetThreadsRow struct {
 	UserUpdatedAt                 pgtype.Timestamp
 }
 
-// Order by random is not performant as we need to do a full table scan.
-// Move to TABLESAMPLE SYSTEM_ROWS(N) when performance becomes an issue.
-// Table sample is not random enough until the table gets big.
-// https://www.postgresql.org/docs/current/tsm-system-rows.html
-func (q *Queries) GetThreads(ctx context.Context, dollar_1 int64) ([]GetThreadsRow, error) {
-	rows, err := q.db.Query(ctx, getThreads, dollar_1)
+// Comment trees are walked with a recursive cte from the replies of a parent comment
+// (or the top level comments when parent_id is null) down to max_depth.
+// Only the first reply_limit replies of each nested comment are returned, the rest are loaded from a cursor.
+func (q *Queries) GetCommentTree(ctx context.Context, arg GetCommentTreeParams) ([]GetCommentTreeRow, error) {
+	rows, err := q.db.Query(ctx, getCommentTree,
+		arg.ThreadID,
+		arg.ParentID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Viewer,
+		arg.PageLimit,
+		arg.MaxDepth,
+		arg.ReplyLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetThreadsRow
+	var items []GetCommentTreeRow
 	for rows.Next() {
-		var i GetThreadsRow
+		var i GetCommentTreeRow
 		if err := rows.Scan(
 			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
 			&i.Address,
-			&i.Title,
 			&i.Content,
 			&i.ImageFileName,
 			&i.ImageOriginalUrl,
@@ -657,6 +1665,11 @@ func (q *Queries) GetThreads(ctx context.Context, dollar_1 int64) ([]GetThreadsR
 			&i.IsDeleted,
 			&i.CreatedAt,
 			&i.DeletedAt,
+			&i.EditedAt,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.Depth,
+			&i.ReplyCount,
 			&i.Address_2,
 			&i.EnsName,
 			&i.EnsAvatarFileName,
@@ -678,16 +1691,2397 @@ func (q *Queries) GetThreads(ctx context.Context, dollar_1 int64) ([]GetThreadsR
 	return items, nil
 }
 
-const getUser = `-- name: GetUser :one
-SELECT address, ens_name, created_at, updated_at, reputation, ens_avatar_file_name, ens_avatar_original_url, ens_avatar_original_content_type, ens_avatar_formatted_url, ens_avatar_formatted_content_type
-FROM users
-WHERE address = $1
+const getCommentVotesByAddress = `-- name: GetCommentVotesByAddress :many
+SELECT comment_id, vote
+FROM comment_votes
+WHERE address = $1::varchar(42)
+AND comment_id = ANY($2::bigint[])
 `
 
-func (q *Queries) GetUser(ctx context.Context, address string) (User, error) {
-	row := q.db.QueryRow(ctx, getUser, address)
-	var i User
-	err := row.Scan(
+type GetCommentVotesByAddressParams struct {
+	Address    string
+	CommentIds []int64
+}
+
+type GetCommentVotesByAddressRow struct {
+	CommentID int64
+	Vote      int16
+}
+
+func (q *Queries) GetCommentVotesByAddress(ctx context.Context, arg GetCommentVotesByAddressParams) ([]GetCommentVotesByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getCommentVotesByAddress, arg.Address, arg.CommentIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCommentVotesByAddressRow
+	for rows.Next() {
+		var i GetCommentVotesByAddressRow
+		if err := rows.Scan(&i.CommentID, &i.Vote); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getComments = `-- name: GetComments :many
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at,
+	count(*) OVER() AS full_count
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.thread_id = $1
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (c.address = $4 OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at DESC
+OFFSET $2::bigint
+LIMIT $3::bigint
+`
+
+type GetCommentsParams struct {
+	ThreadID int64
+	Column2  int64
+	Column3  int64
+	Address  string
+}
+
+type GetCommentsRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+	FullCount                     int64
+}
+
+func (q *Queries) GetComments(ctx context.Context, arg GetCommentsParams) ([]GetCommentsRow, error) {
+	rows, err := q.db.Query(ctx, getComments,
+		arg.ThreadID,
+		arg.Column2,
+		arg.Column3,
+		arg.Address,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetCommentsRow
+	for rows.Next() {
+		var i GetCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
+			&i.Address,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.RID,
+			&i.RAddress,
+			&i.RContent,
+			&i.RImageFileName,
+			&i.RImageOriginalUrl,
+			&i.RImageOriginalContentType,
+			&i.RImageFormattedUrl,
+			&i.RImageFormattedContentType,
+			&i.RIsDeleted,
+			&i.RCreatedAt,
+			&i.RDeletedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedThread = `-- name: GetDeletedThread :one
+SELECT 
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.id = $1
+AND t.is_deleted = TRUE
+`
+
+type GetDeletedThreadRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+// deleted threads are only read to be restored
+func (q *Queries) GetDeletedThread(ctx context.Context, id int64) (GetDeletedThreadRow, error) {
+	row := q.db.QueryRow(ctx, getDeletedThread, id)
+	var i GetDeletedThreadRow
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Title,
+		&i.Content,
+		&i.ImageFileName,
+		&i.ImageOriginalUrl,
+		&i.ImageOriginalContentType,
+		&i.ImageFormattedUrl,
+		&i.ImageFormattedContentType,
+		&i.Votes,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.HotScore,
+		&i.ActiveAt,
+		&i.EditedAt,
+		&i.SearchVector,
+		&i.BoardID,
+		&i.IsHidden,
+		&i.DeletedBy,
+		&i.IsLocked,
+		&i.PinnedUntil,
+		&i.ArchivedAt,
+		&i.Address_2,
+		&i.EnsName,
+		&i.EnsAvatarFileName,
+		&i.EnsAvatarOriginalUrl,
+		&i.EnsAvatarOriginalContentType,
+		&i.EnsAvatarFormattedUrl,
+		&i.EnsAvatarFormattedContentType,
+		&i.Reputation,
+		&i.UserCreatedAt,
+		&i.UserUpdatedAt,
+	)
+	return i, err
+}
+
+const getDistribution = `-- name: GetDistribution :one
+SELECT id, round, votes, amount, created_at, merkle_root, participants
+FROM distributions
+WHERE id = $1
+`
+
+func (q *Queries) GetDistribution(ctx context.Context, id int64) (Distribution, error) {
+	row := q.db.QueryRow(ctx, getDistribution, id)
+	var i Distribution
+	err := row.Scan(
+		&i.ID,
+		&i.Round,
+		&i.Votes,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.MerkleRoot,
+		&i.Participants,
+	)
+	return i, err
+}
+
+const getDistributions = `-- name: GetDistributions :many
+SELECT id, round, votes, amount, created_at, merkle_root, participants, COUNT(*) OVER() AS full_count
+FROM distributions
+ORDER BY round DESC
+LIMIT $1::bigint
+OFFSET $2::bigint
+`
+
+type GetDistributionsParams struct {
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetDistributionsRow struct {
+	ID           int64
+	Round        pgtype.Timestamp
+	Votes        int64
+	Amount       pgtype.Numeric
+	CreatedAt    pgtype.Timestamp
+	MerkleRoot   string
+	Participants int64
+	FullCount    int64
+}
+
+func (q *Queries) GetDistributions(ctx context.Context, arg GetDistributionsParams) ([]GetDistributionsRow, error) {
+	rows, err := q.db.Query(ctx, getDistributions, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDistributionsRow
+	for rows.Next() {
+		var i GetDistributionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Round,
+			&i.Votes,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.MerkleRoot,
+			&i.Participants,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getEarnings = `-- name: GetEarnings :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS amount, COUNT(*) AS rounds
+FROM allocations
+WHERE address = $1
+`
+
+type GetEarningsRow struct {
+	Amount pgtype.Numeric
+	Rounds int64
+}
+
+func (q *Queries) GetEarnings(ctx context.Context, address string) (GetEarningsRow, error) {
+	row := q.db.QueryRow(ctx, getEarnings, address)
+	var i GetEarningsRow
+	err := row.Scan(&i.Amount, &i.Rounds)
+	return i, err
+}
+
+const getHotThreads = `-- name: GetHotThreads :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND ($1::bigint IS NULL OR t.board_id = $1::bigint)
+AND ($2::bigint IS NULL OR (t.hot_score, t.id) < ($3::float8, $2::bigint))
+AND (t.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.hot_score DESC, t.id DESC
+LIMIT $5::bigint
+`
+
+type GetHotThreadsParams struct {
+	BoardID        pgtype.Int8
+	CursorID       pgtype.Int8
+	CursorHotScore pgtype.Float8
+	Viewer         pgtype.Text
+	PageLimit      int64
+}
+
+type GetHotThreadsRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetHotThreads(ctx context.Context, arg GetHotThreadsParams) ([]GetHotThreadsRow, error) {
+	rows, err := q.db.Query(ctx, getHotThreads,
+		arg.BoardID,
+		arg.CursorID,
+		arg.CursorHotScore,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetHotThreadsRow
+	for rows.Next() {
+		var i GetHotThreadsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getLastDistribution = `-- name: GetLastDistribution :one
+SELECT id, round, votes, amount, created_at, merkle_root, participants
+FROM distributions
+ORDER BY round DESC
+LIMIT 1
+`
+
+func (q *Queries) GetLastDistribution(ctx context.Context) (Distribution, error) {
+	row := q.db.QueryRow(ctx, getLastDistribution)
+	var i Distribution
+	err := row.Scan(
+		&i.ID,
+		&i.Round,
+		&i.Votes,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.MerkleRoot,
+		&i.Participants,
+	)
+	return i, err
+}
+
+const getMerkleTree = `-- name: GetMerkleTree :one
+SELECT nodes
+FROM merkle_trees
+WHERE distribution_id = $1
+`
+
+func (q *Queries) GetMerkleTree(ctx context.Context, distributionID int64) ([]string, error) {
+	row := q.db.QueryRow(ctx, getMerkleTree, distributionID)
+	var nodes []string
+	err := row.Scan(&nodes)
+	return nodes, err
+}
+
+const getModerationLog = `-- name: GetModerationLog :many
+SELECT id, action, actor, target_type, target_id, reason, before, after, created_at, COUNT(*) OVER() AS full_count
+FROM moderation_log
+ORDER BY created_at DESC, id DESC
+LIMIT $1::bigint
+OFFSET $2::bigint
+`
+
+type GetModerationLogParams struct {
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetModerationLogRow struct {
+	ID         int64
+	Action     string
+	Actor      string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     []byte
+	After      []byte
+	CreatedAt  pgtype.Timestamp
+	FullCount  int64
+}
+
+func (q *Queries) GetModerationLog(ctx context.Context, arg GetModerationLogParams) ([]GetModerationLogRow, error) {
+	rows, err := q.db.Query(ctx, getModerationLog, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetModerationLogRow
+	for rows.Next() {
+		var i GetModerationLogRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Action,
+			&i.Actor,
+			&i.TargetType,
+			&i.TargetID,
+			&i.Reason,
+			&i.Before,
+			&i.After,
+			&i.CreatedAt,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNewCommentsByAddress = `-- name: GetNewCommentsByAddress :many
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.address = $1::varchar(42)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND ($2::bigint IS NULL OR (c.created_at, c.id) < ($3::timestamp, $2::bigint))
+AND (c.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT $5::bigint
+`
+
+type GetNewCommentsByAddressParams struct {
+	Address         string
+	CursorID        pgtype.Int8
+	CursorCreatedAt pgtype.Timestamp
+	Viewer          pgtype.Text
+	PageLimit       int64
+}
+
+type GetNewCommentsByAddressRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetNewCommentsByAddress(ctx context.Context, arg GetNewCommentsByAddressParams) ([]GetNewCommentsByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getNewCommentsByAddress,
+		arg.Address,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNewCommentsByAddressRow
+	for rows.Next() {
+		var i GetNewCommentsByAddressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
+			&i.Address,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.RID,
+			&i.RAddress,
+			&i.RContent,
+			&i.RImageFileName,
+			&i.RImageOriginalUrl,
+			&i.RImageOriginalContentType,
+			&i.RImageFormattedUrl,
+			&i.RImageFormattedContentType,
+			&i.RIsDeleted,
+			&i.RCreatedAt,
+			&i.RDeletedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNewThreads = `-- name: GetNewThreads :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND ($1::bigint IS NULL OR t.board_id = $1::bigint)
+AND ($2::bigint IS NULL OR (t.created_at, t.id) < ($3::timestamp, $2::bigint))
+AND (t.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $5::bigint
+`
+
+type GetNewThreadsParams struct {
+	BoardID         pgtype.Int8
+	CursorID        pgtype.Int8
+	CursorCreatedAt pgtype.Timestamp
+	Viewer          pgtype.Text
+	PageLimit       int64
+}
+
+type GetNewThreadsRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetNewThreads(ctx context.Context, arg GetNewThreadsParams) ([]GetNewThreadsRow, error) {
+	rows, err := q.db.Query(ctx, getNewThreads,
+		arg.BoardID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNewThreadsRow
+	for rows.Next() {
+		var i GetNewThreadsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNewThreadsByAddress = `-- name: GetNewThreadsByAddress :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.address = $1::varchar(42)
+AND t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND ($2::bigint IS NULL OR (t.created_at, t.id) < ($3::timestamp, $2::bigint))
+AND (t.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT $5::bigint
+`
+
+type GetNewThreadsByAddressParams struct {
+	Address         string
+	CursorID        pgtype.Int8
+	CursorCreatedAt pgtype.Timestamp
+	Viewer          pgtype.Text
+	PageLimit       int64
+}
+
+type GetNewThreadsByAddressRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetNewThreadsByAddress(ctx context.Context, arg GetNewThreadsByAddressParams) ([]GetNewThreadsByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getNewThreadsByAddress,
+		arg.Address,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNewThreadsByAddressRow
+	for rows.Next() {
+		var i GetNewThreadsByAddressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNewerComments = `-- name: GetNewerComments :many
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.thread_id = $1
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (c.created_at, c.id) > ($2::timestamp, $3::bigint)
+AND (c.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT $5::bigint
+`
+
+type GetNewerCommentsParams struct {
+	ThreadID        int64
+	CursorCreatedAt pgtype.Timestamp
+	CursorID        int64
+	Viewer          pgtype.Text
+	PageLimit       int64
+}
+
+type GetNewerCommentsRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+// Newer comments are walked from the cursor in ascending order.
+func (q *Queries) GetNewerComments(ctx context.Context, arg GetNewerCommentsParams) ([]GetNewerCommentsRow, error) {
+	rows, err := q.db.Query(ctx, getNewerComments,
+		arg.ThreadID,
+		arg.CursorCreatedAt,
+		arg.CursorID,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNewerCommentsRow
+	for rows.Next() {
+		var i GetNewerCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
+			&i.Address,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.RID,
+			&i.RAddress,
+			&i.RContent,
+			&i.RImageFileName,
+			&i.RImageOriginalUrl,
+			&i.RImageOriginalContentType,
+			&i.RImageFormattedUrl,
+			&i.RImageFormattedContentType,
+			&i.RIsDeleted,
+			&i.RCreatedAt,
+			&i.RDeletedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotifications = `-- name: GetNotifications :many
+SELECT id, recipient, type, actor, thread_id, comment_id, read_at, created_at, COUNT(*) OVER() AS full_count
+FROM notifications
+WHERE recipient = $1::varchar(42)
+AND (NOT $2::boolean OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT $3::bigint
+OFFSET $4::bigint
+`
+
+type GetNotificationsParams struct {
+	Recipient  string
+	UnreadOnly bool
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetNotificationsRow struct {
+	ID        int64
+	Recipient string
+	Type      string
+	Actor     string
+	ThreadID  int64
+	CommentID int64
+	ReadAt    pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+	FullCount int64
+}
+
+func (q *Queries) GetNotifications(ctx context.Context, arg GetNotificationsParams) ([]GetNotificationsRow, error) {
+	rows, err := q.db.Query(ctx, getNotifications,
+		arg.Recipient,
+		arg.UnreadOnly,
+		arg.PageLimit,
+		arg.PageOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotificationsRow
+	for rows.Next() {
+		var i GetNotificationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Recipient,
+			&i.Type,
+			&i.Actor,
+			&i.ThreadID,
+			&i.CommentID,
+			&i.ReadAt,
+			&i.CreatedAt,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOlderComments = `-- name: GetOlderComments :many
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.thread_id = $1
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND ($2::bigint IS NULL OR (c.created_at, c.id) < ($3::timestamp, $2::bigint))
+AND (c.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT $5::bigint
+`
+
+type GetOlderCommentsParams struct {
+	ThreadID        int64
+	CursorID        pgtype.Int8
+	CursorCreatedAt pgtype.Timestamp
+	Viewer          pgtype.Text
+	PageLimit       int64
+}
+
+type GetOlderCommentsRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+// Comments are keyset paginated on (created_at, id) from a cursor in either direction.
+// Older comments are walked from the cursor (or the newest comment when the cursor is null).
+func (q *Queries) GetOlderComments(ctx context.Context, arg GetOlderCommentsParams) ([]GetOlderCommentsRow, error) {
+	rows, err := q.db.Query(ctx, getOlderComments,
+		arg.ThreadID,
+		arg.CursorID,
+		arg.CursorCreatedAt,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOlderCommentsRow
+	for rows.Next() {
+		var i GetOlderCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
+			&i.Address,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.RID,
+			&i.RAddress,
+			&i.RContent,
+			&i.RImageFileName,
+			&i.RImageOriginalUrl,
+			&i.RImageOriginalContentType,
+			&i.RImageFormattedUrl,
+			&i.RImageFormattedContentType,
+			&i.RIsDeleted,
+			&i.RCreatedAt,
+			&i.RDeletedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOpenReports = `-- name: GetOpenReports :many
+SELECT
+	target_type,
+	target_id,
+	COUNT(*) AS report_count,
+	array_agg(DISTINCT reason)::text[] AS reasons,
+	COALESCE(array_agg(details ORDER BY created_at ASC) FILTER (WHERE details <> ''), '{}')::text[] AS details,
+	MIN(created_at)::timestamp AS first_reported_at,
+	MAX(created_at)::timestamp AS last_reported_at,
+	COUNT(*) OVER() AS full_count
+FROM reports
+WHERE resolved_at IS NULL
+GROUP BY target_type, target_id
+ORDER BY COUNT(*) DESC, MIN(created_at) ASC
+LIMIT $1::bigint
+OFFSET $2::bigint
+`
+
+type GetOpenReportsParams struct {
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetOpenReportsRow struct {
+	TargetType      string
+	TargetID        string
+	ReportCount     int64
+	Reasons         []string
+	Details         []string
+	FirstReportedAt pgtype.Timestamp
+	LastReportedAt  pgtype.Timestamp
+	FullCount       int64
+}
+
+// Open reports are grouped by target with the most reported targets first
+func (q *Queries) GetOpenReports(ctx context.Context, arg GetOpenReportsParams) ([]GetOpenReportsRow, error) {
+	rows, err := q.db.Query(ctx, getOpenReports, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetOpenReportsRow
+	for rows.Next() {
+		var i GetOpenReportsRow
+		if err := rows.Scan(
+			&i.TargetType,
+			&i.TargetID,
+			&i.ReportCount,
+			&i.Reasons,
+			&i.Details,
+			&i.FirstReportedAt,
+			&i.LastReportedAt,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPinnedThreads = `-- name: GetPinnedThreads :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND t.pinned_until > NOW()
+AND ($1::bigint IS NULL OR t.board_id = $1::bigint)
+AND (t.address = $2::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC
+`
+
+type GetPinnedThreadsParams struct {
+	BoardID pgtype.Int8
+	Viewer  pgtype.Text
+}
+
+type GetPinnedThreadsRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+// Feeds are keyset paginated on (sort key, id) so pages stay stable as new threads arrive.
+// The cursor is null when fetching the first page and the board is null for the feed of every board.
+// pinned threads are left out of the feeds and fetched separately for their first page
+func (q *Queries) GetPinnedThreads(ctx context.Context, arg GetPinnedThreadsParams) ([]GetPinnedThreadsRow, error) {
+	rows, err := q.db.Query(ctx, getPinnedThreads, arg.BoardID, arg.Viewer)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetPinnedThreadsRow
+	for rows.Next() {
+		var i GetPinnedThreadsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getPushSubscriptionsByAddresses = `-- name: GetPushSubscriptionsByAddresses :many
+SELECT endpoint, address, p256dh, auth, created_at
+FROM push_subscriptions
+WHERE address = ANY($1::varchar(42)[])
+`
+
+func (q *Queries) GetPushSubscriptionsByAddresses(ctx context.Context, addresses []string) ([]PushSubscription, error) {
+	rows, err := q.db.Query(ctx, getPushSubscriptionsByAddresses, addresses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []PushSubscription
+	for rows.Next() {
+		var i PushSubscription
+		if err := rows.Scan(
+			&i.Endpoint,
+			&i.Address,
+			&i.P256dh,
+			&i.Auth,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRolesByAddress = `-- name: GetRolesByAddress :many
+SELECT id, address, role, board_id, created_by, created_at FROM roles
+WHERE address = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) GetRolesByAddress(ctx context.Context, address string) ([]Role, error) {
+	rows, err := q.db.Query(ctx, getRolesByAddress, address)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Role
+	for rows.Next() {
+		var i Role
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Role,
+			&i.BoardID,
+			&i.CreatedBy,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThread = `-- name: GetThread :one
+SELECT 
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.id = $1
+AND t.is_deleted = FALSE
+`
+
+type GetThreadRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetThread(ctx context.Context, id int64) (GetThreadRow, error) {
+	row := q.db.QueryRow(ctx, getThread, id)
+	var i GetThreadRow
+	err := row.Scan(
+		&i.ID,
+		&i.Address,
+		&i.Title,
+		&i.Content,
+		&i.ImageFileName,
+		&i.ImageOriginalUrl,
+		&i.ImageOriginalContentType,
+		&i.ImageFormattedUrl,
+		&i.ImageFormattedContentType,
+		&i.Votes,
+		&i.IsDeleted,
+		&i.CreatedAt,
+		&i.DeletedAt,
+		&i.HotScore,
+		&i.ActiveAt,
+		&i.EditedAt,
+		&i.SearchVector,
+		&i.BoardID,
+		&i.IsHidden,
+		&i.DeletedBy,
+		&i.IsLocked,
+		&i.PinnedUntil,
+		&i.ArchivedAt,
+		&i.Address_2,
+		&i.EnsName,
+		&i.EnsAvatarFileName,
+		&i.EnsAvatarOriginalUrl,
+		&i.EnsAvatarOriginalContentType,
+		&i.EnsAvatarFormattedUrl,
+		&i.EnsAvatarFormattedContentType,
+		&i.Reputation,
+		&i.UserCreatedAt,
+		&i.UserUpdatedAt,
+	)
+	return i, err
+}
+
+const getThreadRevisions = `-- name: GetThreadRevisions :many
+SELECT id, thread_id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at, revised_at FROM thread_revisions
+WHERE thread_id = $1
+ORDER BY revised_at DESC, id DESC
+`
+
+func (q *Queries) GetThreadRevisions(ctx context.Context, threadID int64) ([]ThreadRevision, error) {
+	rows, err := q.db.Query(ctx, getThreadRevisions, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ThreadRevision
+	for rows.Next() {
+		var i ThreadRevision
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.CreatedAt,
+			&i.RevisedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadSubscribers = `-- name: GetThreadSubscribers :many
+SELECT address
+FROM thread_subscriptions
+WHERE thread_id = $1::bigint
+`
+
+func (q *Queries) GetThreadSubscribers(ctx context.Context, threadID int64) ([]string, error) {
+	rows, err := q.db.Query(ctx, getThreadSubscribers, threadID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var address string
+		if err := rows.Scan(&address); err != nil {
+			return nil, err
+		}
+		items = append(items, address)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getThreadVotesByAddress = `-- name: GetThreadVotesByAddress :many
+SELECT thread_id, vote
+FROM thread_votes
+WHERE address = $1::varchar(42)
+AND thread_id = ANY($2::bigint[])
+`
+
+type GetThreadVotesByAddressParams struct {
+	Address   string
+	ThreadIds []int64
+}
+
+type GetThreadVotesByAddressRow struct {
+	ThreadID int64
+	Vote     int16
+}
+
+// votes of a single user on a page of threads, missing rows mean the user never voted
+func (q *Queries) GetThreadVotesByAddress(ctx context.Context, arg GetThreadVotesByAddressParams) ([]GetThreadVotesByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getThreadVotesByAddress, arg.Address, arg.ThreadIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetThreadVotesByAddressRow
+	for rows.Next() {
+		var i GetThreadVotesByAddressRow
+		if err := rows.Scan(&i.ThreadID, &i.Vote); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopCommentsByAddress = `-- name: GetTopCommentsByAddress :many
+SELECT
+	c.id, c.thread_id, c.replied_to_comment_id, c.address, c.content, c.image_file_name, c.image_original_url, c.image_original_content_type, c.image_formatted_url, c.image_formatted_content_type, c.votes, c.is_deleted, c.created_at, c.deleted_at, c.edited_at, c.search_vector, c.is_hidden, c.deleted_by,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.address = $1::varchar(42)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND ($2::bigint IS NULL OR (c.votes, c.id) < ($3::bigint, $2::bigint))
+AND (c.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.votes DESC, c.id DESC
+LIMIT $5::bigint
+`
+
+type GetTopCommentsByAddressParams struct {
+	Address     string
+	CursorID    pgtype.Int8
+	CursorVotes pgtype.Int8
+	Viewer      pgtype.Text
+	PageLimit   int64
+}
+
+type GetTopCommentsByAddressRow struct {
+	ID                            int64
+	ThreadID                      int64
+	RepliedToCommentID            pgtype.Int8
+	Address                       string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	RID                           pgtype.Int8
+	RAddress                      pgtype.Text
+	RContent                      pgtype.Text
+	RImageFileName                pgtype.Text
+	RImageOriginalUrl             pgtype.Text
+	RImageOriginalContentType     pgtype.Text
+	RImageFormattedUrl            pgtype.Text
+	RImageFormattedContentType    pgtype.Text
+	RIsDeleted                    pgtype.Bool
+	RCreatedAt                    pgtype.Timestamp
+	RDeletedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetTopCommentsByAddress(ctx context.Context, arg GetTopCommentsByAddressParams) ([]GetTopCommentsByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getTopCommentsByAddress,
+		arg.Address,
+		arg.CursorID,
+		arg.CursorVotes,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopCommentsByAddressRow
+	for rows.Next() {
+		var i GetTopCommentsByAddressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ThreadID,
+			&i.RepliedToCommentID,
+			&i.Address,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.RID,
+			&i.RAddress,
+			&i.RContent,
+			&i.RImageFileName,
+			&i.RImageOriginalUrl,
+			&i.RImageOriginalContentType,
+			&i.RImageFormattedUrl,
+			&i.RImageFormattedContentType,
+			&i.RIsDeleted,
+			&i.RCreatedAt,
+			&i.RDeletedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopThreads = `-- name: GetTopThreads :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND ($1::bigint IS NULL OR t.board_id = $1::bigint)
+AND t.created_at >= $2::timestamp
+AND ($3::bigint IS NULL OR (t.votes, t.id) < ($4::bigint, $3::bigint))
+AND (t.address = $5::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.votes DESC, t.id DESC
+LIMIT $6::bigint
+`
+
+type GetTopThreadsParams struct {
+	BoardID     pgtype.Int8
+	Since       pgtype.Timestamp
+	CursorID    pgtype.Int8
+	CursorVotes pgtype.Int8
+	Viewer      pgtype.Text
+	PageLimit   int64
+}
+
+type GetTopThreadsRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetTopThreads(ctx context.Context, arg GetTopThreadsParams) ([]GetTopThreadsRow, error) {
+	rows, err := q.db.Query(ctx, getTopThreads,
+		arg.BoardID,
+		arg.Since,
+		arg.CursorID,
+		arg.CursorVotes,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopThreadsRow
+	for rows.Next() {
+		var i GetTopThreadsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getTopThreadsByAddress = `-- name: GetTopThreadsByAddress :many
+SELECT
+	t.id, t.address, t.title, t.content, t.image_file_name, t.image_original_url, t.image_original_content_type, t.image_formatted_url, t.image_formatted_content_type, t.votes, t.is_deleted, t.created_at, t.deleted_at, t.hot_score, t.active_at, t.edited_at, t.search_vector, t.board_id, t.is_hidden, t.deleted_by, t.is_locked, t.pinned_until, t.archived_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.address = $1::varchar(42)
+AND t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND ($2::bigint IS NULL OR (t.votes, t.id) < ($3::bigint, $2::bigint))
+AND (t.address = $4::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.votes DESC, t.id DESC
+LIMIT $5::bigint
+`
+
+type GetTopThreadsByAddressParams struct {
+	Address     string
+	CursorID    pgtype.Int8
+	CursorVotes pgtype.Int8
+	Viewer      pgtype.Text
+	PageLimit   int64
+}
+
+type GetTopThreadsByAddressRow struct {
+	ID                            int64
+	Address                       string
+	Title                         string
+	Content                       string
+	ImageFileName                 string
+	ImageOriginalUrl              string
+	ImageOriginalContentType      string
+	ImageFormattedUrl             string
+	ImageFormattedContentType     string
+	Votes                         int64
+	IsDeleted                     bool
+	CreatedAt                     pgtype.Timestamp
+	DeletedAt                     pgtype.Timestamp
+	HotScore                      float64
+	ActiveAt                      pgtype.Timestamp
+	EditedAt                      pgtype.Timestamp
+	SearchVector                  interface{}
+	BoardID                       int64
+	IsHidden                      bool
+	DeletedBy                     pgtype.Text
+	IsLocked                      bool
+	PinnedUntil                   pgtype.Timestamp
+	ArchivedAt                    pgtype.Timestamp
+	Address_2                     string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+func (q *Queries) GetTopThreadsByAddress(ctx context.Context, arg GetTopThreadsByAddressParams) ([]GetTopThreadsByAddressRow, error) {
+	rows, err := q.db.Query(ctx, getTopThreadsByAddress,
+		arg.Address,
+		arg.CursorID,
+		arg.CursorVotes,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTopThreadsByAddressRow
+	for rows.Next() {
+		var i GetTopThreadsByAddressRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Address,
+			&i.Title,
+			&i.Content,
+			&i.ImageFileName,
+			&i.ImageOriginalUrl,
+			&i.ImageOriginalContentType,
+			&i.ImageFormattedUrl,
+			&i.ImageFormattedContentType,
+			&i.Votes,
+			&i.IsDeleted,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.HotScore,
+			&i.ActiveAt,
+			&i.EditedAt,
+			&i.SearchVector,
+			&i.BoardID,
+			&i.IsHidden,
+			&i.DeletedBy,
+			&i.IsLocked,
+			&i.PinnedUntil,
+			&i.ArchivedAt,
+			&i.Address_2,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUndistributedVoteDecisions = `-- name: GetUndistributedVoteDecisions :many
+SELECT d.id, d.type, d.target_id, d.voter, d.author, d.vote, d.reputation, d.accepted, d.reason, d.voted_at, d.distribution_id, d.counted, d.created_at,
+	NOT EXISTS (
+		SELECT 1 FROM vote_decisions l
+		WHERE l.type = d.type AND l.target_id = d.target_id AND l.voter = d.voter
+		AND l.voted_at > d.voted_at
+		AND l.created_at <= $1::timestamp
+	) AS latest,
+	EXISTS (
+		SELECT 1 FROM vote_decisions c
+		WHERE c.type = d.type AND c.target_id = d.target_id AND c.voter = d.voter
+		AND c.counted = TRUE
+	) AS counted_before
+FROM vote_decisions d
+WHERE d.distribution_id IS NULL
+AND d.created_at <= $1::timestamp
+ORDER BY d.id ASC
+`
+
+type GetUndistributedVoteDecisionsRow struct {
+	ID             int64
+	Type           string
+	TargetID       int64
+	Voter          string
+	Author         pgtype.Text
+	Vote           int16
+	Reputation     pgtype.Numeric
+	Accepted       bool
+	Reason         pgtype.Text
+	VotedAt        pgtype.Timestamp
+	DistributionID pgtype.Int8
+	Counted        bool
+	CreatedAt      pgtype.Timestamp
+	Latest         bool
+	CountedBefore  bool
+}
+
+// Every vote decision made up to the cutoff that is not consumed by a distribution.
+// latest is false if the voter changed their vote since, counted_before is true if the vote already counted towards a distribution.
+func (q *Queries) GetUndistributedVoteDecisions(ctx context.Context, cutoff pgtype.Timestamp) ([]GetUndistributedVoteDecisionsRow, error) {
+	rows, err := q.db.Query(ctx, getUndistributedVoteDecisions, cutoff)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetUndistributedVoteDecisionsRow
+	for rows.Next() {
+		var i GetUndistributedVoteDecisionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.TargetID,
+			&i.Voter,
+			&i.Author,
+			&i.Vote,
+			&i.Reputation,
+			&i.Accepted,
+			&i.Reason,
+			&i.VotedAt,
+			&i.DistributionID,
+			&i.Counted,
+			&i.CreatedAt,
+			&i.Latest,
+			&i.CountedBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUser = `-- name: GetUser :one
+SELECT address, ens_name, created_at, updated_at, reputation, ens_avatar_file_name, ens_avatar_original_url, ens_avatar_original_content_type, ens_avatar_formatted_url, ens_avatar_formatted_content_type
+FROM users
+WHERE address = $1
+`
+
+func (q *Queries) GetUser(ctx context.Context, address string) (User, error) {
+	row := q.db.QueryRow(ctx, getUser, address)
+	var i User
+	err := row.Scan(
 		&i.Address,
 		&i.EnsName,
 		&i.CreatedAt,
@@ -699,7 +4093,614 @@ func (q *Queries) GetUser(ctx context.Context, address string) (User, error) {
 		&i.EnsAvatarFormattedUrl,
 		&i.EnsAvatarFormattedContentType,
 	)
-	return i, err
+	return i, err
+}
+
+const getUserStats = `-- name: GetUserStats :one
+SELECT
+	(SELECT COUNT(*) FROM threads t WHERE t.address = $1::varchar(42) AND t.is_deleted = FALSE) AS thread_count,
+	(SELECT COUNT(*) FROM comments c WHERE c.address = $1::varchar(42) AND c.is_deleted = FALSE) AS comment_count,
+	(
+		(SELECT COALESCE(SUM(t.votes), 0) FROM threads t WHERE t.address = $1::varchar(42) AND t.is_deleted = FALSE) +
+		(SELECT COALESCE(SUM(c.votes), 0) FROM comments c WHERE c.address = $1::varchar(42) AND c.is_deleted = FALSE)
+	)::bigint AS votes
+`
+
+type GetUserStatsRow struct {
+	ThreadCount  int64
+	CommentCount int64
+	Votes        int64
+}
+
+// deleted threads and comments do not count towards the activity of a user
+func (q *Queries) GetUserStats(ctx context.Context, address string) (GetUserStatsRow, error) {
+	row := q.db.QueryRow(ctx, getUserStats, address)
+	var i GetUserStatsRow
+	err := row.Scan(&i.ThreadCount, &i.CommentCount, &i.Votes)
+	return i, err
+}
+
+const getVoteDecisionsInRange = `-- name: GetVoteDecisionsInRange :many
+SELECT d.id, d.type, d.target_id, d.voter, d.author, d.vote, d.reputation, d.accepted, d.reason, d.voted_at, d.distribution_id, d.counted, d.created_at,
+	NOT EXISTS (
+		SELECT 1 FROM vote_decisions l
+		WHERE l.type = d.type AND l.target_id = d.target_id AND l.voter = d.voter
+		AND l.voted_at > d.voted_at
+		AND ($1::timestamp IS NULL OR l.voted_at <= $1::timestamp)
+		AND ($2::bigint IS NULL OR l.id >= $2::bigint)
+		AND ($3::bigint IS NULL OR l.id <= $3::bigint)
+	) AS latest,
+	EXISTS (
+		SELECT 1 FROM vote_decisions c
+		WHERE c.type = d.type AND c.target_id = d.target_id AND c.voter = d.voter
+		AND c.counted = TRUE
+		AND (c.voted_at < $4::timestamp OR c.id < $2::bigint)
+	) AS counted_before
+FROM vote_decisions d
+WHERE ($4::timestamp IS NULL OR d.voted_at >= $4::timestamp)
+AND ($1::timestamp IS NULL OR d.voted_at <= $1::timestamp)
+AND ($2::bigint IS NULL OR d.id >= $2::bigint)
+AND ($3::bigint IS NULL OR d.id <= $3::bigint)
+ORDER BY d.id ASC
+`
+
+type GetVoteDecisionsInRangeParams struct {
+	VotedBefore pgtype.Timestamp
+	FromID      pgtype.Int8
+	ToID        pgtype.Int8
+	VotedAfter  pgtype.Timestamp
+}
+
+type GetVoteDecisionsInRangeRow struct {
+	ID             int64
+	Type           string
+	TargetID       int64
+	Voter          string
+	Author         pgtype.Text
+	Vote           int16
+	Reputation     pgtype.Numeric
+	Accepted       bool
+	Reason         pgtype.Text
+	VotedAt        pgtype.Timestamp
+	DistributionID pgtype.Int8
+	Counted        bool
+	CreatedAt      pgtype.Timestamp
+	Latest         bool
+	CountedBefore  bool
+}
+
+// The vote decisions cast in the window and made in the id range, null bounds are left open.
+// latest and counted_before are evaluated as if a single round covered the range right after the rounds before it.
+func (q *Queries) GetVoteDecisionsInRange(ctx context.Context, arg GetVoteDecisionsInRangeParams) ([]GetVoteDecisionsInRangeRow, error) {
+	rows, err := q.db.Query(ctx, getVoteDecisionsInRange,
+		arg.VotedBefore,
+		arg.FromID,
+		arg.ToID,
+		arg.VotedAfter,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetVoteDecisionsInRangeRow
+	for rows.Next() {
+		var i GetVoteDecisionsInRangeRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Type,
+			&i.TargetID,
+			&i.Voter,
+			&i.Author,
+			&i.Vote,
+			&i.Reputation,
+			&i.Accepted,
+			&i.Reason,
+			&i.VotedAt,
+			&i.DistributionID,
+			&i.Counted,
+			&i.CreatedAt,
+			&i.Latest,
+			&i.CountedBefore,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhookDeliveries = `-- name: GetWebhookDeliveries :many
+SELECT id, webhook_id, event_type, event_id, status_code, error, attempts, succeeded, created_at, COUNT(*) OVER() AS full_count
+FROM webhook_deliveries
+WHERE webhook_id = $1::bigint
+ORDER BY created_at DESC, id DESC
+LIMIT $2::bigint
+OFFSET $3::bigint
+`
+
+type GetWebhookDeliveriesParams struct {
+	WebhookID  int64
+	PageLimit  int64
+	PageOffset int64
+}
+
+type GetWebhookDeliveriesRow struct {
+	ID         int64
+	WebhookID  int64
+	EventType  string
+	EventID    string
+	StatusCode pgtype.Int4
+	Error      string
+	Attempts   int32
+	Succeeded  bool
+	CreatedAt  pgtype.Timestamp
+	FullCount  int64
+}
+
+func (q *Queries) GetWebhookDeliveries(ctx context.Context, arg GetWebhookDeliveriesParams) ([]GetWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, getWebhookDeliveries, arg.WebhookID, arg.PageLimit, arg.PageOffset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWebhookDeliveriesRow
+	for rows.Next() {
+		var i GetWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.EventType,
+			&i.EventID,
+			&i.StatusCode,
+			&i.Error,
+			&i.Attempts,
+			&i.Succeeded,
+			&i.CreatedAt,
+			&i.FullCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWebhooks = `-- name: GetWebhooks :many
+SELECT id, url, events, secret, created_by, consecutive_failures, disabled_at, created_at
+FROM webhooks
+ORDER BY id ASC
+`
+
+func (q *Queries) GetWebhooks(ctx context.Context) ([]Webhook, error) {
+	rows, err := q.db.Query(ctx, getWebhooks)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Webhook
+	for rows.Next() {
+		var i Webhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.Url,
+			&i.Events,
+			&i.Secret,
+			&i.CreatedBy,
+			&i.ConsecutiveFailures,
+			&i.DisabledAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const incrementWebhookFailures = `-- name: IncrementWebhookFailures :one
+UPDATE webhooks
+SET
+	consecutive_failures = consecutive_failures + 1,
+	disabled_at = CASE
+		WHEN disabled_at IS NULL AND consecutive_failures + 1 >= $1::bigint THEN NOW()
+		ELSE disabled_at
+	END
+WHERE id = $2::bigint
+RETURNING disabled_at IS NOT NULL AS is_disabled
+`
+
+type IncrementWebhookFailuresParams struct {
+	MaxFailures int64
+	ID          int64
+}
+
+// the webhook is disabled once it fails max_failures times in a row
+func (q *Queries) IncrementWebhookFailures(ctx context.Context, arg IncrementWebhookFailuresParams) (bool, error) {
+	row := q.db.QueryRow(ctx, incrementWebhookFailures, arg.MaxFailures, arg.ID)
+	var is_disabled bool
+	err := row.Scan(&is_disabled)
+	return is_disabled, err
+}
+
+const readAllNotifications = `-- name: ReadAllNotifications :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE recipient = $1::varchar(42)
+AND read_at IS NULL
+`
+
+func (q *Queries) ReadAllNotifications(ctx context.Context, recipient string) (int64, error) {
+	result, err := q.db.Exec(ctx, readAllNotifications, recipient)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const readNotification = `-- name: ReadNotification :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = $1::bigint
+AND recipient = $2::varchar(42)
+`
+
+type ReadNotificationParams struct {
+	ID        int64
+	Recipient string
+}
+
+// notifications that were already read keep their original read_at
+func (q *Queries) ReadNotification(ctx context.Context, arg ReadNotificationParams) (int64, error) {
+	result, err := q.db.Exec(ctx, readNotification, arg.ID, arg.Recipient)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const resetWebhookFailures = `-- name: ResetWebhookFailures :exec
+UPDATE webhooks
+SET consecutive_failures = 0
+WHERE id = $1::bigint
+`
+
+func (q *Queries) ResetWebhookFailures(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, resetWebhookFailures, id)
+	return err
+}
+
+const resolveReports = `-- name: ResolveReports :execrows
+UPDATE reports
+SET resolved_at = NOW(), resolved_by = $1::varchar(42), resolution = $2::varchar(16)
+WHERE target_type = $3::varchar(16)
+AND target_id = $4::varchar(42)
+AND resolved_at IS NULL
+`
+
+type ResolveReportsParams struct {
+	ResolvedBy string
+	Resolution string
+	TargetType string
+	TargetID   string
+}
+
+func (q *Queries) ResolveReports(ctx context.Context, arg ResolveReportsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, resolveReports,
+		arg.ResolvedBy,
+		arg.Resolution,
+		arg.TargetType,
+		arg.TargetID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreComment = `-- name: RestoreComment :execrows
+UPDATE comments
+SET is_deleted = FALSE, deleted_at = NULL, deleted_by = NULL
+WHERE id = $1
+AND is_deleted = TRUE
+`
+
+func (q *Queries) RestoreComment(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreComment, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const restoreThread = `-- name: RestoreThread :execrows
+UPDATE threads
+SET is_deleted = FALSE, deleted_at = NULL, deleted_by = NULL
+WHERE id = $1
+AND is_deleted = TRUE
+`
+
+func (q *Queries) RestoreThread(ctx context.Context, id int64) (int64, error) {
+	result, err := q.db.Exec(ctx, restoreThread, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const revokeBans = `-- name: RevokeBans :execrows
+UPDATE bans
+SET revoked_at = NOW(), revoked_by = $1::varchar(42)
+WHERE address = $2::varchar(42)
+AND revoked_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+`
+
+type RevokeBansParams struct {
+	RevokedBy string
+	Address   string
+}
+
+func (q *Queries) RevokeBans(ctx context.Context, arg RevokeBansParams) (int64, error) {
+	result, err := q.db.Exec(ctx, revokeBans, arg.RevokedBy, arg.Address)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const search = `-- name: Search :many
+WITH search_query AS (
+	SELECT websearch_to_tsquery('english', $1::text) AS q
+), results AS (
+	SELECT
+		'thread'::text AS kind,
+		t.id,
+		t.id AS thread_id,
+		t.title,
+		t.title || ' ' || t.content AS body,
+		t.address,
+		t.created_at,
+		ts_rank(t.search_vector, search_query.q)::float8 AS rank,
+		search_query.q AS q
+	FROM threads t, search_query
+	WHERE t.search_vector @@ search_query.q
+	AND t.is_deleted = FALSE
+	AND t.is_hidden = FALSE
+	UNION ALL
+	SELECT
+		'comment'::text AS kind,
+		c.id,
+		c.thread_id,
+		t.title,
+		c.content AS body,
+		c.address,
+		c.created_at,
+		ts_rank(c.search_vector, search_query.q)::float8 AS rank,
+		search_query.q AS q
+	FROM comments c
+	INNER JOIN threads t ON c.thread_id = t.id, search_query
+	WHERE c.search_vector @@ search_query.q
+	AND c.is_deleted = FALSE
+	AND c.is_hidden = FALSE
+	AND t.is_deleted = FALSE
+	AND t.is_hidden = FALSE
+)
+SELECT
+	r.kind,
+	r.id,
+	r.thread_id,
+	r.title,
+	ts_headline('english', translate(r.body, chr(2) || chr(3), ''), r.q, 'StartSel="' || chr(2) || '", StopSel="' || chr(3) || '", MaxFragments=2, MaxWords=30, MinWords=10')::text AS snippet,
+	r.created_at,
+	r.rank,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM results r
+INNER JOIN users u on r.address = u.address
+WHERE ($2::varchar IS NULL OR r.address = $2::varchar)
+AND ($3::timestamp IS NULL OR r.created_at >= $3::timestamp)
+AND ($4::timestamp IS NULL OR r.created_at < $4::timestamp)
+AND ($5::bigint IS NULL OR (r.rank, r.kind, r.id) < ($6::float8, $7::text, $5::bigint))
+AND (r.address = $8::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = r.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY r.rank DESC, r.kind DESC, r.id DESC
+LIMIT $9::bigint
+`
+
+type SearchParams struct {
+	Query         string
+	Author        pgtype.Text
+	CreatedAfter  pgtype.Timestamp
+	CreatedBefore pgtype.Timestamp
+	CursorID      pgtype.Int8
+	CursorRank    pgtype.Float8
+	CursorKind    pgtype.Text
+	Viewer        pgtype.Text
+	PageLimit     int64
+}
+
+type SearchRow struct {
+	Kind                          string
+	ID                            int64
+	ThreadID                      int64
+	Title                         string
+	Snippet                       string
+	CreatedAt                     pgtype.Timestamp
+	Rank                          float64
+	Address                       string
+	EnsName                       pgtype.Text
+	EnsAvatarFileName             pgtype.Text
+	EnsAvatarOriginalUrl          pgtype.Text
+	EnsAvatarOriginalContentType  pgtype.Text
+	EnsAvatarFormattedUrl         pgtype.Text
+	EnsAvatarFormattedContentType pgtype.Text
+	Reputation                    pgtype.Numeric
+	UserCreatedAt                 pgtype.Timestamp
+	UserUpdatedAt                 pgtype.Timestamp
+}
+
+// Threads and comments are searched together and ranked against each other.
+// Results are keyset paginated on (rank, kind, id) and snippets are only highlighted for the returned page.
+func (q *Queries) Search(ctx context.Context, arg SearchParams) ([]SearchRow, error) {
+	rows, err := q.db.Query(ctx, search,
+		arg.Query,
+		arg.Author,
+		arg.CreatedAfter,
+		arg.CreatedBefore,
+		arg.CursorID,
+		arg.CursorRank,
+		arg.CursorKind,
+		arg.Viewer,
+		arg.PageLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchRow
+	for rows.Next() {
+		var i SearchRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.ID,
+			&i.ThreadID,
+			&i.Title,
+			&i.Snippet,
+			&i.CreatedAt,
+			&i.Rank,
+			&i.Address,
+			&i.EnsName,
+			&i.EnsAvatarFileName,
+			&i.EnsAvatarOriginalUrl,
+			&i.EnsAvatarOriginalContentType,
+			&i.EnsAvatarFormattedUrl,
+			&i.EnsAvatarFormattedContentType,
+			&i.Reputation,
+			&i.UserCreatedAt,
+			&i.UserUpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setCommentHidden = `-- name: SetCommentHidden :exec
+UPDATE comments
+SET is_hidden = $1::boolean
+WHERE id = $2::bigint
+`
+
+type SetCommentHiddenParams struct {
+	IsHidden bool
+	ID       int64
+}
+
+func (q *Queries) SetCommentHidden(ctx context.Context, arg SetCommentHiddenParams) error {
+	_, err := q.db.Exec(ctx, setCommentHidden, arg.IsHidden, arg.ID)
+	return err
+}
+
+const setThreadArchived = `-- name: SetThreadArchived :execrows
+UPDATE threads
+SET archived_at = CASE WHEN $1::boolean THEN COALESCE(archived_at, NOW()) ELSE NULL END
+WHERE id = $2::bigint
+AND is_deleted = FALSE
+`
+
+type SetThreadArchivedParams struct {
+	IsArchived bool
+	ID         int64
+}
+
+func (q *Queries) SetThreadArchived(ctx context.Context, arg SetThreadArchivedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setThreadArchived, arg.IsArchived, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setThreadHidden = `-- name: SetThreadHidden :exec
+UPDATE threads
+SET is_hidden = $1::boolean
+WHERE id = $2::bigint
+`
+
+type SetThreadHiddenParams struct {
+	IsHidden bool
+	ID       int64
+}
+
+func (q *Queries) SetThreadHidden(ctx context.Context, arg SetThreadHiddenParams) error {
+	_, err := q.db.Exec(ctx, setThreadHidden, arg.IsHidden, arg.ID)
+	return err
+}
+
+const setThreadLocked = `-- name: SetThreadLocked :execrows
+UPDATE threads
+SET is_locked = $1::boolean
+WHERE id = $2::bigint
+AND is_deleted = FALSE
+`
+
+type SetThreadLockedParams struct {
+	IsLocked bool
+	ID       int64
+}
+
+func (q *Queries) SetThreadLocked(ctx context.Context, arg SetThreadLockedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setThreadLocked, arg.IsLocked, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setThreadPinned = `-- name: SetThreadPinned :execrows
+UPDATE threads
+SET pinned_until = $1::timestamp
+WHERE id = $2::bigint
+AND is_deleted = FALSE
+`
+
+type SetThreadPinnedParams struct {
+	PinnedUntil pgtype.Timestamp
+	ID          int64
+}
+
+func (q *Queries) SetThreadPinned(ctx context.Context, arg SetThreadPinnedParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setThreadPinned, arg.PinnedUntil, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const updateChallenge = `-- name: UpdateChallenge :exec
@@ -720,6 +4721,96 @@ func (q *Queries) UpdateChallenge(ctx context.Context, arg UpdateChallengeParams
 	return err
 }
 
+const updateComment = `-- name: UpdateComment :execrows
+UPDATE comments
+SET content = $2,
+	image_file_name = $3,
+	image_original_url = $4,
+	image_original_content_type = $5,
+	image_formatted_url = $6,
+	image_formatted_content_type = $7,
+	edited_at = NOW()
+WHERE id = $1 AND is_deleted = FALSE
+`
+
+type UpdateCommentParams struct {
+	ID                        int64
+	Content                   string
+	ImageFileName             string
+	ImageOriginalUrl          string
+	ImageOriginalContentType  string
+	ImageFormattedUrl         string
+	ImageFormattedContentType string
+}
+
+func (q *Queries) UpdateComment(ctx context.Context, arg UpdateCommentParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateComment,
+		arg.ID,
+		arg.Content,
+		arg.ImageFileName,
+		arg.ImageOriginalUrl,
+		arg.ImageOriginalContentType,
+		arg.ImageFormattedUrl,
+		arg.ImageFormattedContentType,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateThread = `-- name: UpdateThread :execrows
+UPDATE threads
+SET title = $2,
+	content = $3,
+	image_file_name = $4,
+	image_original_url = $5,
+	image_original_content_type = $6,
+	image_formatted_url = $7,
+	image_formatted_content_type = $8,
+	edited_at = NOW()
+WHERE id = $1 AND is_deleted = FALSE
+`
+
+type UpdateThreadParams struct {
+	ID                        int64
+	Title                     string
+	Content                   string
+	ImageFileName             string
+	ImageOriginalUrl          string
+	ImageOriginalContentType  string
+	ImageFormattedUrl         string
+	ImageFormattedContentType string
+}
+
+func (q *Queries) UpdateThread(ctx context.Context, arg UpdateThreadParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateThread,
+		arg.ID,
+		arg.Title,
+		arg.Content,
+		arg.ImageFileName,
+		arg.ImageOriginalUrl,
+		arg.ImageOriginalContentType,
+		arg.ImageFormattedUrl,
+		arg.ImageFormattedContentType,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateThreadActivity = `-- name: UpdateThreadActivity :exec
+UPDATE threads
+SET active_at = NOW()
+WHERE id = $1
+`
+
+func (q *Queries) UpdateThreadActivity(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, updateThreadActivity, id)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :exec
 UPDATE users
 SET
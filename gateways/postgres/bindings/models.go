@@ -8,6 +8,40 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type Allocation struct {
+	DistributionID int64
+	Address        string
+	Amount         pgtype.Numeric
+	Votes          int64
+}
+
+type Ban struct {
+	ID        int64
+	Address   string
+	Type      string
+	Reason    string
+	ExpiresAt pgtype.Timestamp
+	IssuedBy  string
+	CreatedAt pgtype.Timestamp
+	RevokedAt pgtype.Timestamp
+	RevokedBy pgtype.Text
+}
+
+type Board struct {
+	ID                        int64
+	Slug                      string
+	Title                     string
+	Description               string
+	Rules                     string
+	CreatedBy                 string
+	ImageFileName             pgtype.Text
+	ImageOriginalUrl          pgtype.Text
+	ImageOriginalContentType  pgtype.Text
+	ImageFormattedUrl         pgtype.Text
+	ImageFormattedContentType pgtype.Text
+	CreatedAt                 pgtype.Timestamp
+}
+
 type Challenge struct {
 	Address   string
 	Message   string
@@ -29,6 +63,23 @@ type Comment struct {
 	IsDeleted                 bool
 	CreatedAt                 pgtype.Timestamp
 	DeletedAt                 pgtype.Timestamp
+	EditedAt                  pgtype.Timestamp
+	SearchVector              interface{}
+	IsHidden                  bool
+	DeletedBy                 pgtype.Text
+}
+
+type CommentRevision struct {
+	ID                        int64
+	CommentID                 int64
+	Content                   string
+	ImageFileName             string
+	ImageOriginalUrl          string
+	ImageOriginalContentType  string
+	ImageFormattedUrl         string
+	ImageFormattedContentType string
+	CreatedAt                 pgtype.Timestamp
+	RevisedAt                 pgtype.Timestamp
 }
 
 type CommentVote struct {
@@ -39,12 +90,99 @@ type CommentVote struct {
 	Vote      int16
 }
 
+type Distribution struct {
+	ID           int64
+	Round        pgtype.Timestamp
+	Votes        int64
+	Amount       pgtype.Numeric
+	CreatedAt    pgtype.Timestamp
+	MerkleRoot   string
+	Participants int64
+}
+
 type IndexerProgress struct {
 	Version          string
 	LastIndexedBlock pgtype.Numeric
 	IndexedOn        pgtype.Timestamp
 }
 
+type MerkleTree struct {
+	DistributionID int64
+	Nodes          []string
+}
+
+type ModerationLog struct {
+	ID         int64
+	Action     string
+	Actor      string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Before     []byte
+	After      []byte
+	CreatedAt  pgtype.Timestamp
+}
+
+type Notification struct {
+	ID        int64
+	Recipient string
+	Type      string
+	Actor     string
+	ThreadID  int64
+	CommentID int64
+	ReadAt    pgtype.Timestamp
+	CreatedAt pgtype.Timestamp
+}
+
+type PushSubscription struct {
+	Endpoint  string
+	Address   string
+	P256dh    string
+	Auth      string
+	CreatedAt pgtype.Timestamp
+}
+
+type Report struct {
+	ID         int64
+	Reporter   string
+	TargetType string
+	TargetID   string
+	Reason     string
+	Details    string
+	CreatedAt  pgtype.Timestamp
+	ResolvedAt pgtype.Timestamp
+	ResolvedBy pgtype.Text
+	Resolution pgtype.Text
+}
+
+type Restoration struct {
+	ID         int64
+	TargetType string
+	TargetID   int64
+	RestoredBy string
+	Reason     string
+	CreatedAt  pgtype.Timestamp
+}
+
+type Role struct {
+	ID        int64
+	Address   string
+	Role      string
+	BoardID   pgtype.Int8
+	CreatedBy string
+	CreatedAt pgtype.Timestamp
+}
+
+type RoleChange struct {
+	ID        int64
+	Address   string
+	Role      string
+	BoardID   pgtype.Int8
+	Action    string
+	ChangedBy string
+	CreatedAt pgtype.Timestamp
+}
+
 type Thread struct {
 	ID                        int64
 	Address                   string
@@ -59,6 +197,36 @@ type Thread struct {
 	IsDeleted                 bool
 	CreatedAt                 pgtype.Timestamp
 	DeletedAt                 pgtype.Timestamp
+	HotScore                  float64
+	ActiveAt                  pgtype.Timestamp
+	EditedAt                  pgtype.Timestamp
+	SearchVector              interface{}
+	BoardID                   int64
+	IsHidden                  bool
+	DeletedBy                 pgtype.Text
+	IsLocked                  bool
+	PinnedUntil               pgtype.Timestamp
+	ArchivedAt                pgtype.Timestamp
+}
+
+type ThreadRevision struct {
+	ID                        int64
+	ThreadID                  int64
+	Title                     string
+	Content                   string
+	ImageFileName             string
+	ImageOriginalUrl          string
+	ImageOriginalContentType  string
+	ImageFormattedUrl         string
+	ImageFormattedContentType string
+	CreatedAt                 pgtype.Timestamp
+	RevisedAt                 pgtype.Timestamp
+}
+
+type ThreadSubscription struct {
+	Address   string
+	ThreadID  int64
+	CreatedAt pgtype.Timestamp
 }
 
 type ThreadVote struct {
@@ -90,3 +258,42 @@ type User struct {
 	EnsAvatarFormattedUrl         pgtype.Text
 	EnsAvatarFormattedContentType pgtype.Text
 }
+
+type VoteDecision struct {
+	ID             int64
+	Type           string
+	TargetID       int64
+	Voter          string
+	Author         pgtype.Text
+	Vote           int16
+	Reputation     pgtype.Numeric
+	Accepted       bool
+	Reason         pgtype.Text
+	VotedAt        pgtype.Timestamp
+	DistributionID pgtype.Int8
+	Counted        bool
+	CreatedAt      pgtype.Timestamp
+}
+
+type Webhook struct {
+	ID                  int64
+	Url                 string
+	Events              []string
+	Secret              string
+	CreatedBy           string
+	ConsecutiveFailures int64
+	DisabledAt          pgtype.Timestamp
+	CreatedAt           pgtype.Timestamp
+}
+
+type WebhookDelivery struct {
+	ID         int64
+	WebhookID  int64
+	EventType  string
+	EventID    string
+	StatusCode pgtype.Int4
+	Error      string
+	Attempts   int32
+	Succeeded  bool
+	CreatedAt  pgtype.Timestamp
+}
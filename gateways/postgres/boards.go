@@ -0,0 +1,97 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *postgresGateway) CreateBoard(
+	ctx context.Context,
+	slug string,
+	title string,
+	description string,
+	rules string,
+	createdBy string,
+	image *entities.Image,
+) (entities.Board, error) {
+	params := bindings.CreateBoardParams{
+		Slug:        slug,
+		Title:       title,
+		Description: description,
+		Rules:       rules,
+		CreatedBy:   createdBy,
+	}
+	if image != nil {
+		params.ImageFileName = pgtype.Text{String: image.FileName(), Valid: true}
+		params.ImageOriginalUrl = pgtype.Text{String: image.OriginalURL(), Valid: true}
+		params.ImageOriginalContentType = pgtype.Text{String: image.OriginalContentType(), Valid: true}
+		params.ImageFormattedUrl = pgtype.Text{String: image.FormattedURL(), Valid: true}
+		params.ImageFormattedContentType = pgtype.Text{String: image.FormattedContentType(), Valid: true}
+	}
+
+	if _, err := p.queries.CreateBoard(ctx, params); err != nil {
+		return entities.Board{}, err
+	}
+
+	return p.GetBoardBySlug(ctx, slug)
+}
+
+func (p *postgresGateway) GetBoards(ctx context.Context) ([]entities.Board, error) {
+	dbBoards, err := p.queries.GetBoards(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	boards := []entities.Board{}
+	for _, dbBoard := range dbBoards {
+		boards = append(boards, toBoard(dbBoard))
+	}
+
+	return boards, nil
+}
+
+func (p *postgresGateway) GetBoardBySlug(ctx context.Context, slug string) (entities.Board, error) {
+	dbBoard, err := p.queries.GetBoardBySlug(ctx, slug)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Board{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		return entities.Board{}, err
+	}
+
+	return toBoard(dbBoard), nil
+}
+
+func toBoard(dbBoard bindings.Board) entities.Board {
+	var image *entities.Image
+	if dbBoard.ImageOriginalUrl.Valid {
+		boardImage := entities.NewImage(
+			dbBoard.ImageFileName.String,
+			dbBoard.ImageOriginalUrl.String,
+			dbBoard.ImageOriginalContentType.String,
+			dbBoard.ImageFormattedUrl.String,
+			dbBoard.ImageFormattedContentType.String,
+		)
+		image = &boardImage
+	}
+
+	return entities.NewBoard(entities.BoardParams{
+		Id:          dbBoard.ID,
+		Slug:        dbBoard.Slug,
+		Title:       dbBoard.Title,
+		Description: dbBoard.Description,
+		Rules:       dbBoard.Rules,
+		CreatedBy:   dbBoard.CreatedBy,
+		Image:       image,
+		CreatedAt:   dbBoard.CreatedAt.Time,
+	})
+}
@@ -3,10 +3,12 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
 	"github.com/daochanio/backend/gateways/postgres/bindings"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -28,7 +30,17 @@ func (p *postgresGateway) CreateComment(
 		rep.Int64 = *repliedToCommentId
 	}
 
-	id, err := p.queries.CreateComment(ctx, bindings.CreateCommentParams{
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	id, err := qtx.CreateComment(ctx, bindings.CreateCommentParams{
 		ThreadID:                  threadId,
 		Address:                   address,
 		RepliedToCommentID:        rep,
@@ -44,14 +56,30 @@ func (p *postgresGateway) CreateComment(
 		return entities.Comment{}, err
 	}
 
+	// keep track of the latest comment for the active thread feed
+	if err := qtx.UpdateThreadActivity(ctx, threadId); err != nil {
+		return entities.Comment{}, fmt.Errorf("failed to update thread activity: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Comment{}, err
+	}
+
 	return p.GetCommentById(ctx, id)
 }
 
-func (p *postgresGateway) GetComments(ctx context.Context, threadId int64, offset int64, limit int64) ([]entities.Comment, int64, error) {
+// comments of shadow banned authors are only returned to the author, an empty viewer matches no author
+func (p *postgresGateway) GetComments(ctx context.Context, threadId int64, offset int64, limit int64, viewer *string) ([]entities.Comment, int64, error) {
+	address := ""
+	if viewer != nil {
+		address = *viewer
+	}
+
 	dbComments, err := p.queries.GetComments(ctx, bindings.GetCommentsParams{
 		ThreadID: threadId,
 		Column2:  offset,
 		Column3:  limit,
+		Address:  address,
 	})
 
 	if err != nil {
@@ -100,8 +128,11 @@ func (p *postgresGateway) GetComments(ctx context.Context, threadId int64, offse
 			User:             user,
 			RepliedToComment: repliedToComment,
 			IsDeleted:        dbComment.IsDeleted,
+			IsHidden:         dbComment.IsHidden,
 			CreatedAt:        dbComment.CreatedAt.Time,
 			DeletedAt:        deletedAt,
+			DeletedBy:        toStringPtr(dbComment.DeletedBy),
+			EditedAt:         toTimePtr(dbComment.EditedAt),
 			Votes:            dbComment.Votes,
 		})
 
@@ -110,6 +141,145 @@ func (p *postgresGateway) GetComments(ctx context.Context, threadId int64, offse
 	return comments, count, nil
 }
 
+func (p *postgresGateway) GetCommentsByCursor(ctx context.Context, spec gateways.CommentsSpec) ([]entities.Comment, error) {
+	comments := []entities.Comment{}
+
+	// without a cursor we start from the newest comment
+	if spec.Cursor == nil || spec.Cursor.Direction() == entities.NextCursorDirection {
+		params := bindings.GetOlderCommentsParams{
+			ThreadID:  spec.ThreadId,
+			Viewer:    toText(spec.Viewer),
+			PageLimit: spec.Limit,
+		}
+		if spec.Cursor != nil {
+			params.CursorID = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+			params.CursorCreatedAt = pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true}
+		}
+
+		rows, err := p.queries.GetOlderComments(ctx, params)
+
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			comments = append(comments, toComment(bindings.GetCommentRow(row)))
+		}
+
+		return comments, nil
+	}
+
+	rows, err := p.queries.GetNewerComments(ctx, bindings.GetNewerCommentsParams{
+		ThreadID:        spec.ThreadId,
+		CursorCreatedAt: pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true},
+		CursorID:        spec.Cursor.Id(),
+		Viewer:          toText(spec.Viewer),
+		PageLimit:       spec.Limit,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		comments = append(comments, toComment(bindings.GetCommentRow(row)))
+	}
+
+	return comments, nil
+}
+
+func (p *postgresGateway) GetCommentsByAddress(ctx context.Context, spec gateways.ActivitySpec) ([]entities.Comment, error) {
+	cursorId := pgtype.Int8{}
+	if spec.Cursor != nil {
+		cursorId = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+	}
+
+	comments := []entities.Comment{}
+	switch spec.Sort {
+	case entities.NewActivitySort:
+		cursorCreatedAt := pgtype.Timestamp{}
+		if spec.Cursor != nil {
+			cursorCreatedAt = pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true}
+		}
+		rows, err := p.queries.GetNewCommentsByAddress(ctx, bindings.GetNewCommentsByAddressParams{
+			Address:         spec.Address,
+			CursorID:        cursorId,
+			CursorCreatedAt: cursorCreatedAt,
+			Viewer:          toText(spec.Viewer),
+			PageLimit:       spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			comments = append(comments, toComment(bindings.GetCommentRow(row)))
+		}
+	case entities.TopActivitySort:
+		cursorVotes := pgtype.Int8{}
+		if spec.Cursor != nil {
+			cursorVotes = pgtype.Int8{Int64: spec.Cursor.Votes(), Valid: true}
+		}
+		rows, err := p.queries.GetTopCommentsByAddress(ctx, bindings.GetTopCommentsByAddressParams{
+			Address:     spec.Address,
+			CursorID:    cursorId,
+			CursorVotes: cursorVotes,
+			Viewer:      toText(spec.Viewer),
+			PageLimit:   spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			comments = append(comments, toComment(bindings.GetCommentRow(row)))
+		}
+	default:
+		return nil, fmt.Errorf("invalid activity sort: %v", spec.Sort)
+	}
+
+	return comments, nil
+}
+
+func (p *postgresGateway) GetCommentTree(ctx context.Context, spec gateways.CommentTreeSpec) ([]entities.Comment, error) {
+	params := bindings.GetCommentTreeParams{
+		ThreadID:   spec.ThreadId,
+		PageLimit:  spec.Limit,
+		MaxDepth:   spec.MaxDepth,
+		ReplyLimit: spec.ReplyLimit,
+		Viewer:     toText(spec.Viewer),
+	}
+	if spec.ParentId != nil {
+		params.ParentID = pgtype.Int8{Int64: *spec.ParentId, Valid: true}
+	}
+	if spec.Cursor != nil {
+		params.CursorID = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+		params.CursorCreatedAt = pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true}
+	}
+
+	rows, err := p.queries.GetCommentTree(ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// nested replies are grouped by the comment they reply to so the tree can be built from the roots down
+	roots := []bindings.GetCommentTreeRow{}
+	replies := map[int64][]bindings.GetCommentTreeRow{}
+	for _, row := range rows {
+		if row.Depth == 1 {
+			roots = append(roots, row)
+		} else {
+			replies[row.RepliedToCommentID.Int64] = append(replies[row.RepliedToCommentID.Int64], row)
+		}
+	}
+
+	comments := []entities.Comment{}
+	for _, root := range roots {
+		comments = append(comments, toCommentTree(root, replies, spec.MaxDepth))
+	}
+
+	return comments, nil
+}
+
 func (p *postgresGateway) GetCommentById(ctx context.Context, id int64) (entities.Comment, error) {
 	dbComment, err := p.queries.GetComment(ctx, id)
 
@@ -121,6 +291,134 @@ func (p *postgresGateway) GetCommentById(ctx context.Context, id int64) (entitie
 		return entities.Comment{}, err
 	}
 
+	return toComment(dbComment), nil
+}
+
+// The current version of the comment is kept as a revision in the same transaction as the edit
+func (p *postgresGateway) UpdateComment(ctx context.Context, id int64, content string, image *entities.Image) (entities.Comment, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	if err := qtx.CreateCommentRevision(ctx, id); err != nil {
+		return entities.Comment{}, fmt.Errorf("failed to create comment revision: %w", err)
+	}
+
+	count, err := qtx.UpdateComment(ctx, bindings.UpdateCommentParams{
+		ID:                        id,
+		Content:                   content,
+		ImageFileName:             image.FileName(),
+		ImageOriginalUrl:          image.OriginalURL(),
+		ImageOriginalContentType:  image.OriginalContentType(),
+		ImageFormattedUrl:         image.FormattedURL(),
+		ImageFormattedContentType: image.FormattedContentType(),
+	})
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	if count == 0 {
+		return entities.Comment{}, common.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Comment{}, err
+	}
+
+	return p.GetCommentById(ctx, id)
+}
+
+// The log is nil when authors delete their own comment
+func (p *postgresGateway) DeleteComment(ctx context.Context, id int64, deletedBy string, log *entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	_, err = qtx.DeleteComment(ctx, bindings.DeleteCommentParams{
+		DeletedBy: deletedBy,
+		ID:        id,
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return common.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if log != nil {
+		if err := createModerationLog(ctx, qtx, *log); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Votes are recomputed in the same transaction since they keep being recorded while the comment is deleted.
+// The log is nil when authors restore their own comment.
+func (p *postgresGateway) RestoreComment(ctx context.Context, id int64, restoredBy string, reason string, log *entities.ModerationLog) (entities.Comment, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.RestoreComment(ctx, id)
+
+	if err != nil {
+		return entities.Comment{}, err
+	}
+
+	if count == 0 {
+		return entities.Comment{}, common.ErrNotFound
+	}
+
+	if err := qtx.CreateRestoration(ctx, bindings.CreateRestorationParams{
+		TargetType: string(entities.CommentModerationTarget),
+		TargetID:   id,
+		RestoredBy: restoredBy,
+		Reason:     reason,
+	}); err != nil {
+		return entities.Comment{}, fmt.Errorf("failed to create restoration: %w", err)
+	}
+
+	if err := qtx.AggregateCommentVotes(ctx, id); err != nil {
+		return entities.Comment{}, fmt.Errorf("failed to aggregate comment votes: %w", err)
+	}
+
+	if log != nil {
+		if err := createModerationLog(ctx, qtx, *log); err != nil {
+			return entities.Comment{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Comment{}, err
+	}
+
+	return p.GetCommentById(ctx, id)
+}
+
+func toComment(dbComment bindings.GetCommentRow) entities.Comment {
 	var deletedAt *time.Time
 	if dbComment.DeletedAt.Valid {
 		deletedAt = &dbComment.DeletedAt.Time
@@ -157,7 +455,7 @@ func (p *postgresGateway) GetCommentById(ctx context.Context, id int64) (entitie
 		dbComment.RCreatedAt,
 		dbComment.RDeletedAt,
 	)
-	entitie := entities.NewComment(entities.CommentParams{
+	return entities.NewComment(entities.CommentParams{
 		Id:               dbComment.ID,
 		ThreadId:         dbComment.ThreadID,
 		Content:          dbComment.Content,
@@ -165,22 +463,67 @@ func (p *postgresGateway) GetCommentById(ctx context.Context, id int64) (entitie
 		User:             user,
 		RepliedToComment: repliedToComment,
 		IsDeleted:        dbComment.IsDeleted,
+		IsHidden:         dbComment.IsHidden,
 		CreatedAt:        dbComment.CreatedAt.Time,
 		DeletedAt:        deletedAt,
+		DeletedBy:        toStringPtr(dbComment.DeletedBy),
+		EditedAt:         toTimePtr(dbComment.EditedAt),
 		Votes:            dbComment.Votes,
 	})
-
-	return entitie, nil
 }
 
-func (p *postgresGateway) DeleteComment(ctx context.Context, id int64) error {
-	_, err := p.queries.DeleteComment(ctx, id)
+func toCommentTree(dbComment bindings.GetCommentTreeRow, replies map[int64][]bindings.GetCommentTreeRow, maxDepth int32) entities.Comment {
+	var deletedAt *time.Time
+	if dbComment.DeletedAt.Valid {
+		deletedAt = &dbComment.DeletedAt.Time
+	}
 
-	if errors.Is(err, pgx.ErrNoRows) {
-		return common.ErrNotFound
+	image := entities.NewImage(
+		dbComment.ImageFileName,
+		dbComment.ImageOriginalUrl,
+		dbComment.ImageOriginalContentType,
+		dbComment.ImageFormattedUrl,
+		dbComment.ImageFormattedContentType,
+	)
+	user := toUser(
+		dbComment.Address,
+		dbComment.EnsName,
+		dbComment.EnsAvatarFileName,
+		dbComment.EnsAvatarOriginalUrl,
+		dbComment.EnsAvatarOriginalContentType,
+		dbComment.EnsAvatarFormattedUrl,
+		dbComment.EnsAvatarFormattedContentType,
+		dbComment.Reputation,
+		dbComment.UserCreatedAt,
+		dbComment.UserUpdatedAt,
+	)
+
+	// replies of comments at the max depth are not hydrated
+	var commentReplies *[]entities.Comment
+	if dbComment.Depth < maxDepth {
+		hydrated := []entities.Comment{}
+		for _, reply := range replies[dbComment.ID] {
+			hydrated = append(hydrated, toCommentTree(reply, replies, maxDepth))
+		}
+		commentReplies = &hydrated
 	}
 
-	return err
+	return entities.NewComment(entities.CommentParams{
+		Id:         dbComment.ID,
+		ThreadId:   dbComment.ThreadID,
+		Content:    dbComment.Content,
+		Image:      image,
+		User:       user,
+		IsDeleted:  dbComment.IsDeleted,
+		IsHidden:   dbComment.IsHidden,
+		CreatedAt:  dbComment.CreatedAt.Time,
+		DeletedAt:  deletedAt,
+		DeletedBy:  toStringPtr(dbComment.DeletedBy),
+		EditedAt:   toTimePtr(dbComment.EditedAt),
+		Votes:      dbComment.Votes,
+		ReplyCount: dbComment.ReplyCount,
+		Replies:    commentReplies,
+	})
 }
 
 // assumes nil based on id validity
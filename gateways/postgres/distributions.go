@@ -0,0 +1,342 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// nil if no distribution has run yet
+func (p *postgresGateway) GetLastDistribution(ctx context.Context) (*entities.Distribution, error) {
+	dbDistribution, err := p.queries.GetLastDistribution(ctx)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := toDistribution(dbDistribution)
+	return &distribution, nil
+}
+
+func (p *postgresGateway) GetDistributionById(ctx context.Context, id int64) (entities.Distribution, error) {
+	dbDistribution, err := p.queries.GetDistribution(ctx, id)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Distribution{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		return entities.Distribution{}, err
+	}
+
+	return toDistribution(dbDistribution), nil
+}
+
+func (p *postgresGateway) GetAllocation(ctx context.Context, distributionId int64, address string) (entities.Allocation, error) {
+	dbAllocation, err := p.queries.GetAllocation(ctx, bindings.GetAllocationParams{
+		DistributionID: distributionId,
+		Address:        address,
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Allocation{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		return entities.Allocation{}, err
+	}
+
+	return toAllocation(dbAllocation), nil
+}
+
+func (p *postgresGateway) GetDistributions(ctx context.Context, offset int64, limit int64) ([]entities.Distribution, int64, error) {
+	rows, err := p.queries.GetDistributions(ctx, bindings.GetDistributionsParams{
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	distributions := []entities.Distribution{}
+	for _, row := range rows {
+		count = row.FullCount
+		distributions = append(distributions, toDistribution(bindings.Distribution{
+			ID:           row.ID,
+			Round:        row.Round,
+			Votes:        row.Votes,
+			Amount:       row.Amount,
+			CreatedAt:    row.CreatedAt,
+			MerkleRoot:   row.MerkleRoot,
+			Participants: row.Participants,
+		}))
+	}
+
+	return distributions, count, nil
+}
+
+func (p *postgresGateway) GetAllocationsByDistribution(ctx context.Context, distributionId int64, offset int64, limit int64) ([]entities.Allocation, int64, error) {
+	rows, err := p.queries.GetAllocationsByDistribution(ctx, bindings.GetAllocationsByDistributionParams{
+		DistributionID: distributionId,
+		PageLimit:      limit,
+		PageOffset:     offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	allocations := []entities.Allocation{}
+	for _, row := range rows {
+		count = row.FullCount
+		allocations = append(allocations, toAllocation(bindings.GetAllocationRow{
+			DistributionID: row.DistributionID,
+			Address:        row.Address,
+			Amount:         row.Amount,
+			Votes:          row.Votes,
+			Round:          row.Round,
+		}))
+	}
+
+	return allocations, count, nil
+}
+
+func (p *postgresGateway) GetAllocationsByAddress(ctx context.Context, address string, offset int64, limit int64) ([]entities.Allocation, int64, error) {
+	rows, err := p.queries.GetAllocationsByAddress(ctx, bindings.GetAllocationsByAddressParams{
+		Address:    address,
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	allocations := []entities.Allocation{}
+	for _, row := range rows {
+		count = row.FullCount
+		allocations = append(allocations, toAllocation(bindings.GetAllocationRow{
+			DistributionID: row.DistributionID,
+			Address:        row.Address,
+			Amount:         row.Amount,
+			Votes:          row.Votes,
+			Round:          row.Round,
+		}))
+	}
+
+	return allocations, count, nil
+}
+
+// Addresses without allocations have zero earnings
+func (p *postgresGateway) GetEarnings(ctx context.Context, address string) (entities.Earnings, error) {
+	row, err := p.queries.GetEarnings(ctx, address)
+
+	if err != nil {
+		return entities.Earnings{}, err
+	}
+
+	return entities.NewEarnings(address, numericToBigInt(row.Amount), row.Rounds), nil
+}
+
+// The nodes of the tree in the order they were built in, common.ErrNotFound for rounds that ran before trees were built
+func (p *postgresGateway) GetMerkleTree(ctx context.Context, distributionId int64) ([]string, error) {
+	nodes, err := p.queries.GetMerkleTree(ctx, distributionId)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, common.ErrNotFound
+	}
+
+	return nodes, err
+}
+
+// The vote decisions made up to the cutoff that are not consumed by a distribution, in the order they were made.
+func (p *postgresGateway) GetUndistributedVotes(ctx context.Context, cutoff time.Time) ([]entities.DistributionVote, error) {
+	rows, err := p.queries.GetUndistributedVoteDecisions(ctx, pgtype.Timestamp{Time: cutoff, Valid: true})
+
+	if err != nil {
+		return nil, err
+	}
+
+	votes := []entities.DistributionVote{}
+	for _, row := range rows {
+		votes = append(votes, toDistributionVote(row))
+	}
+
+	return votes, nil
+}
+
+// The votes are weighed as if a single round covered the range right after the rounds before it
+func (p *postgresGateway) GetVoteDecisions(ctx context.Context, spec gateways.VoteDecisionsSpec) ([]entities.DistributionVote, error) {
+	params := bindings.GetVoteDecisionsInRangeParams{}
+
+	if spec.VotedAfter != nil {
+		params.VotedAfter = pgtype.Timestamp{Time: *spec.VotedAfter, Valid: true}
+	}
+	if spec.VotedBefore != nil {
+		params.VotedBefore = pgtype.Timestamp{Time: *spec.VotedBefore, Valid: true}
+	}
+	if spec.FromId != nil {
+		params.FromID = pgtype.Int8{Int64: *spec.FromId, Valid: true}
+	}
+	if spec.ToId != nil {
+		params.ToID = pgtype.Int8{Int64: *spec.ToId, Valid: true}
+	}
+
+	rows, err := p.queries.GetVoteDecisionsInRange(ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	votes := []entities.DistributionVote{}
+	for _, row := range rows {
+		votes = append(votes, toDistributionVote(bindings.GetUndistributedVoteDecisionsRow(row)))
+	}
+
+	return votes, nil
+}
+
+// Records the round, consumes its votes and writes its allocations and merkle tree in a single transaction so a failed round leaves nothing behind.
+// The votes must be the ones returned by GetUndistributedVotes for the round.
+// If any of them changed since, the round is rolled back with a retryable error so it can be recomputed.
+// Returns common.ErrNotDistributionTime if the round already ran.
+func (p *postgresGateway) CreateDistribution(ctx context.Context, round time.Time, votes []entities.DistributionVote, allocations []entities.Allocation, merkleRoot string, merkleTree []string) (entities.Distribution, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Distribution{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	amount := big.NewInt(0)
+	for _, allocation := range allocations {
+		amount.Add(amount, allocation.Amount())
+	}
+
+	countedIds := []int64{}
+	for _, vote := range votes {
+		if vote.Counts() {
+			countedIds = append(countedIds, vote.DecisionId())
+		}
+	}
+
+	dbDistribution, err := qtx.CreateDistribution(ctx, bindings.CreateDistributionParams{
+		Round:        pgtype.Timestamp{Time: round, Valid: true},
+		Votes:        int64(len(countedIds)),
+		Amount:       pgtype.Numeric{Int: amount, Valid: true},
+		MerkleRoot:   merkleRoot,
+		Participants: int64(len(allocations)),
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Distribution{}, fmt.Errorf("distribution %v already ran: %w", round, common.ErrNotDistributionTime)
+	}
+
+	if err != nil {
+		return entities.Distribution{}, err
+	}
+
+	consumed, err := qtx.DistributeVoteDecisions(ctx, bindings.DistributeVoteDecisionsParams{
+		DistributionID: dbDistribution.ID,
+		CountedIds:     countedIds,
+		Cutoff:         pgtype.Timestamp{Time: round, Valid: true},
+	})
+
+	if err != nil {
+		return entities.Distribution{}, err
+	}
+
+	if consumed != int64(len(votes)) {
+		return entities.Distribution{}, fmt.Errorf("votes changed while computing distribution %v: %w", round, common.ErrRetryable)
+	}
+
+	for _, allocation := range allocations {
+		if err := qtx.CreateAllocation(ctx, bindings.CreateAllocationParams{
+			DistributionID: dbDistribution.ID,
+			Address:        allocation.Address(),
+			Amount:         pgtype.Numeric{Int: allocation.Amount(), Valid: true},
+			Votes:          allocation.Votes(),
+		}); err != nil {
+			return entities.Distribution{}, err
+		}
+	}
+
+	if err := qtx.CreateMerkleTree(ctx, bindings.CreateMerkleTreeParams{
+		DistributionID: dbDistribution.ID,
+		Nodes:          merkleTree,
+	}); err != nil {
+		return entities.Distribution{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Distribution{}, err
+	}
+
+	return toDistribution(dbDistribution), nil
+}
+
+func toDistribution(distribution bindings.Distribution) entities.Distribution {
+	return entities.NewDistribution(entities.DistributionParams{
+		Id:           distribution.ID,
+		Round:        distribution.Round.Time,
+		Votes:        distribution.Votes,
+		Amount:       numericToBigInt(distribution.Amount),
+		MerkleRoot:   distribution.MerkleRoot,
+		Participants: distribution.Participants,
+		CreatedAt:    distribution.CreatedAt.Time,
+	})
+}
+
+func toAllocation(allocation bindings.GetAllocationRow) entities.Allocation {
+	return entities.NewAllocation(entities.AllocationParams{
+		DistributionId: allocation.DistributionID,
+		Address:        allocation.Address,
+		Amount:         numericToBigInt(allocation.Amount),
+		Votes:          allocation.Votes,
+		Round:          allocation.Round.Time,
+	})
+}
+
+func toDistributionVote(row bindings.GetUndistributedVoteDecisionsRow) entities.DistributionVote {
+	var reason *entities.VoteDecisionReason
+	if row.Reason.Valid {
+		value := entities.VoteDecisionReason(row.Reason.String)
+		reason = &value
+	}
+
+	return entities.NewDistributionVote(entities.DistributionVoteParams{
+		DecisionId: row.ID,
+		Decision: entities.NewVoteDecision(entities.VoteDecisionParams{
+			Type:       entities.VoteType(row.Type),
+			TargetId:   row.TargetID,
+			Voter:      row.Voter,
+			Author:     toStringPtr(row.Author),
+			Value:      toVoteValue(row.Vote),
+			Reputation: numericToBigInt(row.Reputation),
+			Reason:     reason,
+			VotedAt:    row.VotedAt.Time,
+		}),
+		Latest:        row.Latest,
+		CountedBefore: row.CountedBefore,
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/gateways"
@@ -90,3 +91,43 @@ func (p *postgresGateway) rollback(ctx context.Context, tx pgx.Tx) {
 func numericToBigInt(num pgtype.Numeric) *big.Int {
 	return new(big.Int).Mul(num.Int, big.NewInt(1).Exp(big.NewInt(10), big.NewInt(int64(num.Exp)), nil))
 }
+
+// nil if the timestamp is null
+func toTimePtr(ts pgtype.Timestamp) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	return &ts.Time
+}
+
+// null if the value is nil
+func toInt8(value *int64) pgtype.Int8 {
+	if value == nil {
+		return pgtype.Int8{}
+	}
+	return pgtype.Int8{Int64: *value, Valid: true}
+}
+
+// nil if the value is null
+func toInt64Ptr(value pgtype.Int8) *int64 {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Int64
+}
+
+// null if the value is nil
+func toText(value *string) pgtype.Text {
+	if value == nil {
+		return pgtype.Text{}
+	}
+	return pgtype.Text{String: *value, Valid: true}
+}
+
+// nil if the value is null
+func toStringPtr(value pgtype.Text) *string {
+	if !value.Valid {
+		return nil
+	}
+	return &value.String
+}
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- hot_score is a time invariant ranking (votes decayed by age) so it only needs to be
+-- recomputed when votes change and pages can be keyset paginated on it.
+-- active_at tracks the latest comment on the thread.
+ALTER TABLE threads
+ADD COLUMN hot_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+ADD COLUMN active_at TIMESTAMP NOT NULL DEFAULT NOW();
+
+UPDATE threads
+SET
+	hot_score = SIGN(votes) * LOG(GREATEST(ABS(votes), 1)) + EXTRACT(EPOCH FROM created_at) / 45000,
+	active_at = COALESCE((
+		SELECT MAX(c.created_at)
+		FROM comments c
+		WHERE c.thread_id = threads.id
+	), created_at);
+
+CREATE INDEX threads_hot_idx ON threads(hot_score DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_new_idx ON threads(created_at DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_top_idx ON threads(votes DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_active_idx ON threads(active_at DESC, id DESC) WHERE is_deleted = FALSE;
+
+-- +goose StatementEnd
@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- supports keyset pagination of a thread's comments on (created_at, id)
+CREATE INDEX comments_thread_id_created_at_id_idx ON comments(thread_id, created_at DESC, id DESC) WHERE is_deleted = FALSE;
+
+-- +goose StatementEnd
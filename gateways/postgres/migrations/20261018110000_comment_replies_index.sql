@@ -0,0 +1,7 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- replies of a comment are walked oldest first when building comment trees
+CREATE INDEX comments_replied_to_comment_id_created_at_id_idx ON comments(replied_to_comment_id, created_at, id);
+
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE threads ADD COLUMN edited_at TIMESTAMP NULL DEFAULT NULL;
+
+ALTER TABLE comments ADD COLUMN edited_at TIMESTAMP NULL DEFAULT NULL;
+
+-- revisions keep every prior version of edited threads and comments.
+-- created_at is when the version was written and revised_at is when it was replaced.
+CREATE TABLE thread_revisions (
+	id BIGSERIAL PRIMARY KEY,
+	thread_id BIGINT NOT NULL REFERENCES threads(id),
+	title TEXT NOT NULL,
+	content TEXT NOT NULL,
+	image_file_name TEXT NOT NULL,
+	image_original_url TEXT NOT NULL,
+	image_original_content_type TEXT NOT NULL,
+	image_formatted_url TEXT NOT NULL,
+	image_formatted_content_type TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	revised_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE comment_revisions (
+	id BIGSERIAL PRIMARY KEY,
+	comment_id BIGINT NOT NULL REFERENCES comments(id),
+	content TEXT NOT NULL,
+	image_file_name TEXT NOT NULL,
+	image_original_url TEXT NOT NULL,
+	image_original_content_type TEXT NOT NULL,
+	image_formatted_url TEXT NOT NULL,
+	image_formatted_content_type TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	revised_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX thread_revisions_thread_id_idx ON thread_revisions(thread_id);
+
+CREATE INDEX comment_revisions_comment_id_idx ON comment_revisions(comment_id);
+
+-- +goose StatementEnd
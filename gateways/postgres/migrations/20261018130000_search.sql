@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- thread titles are weighted above their content so title matches rank first
+ALTER TABLE threads
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+	setweight(to_tsvector('english', title), 'A') || setweight(to_tsvector('english', content), 'B')
+) STORED;
+
+ALTER TABLE comments
+ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+	to_tsvector('english', content)
+) STORED;
+
+CREATE INDEX threads_search_vector_idx ON threads USING GIN(search_vector);
+
+CREATE INDEX comments_search_vector_idx ON comments USING GIN(search_vector);
+
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE boards (
+	id BIGSERIAL PRIMARY KEY,
+	slug VARCHAR(32) NOT NULL UNIQUE,
+	title TEXT NOT NULL,
+	description TEXT NOT NULL,
+	rules TEXT NOT NULL,
+	created_by VARCHAR(42) NOT NULL REFERENCES users(address),
+	image_file_name TEXT NULL DEFAULT NULL,
+	image_original_url TEXT NULL DEFAULT NULL,
+	image_original_content_type TEXT NULL DEFAULT NULL,
+	image_formatted_url TEXT NULL DEFAULT NULL,
+	image_formatted_content_type TEXT NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- existing threads are moved into a default board created by the zero address
+INSERT INTO boards (slug, title, description, rules, created_by)
+VALUES ('general', 'General', 'Anything goes.', '', '0x0000000000000000000000000000000000000000');
+
+ALTER TABLE threads ADD COLUMN board_id BIGINT NULL REFERENCES boards(id);
+
+UPDATE threads SET board_id = (SELECT id FROM boards WHERE slug = 'general');
+
+ALTER TABLE threads ALTER COLUMN board_id SET NOT NULL;
+
+CREATE INDEX threads_board_hot_idx ON threads(board_id, hot_score DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_board_new_idx ON threads(board_id, created_at DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_board_top_idx ON threads(board_id, votes DESC, id DESC) WHERE is_deleted = FALSE;
+
+CREATE INDEX threads_board_active_idx ON threads(board_id, active_at DESC, id DESC) WHERE is_deleted = FALSE;
+
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- threads and comments of a user are walked newest first or by votes
+CREATE INDEX threads_address_created_at_id_idx ON threads(address, created_at, id) WHERE is_deleted = FALSE;
+CREATE INDEX threads_address_votes_id_idx ON threads(address, votes, id) WHERE is_deleted = FALSE;
+CREATE INDEX comments_address_created_at_id_idx ON comments(address, created_at, id) WHERE is_deleted = FALSE;
+CREATE INDEX comments_address_votes_id_idx ON comments(address, votes, id) WHERE is_deleted = FALSE;
+
+-- +goose StatementEnd
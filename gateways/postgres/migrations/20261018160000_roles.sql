@@ -0,0 +1,30 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- roles without a board apply globally, roles with a board only apply to that board.
+-- roles can be granted to addresses that have not signed in yet so there is no user reference.
+CREATE TABLE roles (
+	id BIGSERIAL PRIMARY KEY,
+	address VARCHAR(42) NOT NULL,
+	role VARCHAR(16) NOT NULL CHECK (role IN ('moderator', 'admin')),
+	board_id BIGINT NULL REFERENCES boards(id),
+	created_by VARCHAR(42) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX roles_address_role_board_id_idx ON roles(address, role, COALESCE(board_id, 0));
+
+-- every grant and revoke is recorded with the address that made the change
+CREATE TABLE role_changes (
+	id BIGSERIAL PRIMARY KEY,
+	address VARCHAR(42) NOT NULL,
+	role VARCHAR(16) NOT NULL,
+	board_id BIGINT NULL REFERENCES boards(id),
+	action VARCHAR(16) NOT NULL CHECK (action IN ('grant', 'revoke')),
+	changed_by VARCHAR(42) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX role_changes_address_idx ON role_changes(address);
+
+-- +goose StatementEnd
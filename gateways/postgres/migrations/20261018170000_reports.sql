@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- hidden threads and comments are left out of feeds until a moderator reviews their reports
+ALTER TABLE threads ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE comments ADD COLUMN is_hidden BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- the target id is the id of the thread or comment, or the address of the user.
+-- reports stay open until a moderator resolves every open report of the target at once.
+CREATE TABLE reports (
+	id BIGSERIAL PRIMARY KEY,
+	reporter VARCHAR(42) NOT NULL REFERENCES users(address),
+	target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('thread', 'comment', 'user')),
+	target_id VARCHAR(42) NOT NULL,
+	reason VARCHAR(16) NOT NULL CHECK (reason IN ('spam', 'abuse', 'illegal', 'other')),
+	details TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	resolved_at TIMESTAMP NULL DEFAULT NULL,
+	resolved_by VARCHAR(42) NULL DEFAULT NULL,
+	resolution VARCHAR(16) NULL DEFAULT NULL CHECK (resolution IN ('dismiss', 'delete', 'ban'))
+);
+
+-- a reporter can only have one open report per target
+CREATE UNIQUE INDEX reports_reporter_target_idx ON reports(reporter, target_type, target_id) WHERE resolved_at IS NULL;
+
+CREATE INDEX reports_target_idx ON reports(target_type, target_id) WHERE resolved_at IS NULL;
+
+-- +goose StatementEnd
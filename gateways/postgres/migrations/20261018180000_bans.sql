@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- hard bans block signing in and writing, shadow bans hide the content of the address from everyone else.
+-- bans are revoked rather than deleted so the history of an address is kept
+CREATE TABLE bans (
+	id BIGSERIAL PRIMARY KEY,
+	address VARCHAR(42) NOT NULL,
+	type VARCHAR(16) NOT NULL CHECK (type IN ('hard', 'shadow')),
+	reason TEXT NOT NULL DEFAULT '',
+	expires_at TIMESTAMP NULL DEFAULT NULL,
+	issued_by VARCHAR(42) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	revoked_at TIMESTAMP NULL DEFAULT NULL,
+	revoked_by VARCHAR(42) NULL DEFAULT NULL
+);
+
+CREATE INDEX bans_address_idx ON bans(address);
+
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- every moderation action is recorded so moderators can be audited publicly.
+-- the target id is the id of the thread or comment, or the address of the user.
+-- snapshots hold the state of the target before and after the action and are null when there is no such state.
+CREATE TABLE moderation_log (
+	id BIGSERIAL PRIMARY KEY,
+	action VARCHAR(16) NOT NULL CHECK (action IN ('delete', 'restore', 'ban', 'unban', 'grant_role', 'revoke_role', 'resolve_reports', 'lock', 'unlock', 'pin', 'unpin')),
+	actor VARCHAR(42) NOT NULL,
+	target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('thread', 'comment', 'user')),
+	target_id VARCHAR(42) NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	before JSONB NULL DEFAULT NULL,
+	after JSONB NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX moderation_log_created_at_idx ON moderation_log(created_at DESC, id DESC);
+
+-- +goose StatementEnd
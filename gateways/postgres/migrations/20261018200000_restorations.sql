@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- authors can only restore what they deleted themselves, so the deleter is kept until the item is restored
+ALTER TABLE threads ADD COLUMN deleted_by VARCHAR(42) NULL DEFAULT NULL;
+
+ALTER TABLE comments ADD COLUMN deleted_by VARCHAR(42) NULL DEFAULT NULL;
+
+-- every restore of a thread or comment is kept with who restored it and why
+CREATE TABLE restorations (
+	id BIGSERIAL PRIMARY KEY,
+	target_type VARCHAR(16) NOT NULL CHECK (target_type IN ('thread', 'comment')),
+	target_id BIGINT NOT NULL,
+	restored_by VARCHAR(42) NOT NULL,
+	reason TEXT NOT NULL DEFAULT '',
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX restorations_target_idx ON restorations(target_type, target_id);
+
+-- +goose StatementEnd
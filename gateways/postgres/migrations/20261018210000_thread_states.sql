@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- locked and archived threads no longer accept comments.
+-- threads are pinned to the top of their feeds until pinned_until passes.
+ALTER TABLE threads ADD COLUMN is_locked BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE threads ADD COLUMN pinned_until TIMESTAMP NULL DEFAULT NULL;
+
+ALTER TABLE threads ADD COLUMN archived_at TIMESTAMP NULL DEFAULT NULL;
+
+CREATE INDEX threads_pinned_until_idx ON threads(pinned_until) WHERE pinned_until IS NOT NULL;
+
+-- inactive threads are archived in the background
+CREATE INDEX threads_active_at_idx ON threads(active_at) WHERE archived_at IS NULL;
+
+ALTER TABLE moderation_log DROP CONSTRAINT moderation_log_action_check;
+
+ALTER TABLE moderation_log ADD CONSTRAINT moderation_log_action_check CHECK (action IN ('delete', 'restore', 'ban', 'unban', 'grant_role', 'revoke_role', 'resolve_reports', 'lock', 'unlock', 'pin', 'unpin', 'archive', 'unarchive'));
+
+-- +goose StatementEnd
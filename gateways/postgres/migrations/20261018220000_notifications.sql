@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- notifications are created in the background for every new comment.
+-- recipients are notified once per comment for the most relevant reason, replies over thread replies over mentions.
+CREATE TABLE notifications (
+	id BIGSERIAL PRIMARY KEY,
+	recipient VARCHAR(42) NOT NULL REFERENCES users(address),
+	type VARCHAR(16) NOT NULL CHECK (type IN ('reply', 'thread_reply', 'mention')),
+	actor VARCHAR(42) NOT NULL REFERENCES users(address),
+	thread_id BIGINT NOT NULL REFERENCES threads(id),
+	comment_id BIGINT NOT NULL REFERENCES comments(id),
+	read_at TIMESTAMP NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	UNIQUE (recipient, comment_id)
+);
+
+CREATE INDEX notifications_recipient_idx ON notifications(recipient, created_at DESC, id DESC);
+
+CREATE INDEX notifications_unread_idx ON notifications(recipient) WHERE read_at IS NULL;
+
+-- mentions are resolved against ens names
+CREATE INDEX users_ens_name_idx ON users(LOWER(ens_name));
+
+-- +goose StatementEnd
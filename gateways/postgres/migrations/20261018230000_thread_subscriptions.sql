@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- users following a thread are notified of every new comment on it.
+-- authors follow their threads and commenters the threads they comment on automatically.
+CREATE TABLE thread_subscriptions (
+	address VARCHAR(42) NOT NULL REFERENCES users(address),
+	thread_id BIGINT NOT NULL REFERENCES threads(id),
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	PRIMARY KEY (address, thread_id)
+);
+
+CREATE INDEX thread_subscriptions_thread_id_idx ON thread_subscriptions(thread_id);
+
+-- authors of existing threads follow them like authors of new threads do
+INSERT INTO thread_subscriptions (address, thread_id)
+SELECT address, id FROM threads;
+
+ALTER TABLE notifications DROP CONSTRAINT notifications_type_check;
+
+ALTER TABLE notifications ADD CONSTRAINT notifications_type_check CHECK (type IN ('reply', 'thread_reply', 'mention', 'subscription'));
+
+-- +goose StatementEnd
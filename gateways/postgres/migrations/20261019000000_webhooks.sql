@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- webhooks are managed by admins and receive the platform events they subscribe to.
+-- webhooks are disabled once consecutive_failures reaches the configured limit and stay disabled until re-enabled.
+CREATE TABLE webhooks (
+	id BIGSERIAL PRIMARY KEY,
+	url TEXT NOT NULL,
+	events VARCHAR(32)[] NOT NULL,
+	secret TEXT NOT NULL,
+	created_by VARCHAR(42) NOT NULL,
+	consecutive_failures BIGINT NOT NULL DEFAULT 0,
+	disabled_at TIMESTAMP NULL DEFAULT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- every delivery is logged once its retries are exhausted.
+-- the event id is the id of the stream message so receivers can de-duplicate redeliveries.
+-- the status code is null when no response was received.
+CREATE TABLE webhook_deliveries (
+	id BIGSERIAL PRIMARY KEY,
+	webhook_id BIGINT NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+	event_type VARCHAR(32) NOT NULL,
+	event_id VARCHAR(64) NOT NULL,
+	status_code INT NULL DEFAULT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	attempts INT NOT NULL,
+	succeeded BOOLEAN NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX webhook_deliveries_webhook_id_idx ON webhook_deliveries(webhook_id, created_at DESC, id DESC);
+
+-- +goose StatementEnd
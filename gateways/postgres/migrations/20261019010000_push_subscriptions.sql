@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Web Push subscriptions of the browsers of each address.
+-- The endpoint is unique to a browser so re-registering it moves it to the new address.
+-- p256dh and auth are the base64url encoded keys payloads are encrypted with.
+CREATE TABLE push_subscriptions (
+	endpoint TEXT PRIMARY KEY,
+	address VARCHAR(42) NOT NULL,
+	p256dh VARCHAR(128) NOT NULL,
+	auth VARCHAR(64) NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX push_subscriptions_address_idx ON push_subscriptions(address);
+
+-- +goose StatementEnd
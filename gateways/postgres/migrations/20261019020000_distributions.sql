@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- a distribution is a round of rewards, at most one per interval
+-- votes is the number of votes consumed by the round and amount the sum of its allocations
+CREATE TABLE distributions (
+	id BIGSERIAL PRIMARY KEY,
+	round TIMESTAMP NOT NULL UNIQUE,
+	votes BIGINT NOT NULL,
+	amount NUMERIC NOT NULL,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- the share of a round earned by an author, votes is the number of votes that counted towards it
+CREATE TABLE allocations (
+	distribution_id BIGINT NOT NULL REFERENCES distributions(id),
+	address VARCHAR(42) NOT NULL REFERENCES users(address),
+	amount NUMERIC NOT NULL,
+	votes BIGINT NOT NULL,
+	PRIMARY KEY (distribution_id, address)
+);
+
+CREATE INDEX allocations_address_idx ON allocations(address);
+
+-- +goose StatementEnd
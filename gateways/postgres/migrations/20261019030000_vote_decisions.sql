@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- every vote processed by the distributor along with whether it was accepted or the reason it was discarded
+-- the author is null when the content voted on does not exist
+-- a decision is consumed by the first distribution after it is made, counted is set if it counted towards the distribution
+CREATE TABLE vote_decisions (
+	id BIGSERIAL PRIMARY KEY,
+	type VARCHAR(16) NOT NULL,
+	target_id BIGINT NOT NULL,
+	voter VARCHAR(42) NOT NULL REFERENCES users(address),
+	author VARCHAR(42) NULL,
+	vote SMALLINT NOT NULL,
+	reputation NUMERIC NOT NULL,
+	accepted BOOLEAN NOT NULL,
+	reason VARCHAR(32) NULL,
+	voted_at TIMESTAMP NOT NULL,
+	distribution_id BIGINT NULL DEFAULT NULL REFERENCES distributions(id),
+	counted BOOLEAN NOT NULL DEFAULT FALSE,
+	created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+	-- a vote message that is delivered again does not record a second decision
+	UNIQUE (type, target_id, voter, voted_at)
+);
+
+CREATE INDEX vote_decisions_undistributed_idx ON vote_decisions(created_at) WHERE distribution_id IS NULL;
+
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- the root of the merkle tree of the allocations of the round, a zero hash if the round has no allocations
+-- rounds that ran before trees were built keep a zero root and can not be claimed
+ALTER TABLE distributions ADD COLUMN merkle_root VARCHAR(66) NOT NULL DEFAULT '0x0000000000000000000000000000000000000000000000000000000000000000';
+
+-- the nodes of the tree are kept apart from the round since they are only read to build proofs
+CREATE TABLE merkle_trees (
+	distribution_id BIGINT PRIMARY KEY REFERENCES distributions(id),
+	nodes VARCHAR(66)[] NOT NULL
+);
+
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- the number of authors with an allocation in the round, kept on the round so listing rounds does not count allocations
+ALTER TABLE distributions ADD COLUMN participants BIGINT NOT NULL DEFAULT 0;
+
+UPDATE distributions d
+SET participants = (SELECT COUNT(*) FROM allocations a WHERE a.distribution_id = d.id);
+
+-- +goose StatementEnd
@@ -0,0 +1,102 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+)
+
+// Moderation actions write their log entry with the queries of their own transaction
+func createModerationLog(ctx context.Context, queries *bindings.Queries, log entities.ModerationLog) error {
+	before, err := fromSnapshot(log.Before())
+
+	if err != nil {
+		return err
+	}
+
+	after, err := fromSnapshot(log.After())
+
+	if err != nil {
+		return err
+	}
+
+	if err := queries.CreateModerationLog(ctx, bindings.CreateModerationLogParams{
+		Action:     string(log.Action()),
+		Actor:      log.Actor(),
+		TargetType: string(log.TargetType()),
+		TargetID:   log.TargetId(),
+		Reason:     log.Reason(),
+		Before:     before,
+		After:      after,
+	}); err != nil {
+		return fmt.Errorf("failed to log %v of %v %v: %w", log.Action(), log.TargetType(), log.TargetId(), err)
+	}
+
+	return nil
+}
+
+// Returns the page of moderation log entries, newest first, and the total number of entries
+func (p *postgresGateway) GetModerationLog(ctx context.Context, offset int64, limit int64) ([]entities.ModerationLog, int64, error) {
+	rows, err := p.queries.GetModerationLog(ctx, bindings.GetModerationLogParams{
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	logs := []entities.ModerationLog{}
+	for _, row := range rows {
+		count = row.FullCount
+
+		before, err := toSnapshot(row.Before)
+
+		if err != nil {
+			return nil, -1, err
+		}
+
+		after, err := toSnapshot(row.After)
+
+		if err != nil {
+			return nil, -1, err
+		}
+
+		logs = append(logs, entities.NewModerationLog(entities.ModerationLogParams{
+			Id:         row.ID,
+			Action:     entities.ModerationAction(row.Action),
+			Actor:      row.Actor,
+			TargetType: entities.ModerationTargetType(row.TargetType),
+			TargetId:   row.TargetID,
+			Reason:     row.Reason,
+			Before:     before,
+			After:      after,
+			CreatedAt:  row.CreatedAt.Time,
+		}))
+	}
+
+	return logs, count, nil
+}
+
+// a nil snapshot is stored as null
+func fromSnapshot(snapshot entities.Snapshot) ([]byte, error) {
+	if snapshot == nil {
+		return nil, nil
+	}
+	return json.Marshal(snapshot)
+}
+
+func toSnapshot(data []byte) (entities.Snapshot, error) {
+	if data == nil {
+		return nil, nil
+	}
+	snapshot := entities.Snapshot{}
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+)
+
+// Notifications of a single comment are created together so a failure can be retried without partial results
+func (p *postgresGateway) CreateNotifications(ctx context.Context, notifications []entities.Notification) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	for _, notification := range notifications {
+		if err := qtx.CreateNotification(ctx, bindings.CreateNotificationParams{
+			Recipient: notification.Recipient(),
+			Type:      string(notification.Type()),
+			Actor:     notification.Actor(),
+			ThreadID:  notification.ThreadId(),
+			CommentID: notification.CommentId(),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Returns the page of notifications of the recipient, newest first, and the total number of them
+func (p *postgresGateway) GetNotifications(ctx context.Context, recipient string, unreadOnly bool, offset int64, limit int64) ([]entities.Notification, int64, error) {
+	rows, err := p.queries.GetNotifications(ctx, bindings.GetNotificationsParams{
+		Recipient:  recipient,
+		UnreadOnly: unreadOnly,
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	notifications := []entities.Notification{}
+	for _, row := range rows {
+		count = row.FullCount
+		notifications = append(notifications, entities.NewNotification(entities.NotificationParams{
+			Id:        row.ID,
+			Recipient: row.Recipient,
+			Type:      entities.NotificationType(row.Type),
+			Actor:     row.Actor,
+			ThreadId:  row.ThreadID,
+			CommentId: row.CommentID,
+			ReadAt:    toTimePtr(row.ReadAt),
+			CreatedAt: row.CreatedAt.Time,
+		}))
+	}
+
+	return notifications, count, nil
+}
+
+func (p *postgresGateway) CountUnreadNotifications(ctx context.Context, recipient string) (int64, error) {
+	return p.queries.CountUnreadNotifications(ctx, recipient)
+}
+
+// Notifications of other recipients are treated as missing
+func (p *postgresGateway) ReadNotification(ctx context.Context, id int64, recipient string) error {
+	rows, err := p.queries.ReadNotification(ctx, bindings.ReadNotificationParams{
+		ID:        id,
+		Recipient: recipient,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// Returns the number of notifications that were marked as read
+func (p *postgresGateway) ReadAllNotifications(ctx context.Context, recipient string) (int64, error) {
+	return p.queries.ReadAllNotifications(ctx, recipient)
+}
+
+// Names without a matching user are left out
+func (p *postgresGateway) GetAddressesByEnsNames(ctx context.Context, ensNames []string) ([]string, error) {
+	addresses, err := p.queries.GetAddressesByEnsNames(ctx, ensNames)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if addresses == nil {
+		return []string{}, nil
+	}
+
+	return addresses, nil
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+)
+
+// registering an endpoint again replaces its keys and address
+// an endpoint already registered by another address is forbidden
+func (p *postgresGateway) CreatePushSubscription(ctx context.Context, subscription entities.PushSubscription) error {
+	rows, err := p.queries.CreatePushSubscription(ctx, bindings.CreatePushSubscriptionParams{
+		Endpoint: subscription.Endpoint(),
+		Address:  subscription.Address(),
+		P256dh:   subscription.P256dh(),
+		Auth:     subscription.Auth(),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return common.ErrForbidden
+	}
+
+	return nil
+}
+
+// subscriptions of other addresses are treated as missing
+func (p *postgresGateway) DeletePushSubscription(ctx context.Context, endpoint string, address string) error {
+	rows, err := p.queries.DeletePushSubscription(ctx, bindings.DeletePushSubscriptionParams{
+		Endpoint: endpoint,
+		Address:  address,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// removes a subscription the push service reported as gone regardless of its address
+func (p *postgresGateway) DeleteExpiredPushSubscription(ctx context.Context, endpoint string) error {
+	return p.queries.DeleteExpiredPushSubscription(ctx, endpoint)
+}
+
+func (p *postgresGateway) GetPushSubscriptionsByAddresses(ctx context.Context, addresses []string) ([]entities.PushSubscription, error) {
+	dbSubscriptions, err := p.queries.GetPushSubscriptionsByAddresses(ctx, addresses)
+
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions := []entities.PushSubscription{}
+	for _, subscription := range dbSubscriptions {
+		subscriptions = append(subscriptions, entities.NewPushSubscription(entities.PushSubscriptionParams{
+			Endpoint:  subscription.Endpoint,
+			Address:   subscription.Address,
+			P256dh:    subscription.P256dh,
+			Auth:      subscription.Auth,
+			CreatedAt: subscription.CreatedAt.Time,
+		}))
+	}
+
+	return subscriptions, nil
+}
@@ -3,6 +3,16 @@ SELECT *
 FROM users
 WHERE address = $1;
 
+-- deleted threads and comments do not count towards the activity of a user
+-- name: GetUserStats :one
+SELECT
+	(SELECT COUNT(*) FROM threads t WHERE t.address = sqlc.arg(address)::varchar(42) AND t.is_deleted = FALSE) AS thread_count,
+	(SELECT COUNT(*) FROM comments c WHERE c.address = sqlc.arg(address)::varchar(42) AND c.is_deleted = FALSE) AS comment_count,
+	(
+		(SELECT COALESCE(SUM(t.votes), 0) FROM threads t WHERE t.address = sqlc.arg(address)::varchar(42) AND t.is_deleted = FALSE) +
+		(SELECT COALESCE(SUM(c.votes), 0) FROM comments c WHERE c.address = sqlc.arg(address)::varchar(42) AND c.is_deleted = FALSE)
+	)::bigint AS votes;
+
 -- upsert user every time they signin so we don't have to check if they exist
 -- name: UpsertUser :exec
 INSERT INTO users (address)
@@ -33,20 +43,283 @@ ON CONFLICT (address) DO UPDATE
 SET message = $2, expires_at = $3;
 
 -- name: CreateThread :one
-INSERT INTO threads (address, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+INSERT INTO threads (address, board_id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, hot_score)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, EXTRACT(EPOCH FROM NOW()) / 45000)
+RETURNING id;
+
+-- name: CreateBoard :one
+INSERT INTO boards (slug, title, description, rules, created_by, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 RETURNING id;
 
+-- name: GetBoards :many
+SELECT * FROM boards
+ORDER BY created_at ASC, id ASC;
+
+-- name: GetBoardBySlug :one
+SELECT * FROM boards
+WHERE slug = $1;
+
+-- name: GetRolesByAddress :many
+SELECT * FROM roles
+WHERE address = $1
+ORDER BY created_at ASC, id ASC;
+
+-- granting a role that is already held is a no-op
+-- name: CreateRole :execrows
+INSERT INTO roles (address, role, board_id, created_by)
+VALUES (sqlc.arg(address)::varchar(42), sqlc.arg(role)::varchar(16), sqlc.narg(board_id)::bigint, sqlc.arg(created_by)::varchar(42))
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteRole :execrows
+DELETE FROM roles
+WHERE address = sqlc.arg(address)::varchar(42)
+AND role = sqlc.arg(role)::varchar(16)
+AND board_id IS NOT DISTINCT FROM sqlc.narg(board_id)::bigint;
+
+-- name: CreateRoleChange :exec
+INSERT INTO role_changes (address, role, board_id, action, changed_by)
+VALUES (sqlc.arg(address)::varchar(42), sqlc.arg(role)::varchar(16), sqlc.narg(board_id)::bigint, sqlc.arg(action)::varchar(16), sqlc.arg(changed_by)::varchar(42));
+
+-- reporting a target the reporter already has an open report for is a no-op
+-- name: CreateReport :exec
+INSERT INTO reports (reporter, target_type, target_id, reason, details)
+VALUES (sqlc.arg(reporter)::varchar(42), sqlc.arg(target_type)::varchar(16), sqlc.arg(target_id)::varchar(42), sqlc.arg(reason)::varchar(16), sqlc.arg(details)::text)
+ON CONFLICT DO NOTHING;
+
+-- name: CountOpenReports :one
+SELECT COUNT(*)
+FROM reports
+WHERE target_type = sqlc.arg(target_type)::varchar(16)
+AND target_id = sqlc.arg(target_id)::varchar(42)
+AND resolved_at IS NULL;
+
+-- Open reports are grouped by target with the most reported targets first
+-- name: GetOpenReports :many
+SELECT
+	target_type,
+	target_id,
+	COUNT(*) AS report_count,
+	array_agg(DISTINCT reason)::text[] AS reasons,
+	COALESCE(array_agg(details ORDER BY created_at ASC) FILTER (WHERE details <> ''), '{}')::text[] AS details,
+	MIN(created_at)::timestamp AS first_reported_at,
+	MAX(created_at)::timestamp AS last_reported_at,
+	COUNT(*) OVER() AS full_count
+FROM reports
+WHERE resolved_at IS NULL
+GROUP BY target_type, target_id
+ORDER BY COUNT(*) DESC, MIN(created_at) ASC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- name: ResolveReports :execrows
+UPDATE reports
+SET resolved_at = NOW(), resolved_by = sqlc.arg(resolved_by)::varchar(42), resolution = sqlc.arg(resolution)::varchar(16)
+WHERE target_type = sqlc.arg(target_type)::varchar(16)
+AND target_id = sqlc.arg(target_id)::varchar(42)
+AND resolved_at IS NULL;
+
+-- name: SetThreadHidden :exec
+UPDATE threads
+SET is_hidden = sqlc.arg(is_hidden)::boolean
+WHERE id = sqlc.arg(id)::bigint;
+
+-- name: SetCommentHidden :exec
+UPDATE comments
+SET is_hidden = sqlc.arg(is_hidden)::boolean
+WHERE id = sqlc.arg(id)::bigint;
+
+-- hard bans take precedence over shadow bans
+-- name: GetActiveBan :one
+SELECT * FROM bans
+WHERE address = $1
+AND revoked_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW())
+ORDER BY type = 'hard' DESC, created_at DESC
+LIMIT 1;
+
+-- name: RevokeBans :execrows
+UPDATE bans
+SET revoked_at = NOW(), revoked_by = sqlc.arg(revoked_by)::varchar(42)
+WHERE address = sqlc.arg(address)::varchar(42)
+AND revoked_at IS NULL
+AND (expires_at IS NULL OR expires_at > NOW());
+
+-- name: CreateBan :exec
+INSERT INTO bans (address, type, reason, expires_at, issued_by)
+VALUES (sqlc.arg(address)::varchar(42), sqlc.arg(type)::varchar(16), sqlc.arg(reason)::text, sqlc.narg(expires_at)::timestamp, sqlc.arg(issued_by)::varchar(42));
+
+-- name: CreateModerationLog :exec
+INSERT INTO moderation_log (action, actor, target_type, target_id, reason, before, after)
+VALUES (sqlc.arg(action)::varchar(16), sqlc.arg(actor)::varchar(42), sqlc.arg(target_type)::varchar(16), sqlc.arg(target_id)::varchar(42), sqlc.arg(reason)::text, sqlc.narg(before)::jsonb, sqlc.narg(after)::jsonb);
+
+-- name: GetModerationLog :many
+SELECT *, COUNT(*) OVER() AS full_count
+FROM moderation_log
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
 -- name: CreateComment :one
 INSERT INTO comments (address, thread_id, replied_to_comment_id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 RETURNING id;
 
--- Order by random is not performant as we need to do a full table scan.
--- Move to TABLESAMPLE SYSTEM_ROWS(N) when performance becomes an issue.
--- Table sample is not random enough until the table gets big.
--- https://www.postgresql.org/docs/current/tsm-system-rows.html
--- name: GetThreads :many
+-- name: UpdateThreadActivity :exec
+UPDATE threads
+SET active_at = NOW()
+WHERE id = $1;
+
+-- Feeds are keyset paginated on (sort key, id) so pages stay stable as new threads arrive.
+-- The cursor is null when fetching the first page and the board is null for the feed of every board.
+-- pinned threads are left out of the feeds and fetched separately for their first page
+-- name: GetPinnedThreads :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND t.pinned_until > NOW()
+AND (sqlc.narg(board_id)::bigint IS NULL OR t.board_id = sqlc.narg(board_id)::bigint)
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC;
+
+-- name: SetThreadLocked :execrows
+UPDATE threads
+SET is_locked = sqlc.arg(is_locked)::boolean
+WHERE id = sqlc.arg(id)::bigint
+AND is_deleted = FALSE;
+
+-- name: SetThreadPinned :execrows
+UPDATE threads
+SET pinned_until = sqlc.narg(pinned_until)::timestamp
+WHERE id = sqlc.arg(id)::bigint
+AND is_deleted = FALSE;
+
+-- name: SetThreadArchived :execrows
+UPDATE threads
+SET archived_at = CASE WHEN sqlc.arg(is_archived)::boolean THEN COALESCE(archived_at, NOW()) ELSE NULL END
+WHERE id = sqlc.arg(id)::bigint
+AND is_deleted = FALSE;
+
+-- pinned threads are left alone until they are unpinned
+-- name: ArchiveInactiveThreads :execrows
+UPDATE threads
+SET archived_at = NOW()
+WHERE archived_at IS NULL
+AND is_deleted = FALSE
+AND active_at < sqlc.arg(inactive_since)::timestamp
+AND (pinned_until IS NULL OR pinned_until <= NOW());
+
+-- name: GetHotThreads :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND (sqlc.narg(board_id)::bigint IS NULL OR t.board_id = sqlc.narg(board_id)::bigint)
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.hot_score, t.id) < (sqlc.narg(cursor_hot_score)::float8, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.hot_score DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetNewThreads :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND (sqlc.narg(board_id)::bigint IS NULL OR t.board_id = sqlc.narg(board_id)::bigint)
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.created_at, t.id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetTopThreads :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND (sqlc.narg(board_id)::bigint IS NULL OR t.board_id = sqlc.narg(board_id)::bigint)
+AND t.created_at >= sqlc.arg(since)::timestamp
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.votes, t.id) < (sqlc.narg(cursor_votes)::bigint, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.votes DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetActiveThreads :many
 SELECT
 	t.*,
 	u.address as address,
@@ -62,8 +335,77 @@ SELECT
 FROM threads t
 INNER JOIN users u on t.address = u.address
 WHERE t.is_deleted = FALSE
-ORDER BY RANDOM()
-LIMIT $1::bigint;
+AND t.is_hidden = FALSE
+AND (t.pinned_until IS NULL OR t.pinned_until <= NOW())
+AND (sqlc.narg(board_id)::bigint IS NULL OR t.board_id = sqlc.narg(board_id)::bigint)
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.active_at, t.id) < (sqlc.narg(cursor_active_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.active_at DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetNewThreadsByAddress :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.address = sqlc.arg(address)::varchar(42)
+AND t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.created_at, t.id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.created_at DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetTopThreadsByAddress :many
+SELECT
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.address = sqlc.arg(address)::varchar(42)
+AND t.is_deleted = FALSE
+AND t.is_hidden = FALSE
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (t.votes, t.id) < (sqlc.narg(cursor_votes)::bigint, sqlc.narg(cursor_id)::bigint))
+AND (t.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = t.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY t.votes DESC, t.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
 
 -- name: GetThread :one
 SELECT 
@@ -83,6 +425,25 @@ INNER JOIN users u on t.address = u.address
 WHERE t.id = $1
 AND t.is_deleted = FALSE;
 
+-- deleted threads are only read to be restored
+-- name: GetDeletedThread :one
+SELECT 
+	t.*,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM threads t
+INNER JOIN users u on t.address = u.address
+WHERE t.id = $1
+AND t.is_deleted = TRUE;
+
 -- name: GetComments :many
 SELECT
 	c.*,
@@ -113,10 +474,346 @@ INNER JOIN users u on c.address = u.address
 LEFT JOIN comments r on c.replied_to_comment_id = r.id
 WHERE c.thread_id = $1
 AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (c.address = $4 OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
 ORDER BY c.created_at DESC
 OFFSET $2::bigint
 LIMIT $3::bigint;
 
+-- Comments are keyset paginated on (created_at, id) from a cursor in either direction.
+-- Older comments are walked from the cursor (or the newest comment when the cursor is null).
+-- name: GetOlderComments :many
+SELECT
+	c.*,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.thread_id = sqlc.arg(thread_id)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (c.created_at, c.id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+AND (c.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- Newer comments are walked from the cursor in ascending order.
+-- name: GetNewerComments :many
+SELECT
+	c.*,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.thread_id = sqlc.arg(thread_id)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (c.created_at, c.id) > (sqlc.arg(cursor_created_at)::timestamp, sqlc.arg(cursor_id)::bigint)
+AND (c.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at ASC, c.id ASC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetNewCommentsByAddress :many
+SELECT
+	c.*,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.address = sqlc.arg(address)::varchar(42)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (c.created_at, c.id) < (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+AND (c.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- name: GetTopCommentsByAddress :many
+SELECT
+	c.*,
+	r.id as r_id,
+	r.address as r_address,
+	r.content as r_content,
+	r.image_file_name as r_image_file_name,
+	r.image_original_url as r_image_original_url,
+	r.image_original_content_type as r_image_original_content_type,
+	r.image_formatted_url as r_image_formatted_url,
+	r.image_formatted_content_type as r_image_formatted_content_type,
+	r.is_deleted as r_is_deleted,
+	r.created_at as r_created_at,
+	r.deleted_at as r_deleted_at,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM comments c
+INNER JOIN users u on c.address = u.address
+LEFT JOIN comments r on c.replied_to_comment_id = r.id
+WHERE c.address = sqlc.arg(address)::varchar(42)
+AND c.is_deleted = FALSE
+AND c.is_hidden = FALSE
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (c.votes, c.id) < (sqlc.narg(cursor_votes)::bigint, sqlc.narg(cursor_id)::bigint))
+AND (c.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = c.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY c.votes DESC, c.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
+-- Comment trees are walked with a recursive cte from the replies of a parent comment
+-- (or the top level comments when parent_id is null) down to max_depth.
+-- Only the first reply_limit replies of each nested comment are walked, the rest are loaded from a cursor.
+-- name: GetCommentTree :many
+WITH RECURSIVE roots AS (
+	SELECT c.*
+	FROM comments c
+	WHERE c.thread_id = sqlc.arg(thread_id)
+	AND c.replied_to_comment_id IS NOT DISTINCT FROM sqlc.narg(parent_id)::bigint
+	AND (sqlc.narg(cursor_id)::bigint IS NULL OR (c.created_at, c.id) > (sqlc.narg(cursor_created_at)::timestamp, sqlc.narg(cursor_id)::bigint))
+	AND c.is_hidden = FALSE
+	AND (c.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+		SELECT 1 FROM bans b
+		WHERE b.address = c.address
+		AND b.type = 'shadow'
+		AND b.revoked_at IS NULL
+		AND (b.expires_at IS NULL OR b.expires_at > NOW())
+	))
+	ORDER BY c.created_at ASC, c.id ASC
+	LIMIT sqlc.arg(page_limit)::bigint
+), tree AS (
+	SELECT roots.*, 1 AS depth
+	FROM roots
+	UNION ALL
+	SELECT c.*, tree.depth + 1
+	FROM tree
+	CROSS JOIN LATERAL (
+		SELECT r.*
+		FROM comments r
+		WHERE tree.depth < sqlc.arg(max_depth)::int
+		AND r.replied_to_comment_id = tree.id
+		AND r.is_hidden = FALSE
+		AND (r.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+			SELECT 1 FROM bans b
+			WHERE b.address = r.address
+			AND b.type = 'shadow'
+			AND b.revoked_at IS NULL
+			AND (b.expires_at IS NULL OR b.expires_at > NOW())
+		))
+		ORDER BY r.created_at ASC, r.id ASC
+		LIMIT sqlc.arg(reply_limit)::bigint
+	) c
+)
+SELECT
+	t.id,
+	t.thread_id,
+	t.replied_to_comment_id,
+	t.address,
+	t.content,
+	t.image_file_name,
+	t.image_original_url,
+	t.image_original_content_type,
+	t.image_formatted_url,
+	t.image_formatted_content_type,
+	t.votes,
+	t.is_deleted,
+	t.created_at,
+	t.deleted_at,
+	t.edited_at,
+	t.is_hidden,
+	t.deleted_by,
+	t.depth::int as depth,
+	(
+		SELECT COUNT(*) FROM comments rc
+		WHERE rc.replied_to_comment_id = t.id
+		AND rc.is_hidden = FALSE
+		AND (rc.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+			SELECT 1 FROM bans b
+			WHERE b.address = rc.address
+			AND b.type = 'shadow'
+			AND b.revoked_at IS NULL
+			AND (b.expires_at IS NULL OR b.expires_at > NOW())
+		))
+	) as reply_count,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM tree t
+INNER JOIN users u on t.address = u.address
+ORDER BY t.depth ASC, t.created_at ASC, t.id ASC;
+
+-- Threads and comments are searched together and ranked against each other.
+-- Results are keyset paginated on (rank, kind, id) and snippets are only highlighted for the returned page.
+-- name: Search :many
+WITH search_query AS (
+	SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS q
+), results AS (
+	SELECT
+		'thread'::text AS kind,
+		t.id,
+		t.id AS thread_id,
+		t.title,
+		t.title || ' ' || t.content AS body,
+		t.address,
+		t.created_at,
+		ts_rank(t.search_vector, search_query.q)::float8 AS rank,
+		search_query.q AS q
+	FROM threads t, search_query
+	WHERE t.search_vector @@ search_query.q
+	AND t.is_deleted = FALSE
+	AND t.is_hidden = FALSE
+	UNION ALL
+	SELECT
+		'comment'::text AS kind,
+		c.id,
+		c.thread_id,
+		t.title,
+		c.content AS body,
+		c.address,
+		c.created_at,
+		ts_rank(c.search_vector, search_query.q)::float8 AS rank,
+		search_query.q AS q
+	FROM comments c
+	INNER JOIN threads t ON c.thread_id = t.id, search_query
+	WHERE c.search_vector @@ search_query.q
+	AND c.is_deleted = FALSE
+	AND c.is_hidden = FALSE
+	AND t.is_deleted = FALSE
+	AND t.is_hidden = FALSE
+)
+SELECT
+	r.kind,
+	r.id,
+	r.thread_id,
+	r.title,
+	ts_headline('english', translate(r.body, chr(2) || chr(3), ''), r.q, 'StartSel="' || chr(2) || '", StopSel="' || chr(3) || '", MaxFragments=2, MaxWords=30, MinWords=10')::text AS snippet,
+	r.created_at,
+	r.rank,
+	u.address as address,
+	u.ens_name as ens_name,
+	u.ens_avatar_file_name as ens_avatar_file_name,
+	u.ens_avatar_original_url as ens_avatar_original_url,
+	u.ens_avatar_original_content_type as ens_avatar_original_content_type,
+	u.ens_avatar_formatted_url as ens_avatar_formatted_url,
+	u.ens_avatar_formatted_content_type as ens_avatar_formatted_content_type,
+	u.reputation as reputation,
+	u.created_at as user_created_at,
+	u.updated_at as user_updated_at
+FROM results r
+INNER JOIN users u on r.address = u.address
+WHERE (sqlc.narg(author)::varchar IS NULL OR r.address = sqlc.narg(author)::varchar)
+AND (sqlc.narg(created_after)::timestamp IS NULL OR r.created_at >= sqlc.narg(created_after)::timestamp)
+AND (sqlc.narg(created_before)::timestamp IS NULL OR r.created_at < sqlc.narg(created_before)::timestamp)
+AND (sqlc.narg(cursor_id)::bigint IS NULL OR (r.rank, r.kind, r.id) < (sqlc.narg(cursor_rank)::float8, sqlc.narg(cursor_kind)::text, sqlc.narg(cursor_id)::bigint))
+AND (r.address = sqlc.narg(viewer)::varchar(42) OR NOT EXISTS (
+	SELECT 1 FROM bans b
+	WHERE b.address = r.address
+	AND b.type = 'shadow'
+	AND b.revoked_at IS NULL
+	AND (b.expires_at IS NULL OR b.expires_at > NOW())
+))
+ORDER BY r.rank DESC, r.kind DESC, r.id DESC
+LIMIT sqlc.arg(page_limit)::bigint;
+
 -- name: GetComment :one
 SELECT
 	c.*,
@@ -148,16 +845,79 @@ WHERE c.id = $1;
 
 -- name: DeleteThread :one
 UPDATE threads
-SET is_deleted = TRUE, deleted_at = NOW()
-WHERE id = $1
+SET is_deleted = TRUE, deleted_at = NOW(), deleted_by = sqlc.arg(deleted_by)::varchar(42)
+WHERE id = sqlc.arg(id)::bigint
 RETURNING id as thread_id;
 
 -- name: DeleteComment :one
 UPDATE comments
-SET is_deleted = TRUE, deleted_at = NOW()
-WHERE id = $1
+SET is_deleted = TRUE, deleted_at = NOW(), deleted_by = sqlc.arg(deleted_by)::varchar(42)
+WHERE id = sqlc.arg(id)::bigint
 RETURNING id as comment_id;
 
+-- name: RestoreThread :execrows
+UPDATE threads
+SET is_deleted = FALSE, deleted_at = NULL, deleted_by = NULL
+WHERE id = $1
+AND is_deleted = TRUE;
+
+-- name: RestoreComment :execrows
+UPDATE comments
+SET is_deleted = FALSE, deleted_at = NULL, deleted_by = NULL
+WHERE id = $1
+AND is_deleted = TRUE;
+
+-- name: CreateRestoration :exec
+INSERT INTO restorations (target_type, target_id, restored_by, reason)
+VALUES (sqlc.arg(target_type)::varchar(16), sqlc.arg(target_id)::bigint, sqlc.arg(restored_by)::varchar(42), sqlc.arg(reason)::text);
+
+-- The current version of a thread is kept as a revision before it is edited
+-- name: CreateThreadRevision :exec
+INSERT INTO thread_revisions (thread_id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at)
+SELECT id, title, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, COALESCE(edited_at, created_at)
+FROM threads
+WHERE id = $1;
+
+-- name: UpdateThread :execrows
+UPDATE threads
+SET title = $2,
+	content = $3,
+	image_file_name = $4,
+	image_original_url = $5,
+	image_original_content_type = $6,
+	image_formatted_url = $7,
+	image_formatted_content_type = $8,
+	edited_at = NOW()
+WHERE id = $1 AND is_deleted = FALSE;
+
+-- name: GetThreadRevisions :many
+SELECT * FROM thread_revisions
+WHERE thread_id = $1
+ORDER BY revised_at DESC, id DESC;
+
+-- The current version of a comment is kept as a revision before it is edited
+-- name: CreateCommentRevision :exec
+INSERT INTO comment_revisions (comment_id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, created_at)
+SELECT id, content, image_file_name, image_original_url, image_original_content_type, image_formatted_url, image_formatted_content_type, COALESCE(edited_at, created_at)
+FROM comments
+WHERE id = $1;
+
+-- name: UpdateComment :execrows
+UPDATE comments
+SET content = $2,
+	image_file_name = $3,
+	image_original_url = $4,
+	image_original_content_type = $5,
+	image_formatted_url = $6,
+	image_formatted_content_type = $7,
+	edited_at = NOW()
+WHERE id = $1 AND is_deleted = FALSE;
+
+-- name: GetCommentRevisions :many
+SELECT * FROM comment_revisions
+WHERE comment_id = $1
+ORDER BY revised_at DESC, id DESC;
+
 -- name: CreateThreadUpVote :exec
 INSERT INTO thread_votes (address, thread_id, vote)
 VALUES ($1, $2, 1)
@@ -194,13 +954,17 @@ VALUES ($1, $2, 0)
 ON CONFLICT (address, comment_id) DO UPDATE SET vote = 0, updated_at = NOW()
 WHERE comment_votes.updated_at < TO_TIMESTAMP($3);
 
+-- hot score decays votes by the age of the thread and must be kept in sync with the votes
 -- name: AggregateThreadVotes :exec
 UPDATE threads
-SET votes = (
-	SELECT COALESCE(SUM(vote), 0)
+SET
+	votes = sub.votes,
+	hot_score = SIGN(sub.votes) * LOG(GREATEST(ABS(sub.votes), 1)) + EXTRACT(EPOCH FROM threads.created_at) / 45000
+FROM (
+	SELECT COALESCE(SUM(vote), 0) AS votes
 	FROM thread_votes
 	WHERE thread_votes.thread_id = $1
-)
+) AS sub
 WHERE threads.id = $1;
 
 -- name: AggregateCommentVotes :exec
@@ -210,4 +974,285 @@ SET votes = (
 	FROM comment_votes
 	WHERE comment_votes.comment_id = $1
 )
-WHERE comments.id = $1;
\ No newline at end of file
+WHERE comments.id = $1;
+-- votes of a single user on a page of threads, missing rows mean the user never voted
+-- name: GetThreadVotesByAddress :many
+SELECT thread_id, vote
+FROM thread_votes
+WHERE address = sqlc.arg(address)::varchar(42)
+AND thread_id = ANY(sqlc.arg(thread_ids)::bigint[]);
+
+-- name: GetCommentVotesByAddress :many
+SELECT comment_id, vote
+FROM comment_votes
+WHERE address = sqlc.arg(address)::varchar(42)
+AND comment_id = ANY(sqlc.arg(comment_ids)::bigint[]);
+
+-- redelivered stream messages must not notify twice so duplicates are ignored
+-- name: CreateNotification :exec
+INSERT INTO notifications (recipient, type, actor, thread_id, comment_id)
+VALUES (sqlc.arg(recipient)::varchar(42), sqlc.arg(type)::varchar(16), sqlc.arg(actor)::varchar(42), sqlc.arg(thread_id)::bigint, sqlc.arg(comment_id)::bigint)
+ON CONFLICT (recipient, comment_id) DO NOTHING;
+
+-- name: GetNotifications :many
+SELECT *, COUNT(*) OVER() AS full_count
+FROM notifications
+WHERE recipient = sqlc.arg(recipient)::varchar(42)
+AND (NOT sqlc.arg(unread_only)::boolean OR read_at IS NULL)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- name: CountUnreadNotifications :one
+SELECT COUNT(*)
+FROM notifications
+WHERE recipient = sqlc.arg(recipient)::varchar(42)
+AND read_at IS NULL;
+
+-- notifications that were already read keep their original read_at
+-- name: ReadNotification :execrows
+UPDATE notifications
+SET read_at = COALESCE(read_at, NOW())
+WHERE id = sqlc.arg(id)::bigint
+AND recipient = sqlc.arg(recipient)::varchar(42);
+
+-- name: ReadAllNotifications :execrows
+UPDATE notifications
+SET read_at = NOW()
+WHERE recipient = sqlc.arg(recipient)::varchar(42)
+AND read_at IS NULL;
+
+-- ens names are matched case insensitively
+-- name: GetAddressesByEnsNames :many
+SELECT address
+FROM users
+WHERE LOWER(ens_name) = ANY(sqlc.arg(ens_names)::text[]);
+
+-- name: CreateThreadSubscription :exec
+INSERT INTO thread_subscriptions (address, thread_id)
+VALUES (sqlc.arg(address)::varchar(42), sqlc.arg(thread_id)::bigint)
+ON CONFLICT (address, thread_id) DO NOTHING;
+
+-- name: DeleteThreadSubscription :exec
+DELETE FROM thread_subscriptions
+WHERE address = sqlc.arg(address)::varchar(42)
+AND thread_id = sqlc.arg(thread_id)::bigint;
+
+-- name: GetThreadSubscribers :many
+SELECT address
+FROM thread_subscriptions
+WHERE thread_id = sqlc.arg(thread_id)::bigint;
+
+-- name: CreateWebhook :one
+INSERT INTO webhooks (url, events, secret, created_by)
+VALUES (sqlc.arg(url)::text, sqlc.arg(events)::varchar(32)[], sqlc.arg(secret)::text, sqlc.arg(created_by)::varchar(42))
+RETURNING *;
+
+-- name: GetWebhooks :many
+SELECT *
+FROM webhooks
+ORDER BY id ASC;
+
+-- name: GetActiveWebhooksByEvent :many
+SELECT *
+FROM webhooks
+WHERE disabled_at IS NULL
+AND sqlc.arg(event)::varchar(32) = ANY(events);
+
+-- name: DeleteWebhook :execrows
+DELETE FROM webhooks
+WHERE id = sqlc.arg(id)::bigint;
+
+-- name: EnableWebhook :one
+UPDATE webhooks
+SET disabled_at = NULL, consecutive_failures = 0
+WHERE id = sqlc.arg(id)::bigint
+RETURNING *;
+
+-- name: CreateWebhookDelivery :exec
+INSERT INTO webhook_deliveries (webhook_id, event_type, event_id, status_code, error, attempts, succeeded)
+VALUES (sqlc.arg(webhook_id)::bigint, sqlc.arg(event_type)::varchar(32), sqlc.arg(event_id)::varchar(64), sqlc.narg(status_code)::int, sqlc.arg(error)::text, sqlc.arg(attempts)::int, sqlc.arg(succeeded)::boolean);
+
+-- name: ResetWebhookFailures :exec
+UPDATE webhooks
+SET consecutive_failures = 0
+WHERE id = sqlc.arg(id)::bigint;
+
+-- the webhook is disabled once it fails max_failures times in a row
+-- name: IncrementWebhookFailures :one
+UPDATE webhooks
+SET
+	consecutive_failures = consecutive_failures + 1,
+	disabled_at = CASE
+		WHEN disabled_at IS NULL AND consecutive_failures + 1 >= sqlc.arg(max_failures)::bigint THEN NOW()
+		ELSE disabled_at
+	END
+WHERE id = sqlc.arg(id)::bigint
+RETURNING disabled_at IS NOT NULL AS is_disabled;
+
+-- name: GetWebhookDeliveries :many
+SELECT *, COUNT(*) OVER() AS full_count
+FROM webhook_deliveries
+WHERE webhook_id = sqlc.arg(webhook_id)::bigint
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- An endpoint registered by another address is left untouched and no rows are affected
+-- name: CreatePushSubscription :execrows
+INSERT INTO push_subscriptions (endpoint, address, p256dh, auth)
+VALUES (sqlc.arg(endpoint)::text, sqlc.arg(address)::varchar(42), sqlc.arg(p256dh)::varchar(128), sqlc.arg(auth)::varchar(64))
+ON CONFLICT (endpoint) DO UPDATE
+SET p256dh = EXCLUDED.p256dh, auth = EXCLUDED.auth, created_at = NOW()
+WHERE push_subscriptions.address = EXCLUDED.address;
+
+-- name: DeletePushSubscription :execrows
+DELETE FROM push_subscriptions
+WHERE endpoint = sqlc.arg(endpoint)::text
+AND address = sqlc.arg(address)::varchar(42);
+
+-- name: DeleteExpiredPushSubscription :exec
+DELETE FROM push_subscriptions
+WHERE endpoint = sqlc.arg(endpoint)::text;
+
+-- name: GetPushSubscriptionsByAddresses :many
+SELECT *
+FROM push_subscriptions
+WHERE address = ANY(sqlc.arg(addresses)::varchar(42)[]);
+
+-- name: GetLastDistribution :one
+SELECT *
+FROM distributions
+ORDER BY round DESC
+LIMIT 1;
+
+-- Every vote decision made up to the cutoff that is not consumed by a distribution.
+-- latest is false if the voter changed their vote since, counted_before is true if the vote already counted towards a distribution.
+-- name: GetUndistributedVoteDecisions :many
+SELECT d.*,
+	NOT EXISTS (
+		SELECT 1 FROM vote_decisions l
+		WHERE l.type = d.type AND l.target_id = d.target_id AND l.voter = d.voter
+		AND l.voted_at > d.voted_at
+		AND l.created_at <= sqlc.arg(cutoff)::timestamp
+	) AS latest,
+	EXISTS (
+		SELECT 1 FROM vote_decisions c
+		WHERE c.type = d.type AND c.target_id = d.target_id AND c.voter = d.voter
+		AND c.counted = TRUE
+	) AS counted_before
+FROM vote_decisions d
+WHERE d.distribution_id IS NULL
+AND d.created_at <= sqlc.arg(cutoff)::timestamp
+ORDER BY d.id ASC;
+
+-- The vote decisions cast in the window and made in the id range, null bounds are left open.
+-- latest and counted_before are evaluated as if a single round covered the range right after the rounds before it.
+-- name: GetVoteDecisionsInRange :many
+SELECT d.*,
+	NOT EXISTS (
+		SELECT 1 FROM vote_decisions l
+		WHERE l.type = d.type AND l.target_id = d.target_id AND l.voter = d.voter
+		AND l.voted_at > d.voted_at
+		AND (sqlc.narg(voted_before)::timestamp IS NULL OR l.voted_at <= sqlc.narg(voted_before)::timestamp)
+		AND (sqlc.narg(from_id)::bigint IS NULL OR l.id >= sqlc.narg(from_id)::bigint)
+		AND (sqlc.narg(to_id)::bigint IS NULL OR l.id <= sqlc.narg(to_id)::bigint)
+	) AS latest,
+	EXISTS (
+		SELECT 1 FROM vote_decisions c
+		WHERE c.type = d.type AND c.target_id = d.target_id AND c.voter = d.voter
+		AND c.counted = TRUE
+		AND (c.voted_at < sqlc.narg(voted_after)::timestamp OR c.id < sqlc.narg(from_id)::bigint)
+	) AS counted_before
+FROM vote_decisions d
+WHERE (sqlc.narg(voted_after)::timestamp IS NULL OR d.voted_at >= sqlc.narg(voted_after)::timestamp)
+AND (sqlc.narg(voted_before)::timestamp IS NULL OR d.voted_at <= sqlc.narg(voted_before)::timestamp)
+AND (sqlc.narg(from_id)::bigint IS NULL OR d.id >= sqlc.narg(from_id)::bigint)
+AND (sqlc.narg(to_id)::bigint IS NULL OR d.id <= sqlc.narg(to_id)::bigint)
+ORDER BY d.id ASC;
+
+-- a round that already exists returns no rows
+-- name: CreateDistribution :one
+INSERT INTO distributions (round, votes, amount, merkle_root, participants)
+VALUES (sqlc.arg(round)::timestamp, sqlc.arg(votes)::bigint, sqlc.arg(amount)::numeric, sqlc.arg(merkle_root)::varchar(66), sqlc.arg(participants)::bigint)
+ON CONFLICT (round) DO NOTHING
+RETURNING *;
+
+-- name: CreateAllocation :exec
+INSERT INTO allocations (distribution_id, address, amount, votes)
+VALUES (sqlc.arg(distribution_id)::bigint, sqlc.arg(address)::varchar(42), sqlc.arg(amount)::numeric, sqlc.arg(votes)::bigint);
+
+-- name: DistributeVoteDecisions :execrows
+UPDATE vote_decisions
+SET distribution_id = sqlc.arg(distribution_id)::bigint, counted = id = ANY(sqlc.arg(counted_ids)::bigint[])
+WHERE distribution_id IS NULL
+AND created_at <= sqlc.arg(cutoff)::timestamp;
+
+-- a decision that already exists for the same vote is left as is
+-- name: CreateVoteDecision :exec
+INSERT INTO vote_decisions (type, target_id, voter, author, vote, reputation, accepted, reason, voted_at)
+VALUES (
+	sqlc.arg(type)::varchar(16),
+	sqlc.arg(target_id)::bigint,
+	sqlc.arg(voter)::varchar(42),
+	sqlc.narg(author)::varchar(42),
+	sqlc.arg(vote)::smallint,
+	sqlc.arg(reputation)::numeric,
+	sqlc.arg(accepted)::boolean,
+	sqlc.narg(reason)::varchar(32),
+	sqlc.arg(voted_at)::timestamp
+)
+ON CONFLICT (type, target_id, voter, voted_at) DO NOTHING;
+
+-- name: CreateMerkleTree :exec
+INSERT INTO merkle_trees (distribution_id, nodes)
+VALUES (sqlc.arg(distribution_id)::bigint, sqlc.arg(nodes)::varchar(66)[]);
+
+-- name: GetMerkleTree :one
+SELECT nodes
+FROM merkle_trees
+WHERE distribution_id = $1;
+
+-- name: GetDistribution :one
+SELECT *
+FROM distributions
+WHERE id = $1;
+
+-- name: GetAllocation :one
+SELECT a.*, d.round
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.distribution_id = sqlc.arg(distribution_id)::bigint
+AND a.address = sqlc.arg(address)::varchar(42);
+
+-- name: GetDistributions :many
+SELECT *, COUNT(*) OVER() AS full_count
+FROM distributions
+ORDER BY round DESC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- largest allocations first
+-- name: GetAllocationsByDistribution :many
+SELECT a.*, d.round, COUNT(*) OVER() AS full_count
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.distribution_id = sqlc.arg(distribution_id)::bigint
+ORDER BY a.amount DESC, a.address ASC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- latest rounds first
+-- name: GetAllocationsByAddress :many
+SELECT a.*, d.round, COUNT(*) OVER() AS full_count
+FROM allocations a
+INNER JOIN distributions d ON d.id = a.distribution_id
+WHERE a.address = sqlc.arg(address)::varchar(42)
+ORDER BY d.round DESC
+LIMIT sqlc.arg(page_limit)::bigint
+OFFSET sqlc.arg(page_offset)::bigint;
+
+-- name: GetEarnings :one
+SELECT COALESCE(SUM(amount), 0)::numeric AS amount, COUNT(*) AS rounds
+FROM allocations
+WHERE address = $1;
@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5"
+)
+
+// Returns the number of open reports of the target including the new report
+func (p *postgresGateway) CreateReport(ctx context.Context, report entities.Report) (int64, error) {
+	if err := p.queries.CreateReport(ctx, bindings.CreateReportParams{
+		Reporter:   report.Reporter(),
+		TargetType: string(report.TargetType()),
+		TargetID:   report.TargetId(),
+		Reason:     string(report.Reason()),
+		Details:    report.Details(),
+	}); err != nil {
+		return 0, err
+	}
+
+	return p.CountOpenReports(ctx, report.TargetType(), report.TargetId())
+}
+
+func (p *postgresGateway) CountOpenReports(ctx context.Context, targetType entities.ReportTargetType, targetId string) (int64, error) {
+	return p.queries.CountOpenReports(ctx, bindings.CountOpenReportsParams{
+		TargetType: string(targetType),
+		TargetID:   targetId,
+	})
+}
+
+// Returns the page of report groups and the total number of report groups
+func (p *postgresGateway) GetOpenReports(ctx context.Context, offset int64, limit int64) ([]entities.ReportGroup, int64, error) {
+	rows, err := p.queries.GetOpenReports(ctx, bindings.GetOpenReportsParams{
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	groups := []entities.ReportGroup{}
+	for _, row := range rows {
+		count = row.FullCount
+
+		reasons := []entities.ReportReason{}
+		for _, reason := range row.Reasons {
+			reasons = append(reasons, entities.ReportReason(reason))
+		}
+		groups = append(groups, entities.NewReportGroup(entities.ReportGroupParams{
+			TargetType:      entities.ReportTargetType(row.TargetType),
+			TargetId:        row.TargetID,
+			Count:           row.ReportCount,
+			Reasons:         reasons,
+			Details:         row.Details,
+			FirstReportedAt: row.FirstReportedAt.Time,
+			LastReportedAt:  row.LastReportedAt.Time,
+		}))
+	}
+
+	return groups, count, nil
+}
+
+// The resolution is applied to the target in the same transaction so reports are never left open for an applied action
+func (p *postgresGateway) ResolveReports(ctx context.Context, spec gateways.ResolveReportsSpec) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	switch spec.Resolution {
+	case entities.DismissReportResolution:
+		if err := setReportTargetHidden(ctx, qtx, spec.TargetType, spec.TargetId, false); err != nil {
+			return fmt.Errorf("failed to unhide %v %v: %w", spec.TargetType, spec.TargetId, err)
+		}
+	case entities.DeleteReportResolution:
+		if err := deleteReportTarget(ctx, qtx, spec.TargetType, spec.TargetId, spec.ResolvedBy); err != nil {
+			return fmt.Errorf("failed to delete %v %v: %w", spec.TargetType, spec.TargetId, err)
+		}
+	case entities.BanReportResolution:
+		if spec.Ban == nil {
+			return errors.New("ban resolutions require a ban")
+		}
+		if err := createBan(ctx, qtx, *spec.Ban); err != nil {
+			return fmt.Errorf("failed to ban %v: %w", spec.Ban.Address(), err)
+		}
+	}
+
+	count, err := qtx.ResolveReports(ctx, bindings.ResolveReportsParams{
+		ResolvedBy: spec.ResolvedBy,
+		Resolution: string(spec.Resolution),
+		TargetType: string(spec.TargetType),
+		TargetID:   spec.TargetId,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := createModerationLog(ctx, qtx, spec.Log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// users cannot be hidden so only threads and comments are updated
+func setReportTargetHidden(ctx context.Context, queries *bindings.Queries, targetType entities.ReportTargetType, targetId string, hidden bool) error {
+	switch targetType {
+	case entities.ThreadReportTarget, entities.CommentReportTarget:
+		id, err := strconv.ParseInt(targetId, 10, 64)
+
+		if err != nil {
+			return err
+		}
+
+		if targetType == entities.ThreadReportTarget {
+			return queries.SetThreadHidden(ctx, bindings.SetThreadHiddenParams{
+				IsHidden: hidden,
+				ID:       id,
+			})
+		}
+
+		return queries.SetCommentHidden(ctx, bindings.SetCommentHiddenParams{
+			IsHidden: hidden,
+			ID:       id,
+		})
+	default:
+		return nil
+	}
+}
+
+func deleteReportTarget(ctx context.Context, queries *bindings.Queries, targetType entities.ReportTargetType, targetId string, deletedBy string) error {
+	id, err := strconv.ParseInt(targetId, 10, 64)
+
+	if err != nil {
+		return err
+	}
+
+	switch targetType {
+	case entities.ThreadReportTarget:
+		_, err = queries.DeleteThread(ctx, bindings.DeleteThreadParams{
+			DeletedBy: deletedBy,
+			ID:        id,
+		})
+	case entities.CommentReportTarget:
+		_, err = queries.DeleteComment(ctx, bindings.DeleteCommentParams{
+			DeletedBy: deletedBy,
+			ID:        id,
+		})
+	default:
+		return fmt.Errorf("%v reports cannot be resolved by deleting", targetType)
+	}
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return common.ErrNotFound
+	}
+
+	return err
+}
+
+func (p *postgresGateway) SetThreadHidden(ctx context.Context, id int64, hidden bool) error {
+	return p.queries.SetThreadHidden(ctx, bindings.SetThreadHiddenParams{
+		IsHidden: hidden,
+		ID:       id,
+	})
+}
+
+func (p *postgresGateway) SetCommentHidden(ctx context.Context, id int64, hidden bool) error {
+	return p.queries.SetCommentHidden(ctx, bindings.SetCommentHiddenParams{
+		IsHidden: hidden,
+		ID:       id,
+	})
+}
@@ -0,0 +1,62 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/domain/entities"
+)
+
+func (p *postgresGateway) GetThreadRevisions(ctx context.Context, threadId int64) ([]entities.Revision, error) {
+	dbRevisions, err := p.queries.GetThreadRevisions(ctx, threadId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []entities.Revision{}
+	for _, dbRevision := range dbRevisions {
+		revisions = append(revisions, entities.NewRevision(entities.RevisionParams{
+			Id:      dbRevision.ID,
+			Title:   dbRevision.Title,
+			Content: dbRevision.Content,
+			Image: entities.NewImage(
+				dbRevision.ImageFileName,
+				dbRevision.ImageOriginalUrl,
+				dbRevision.ImageOriginalContentType,
+				dbRevision.ImageFormattedUrl,
+				dbRevision.ImageFormattedContentType,
+			),
+			CreatedAt: dbRevision.CreatedAt.Time,
+			RevisedAt: dbRevision.RevisedAt.Time,
+		}))
+	}
+
+	return revisions, nil
+}
+
+func (p *postgresGateway) GetCommentRevisions(ctx context.Context, commentId int64) ([]entities.Revision, error) {
+	dbRevisions, err := p.queries.GetCommentRevisions(ctx, commentId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []entities.Revision{}
+	for _, dbRevision := range dbRevisions {
+		revisions = append(revisions, entities.NewRevision(entities.RevisionParams{
+			Id:      dbRevision.ID,
+			Content: dbRevision.Content,
+			Image: entities.NewImage(
+				dbRevision.ImageFileName,
+				dbRevision.ImageOriginalUrl,
+				dbRevision.ImageOriginalContentType,
+				dbRevision.ImageFormattedUrl,
+				dbRevision.ImageFormattedContentType,
+			),
+			CreatedAt: dbRevision.CreatedAt.Time,
+			RevisedAt: dbRevision.RevisedAt.Time,
+		}))
+	}
+
+	return revisions, nil
+}
@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+)
+
+func (p *postgresGateway) GetRolesByAddress(ctx context.Context, address string) ([]entities.Role, error) {
+	dbRoles, err := p.queries.GetRolesByAddress(ctx, address)
+
+	if err != nil {
+		return nil, err
+	}
+
+	roles := []entities.Role{}
+	for _, dbRole := range dbRoles {
+		roles = append(roles, entities.NewRole(entities.RoleParams{
+			Address:   dbRole.Address,
+			Role:      entities.RoleType(dbRole.Role),
+			BoardId:   toInt64Ptr(dbRole.BoardID),
+			CreatedBy: dbRole.CreatedBy,
+			CreatedAt: dbRole.CreatedAt.Time,
+		}))
+	}
+
+	return roles, nil
+}
+
+// Granting a role that is already held is neither recorded as a change nor logged.
+// The log is nil for grants that are not made by a user.
+func (p *postgresGateway) GrantRole(ctx context.Context, address string, role entities.RoleType, boardId *int64, grantedBy string, log *entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.CreateRole(ctx, bindings.CreateRoleParams{
+		Address:   address,
+		Role:      string(role),
+		BoardID:   toInt8(boardId),
+		CreatedBy: grantedBy,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return nil
+	}
+
+	if err := qtx.CreateRoleChange(ctx, bindings.CreateRoleChangeParams{
+		Address:   address,
+		Role:      string(role),
+		BoardID:   toInt8(boardId),
+		Action:    string(entities.GrantRoleAction),
+		ChangedBy: grantedBy,
+	}); err != nil {
+		return fmt.Errorf("failed to record role change: %w", err)
+	}
+
+	if log != nil {
+		if err := createModerationLog(ctx, qtx, *log); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *postgresGateway) RevokeRole(ctx context.Context, address string, role entities.RoleType, boardId *int64, revokedBy string, log entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.DeleteRole(ctx, bindings.DeleteRoleParams{
+		Address: address,
+		Role:    string(role),
+		BoardID: toInt8(boardId),
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := qtx.CreateRoleChange(ctx, bindings.CreateRoleChangeParams{
+		Address:   address,
+		Role:      string(role),
+		BoardID:   toInt8(boardId),
+		Action:    string(entities.RevokeRoleAction),
+		ChangedBy: revokedBy,
+	}); err != nil {
+		return fmt.Errorf("failed to record role change: %w", err)
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"html"
+	"strings"
+
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *postgresGateway) Search(ctx context.Context, spec gateways.SearchSpec) ([]entities.SearchResult, error) {
+	params := bindings.SearchParams{
+		Query:     spec.Query,
+		PageLimit: spec.Limit,
+		Viewer:    toText(spec.Viewer),
+	}
+	if spec.Author != nil {
+		params.Author = pgtype.Text{String: *spec.Author, Valid: true}
+	}
+	if spec.CreatedAfter != nil {
+		params.CreatedAfter = pgtype.Timestamp{Time: *spec.CreatedAfter, Valid: true}
+	}
+	if spec.CreatedBefore != nil {
+		params.CreatedBefore = pgtype.Timestamp{Time: *spec.CreatedBefore, Valid: true}
+	}
+	if spec.Cursor != nil {
+		params.CursorID = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+		params.CursorRank = pgtype.Float8{Float64: spec.Cursor.Rank(), Valid: true}
+		params.CursorKind = pgtype.Text{String: string(spec.Cursor.Kind()), Valid: true}
+	}
+
+	rows, err := p.queries.Search(ctx, params)
+
+	if err != nil {
+		return nil, err
+	}
+
+	results := []entities.SearchResult{}
+	for _, row := range rows {
+		user := toUser(
+			row.Address,
+			row.EnsName,
+			row.EnsAvatarFileName,
+			row.EnsAvatarOriginalUrl,
+			row.EnsAvatarOriginalContentType,
+			row.EnsAvatarFormattedUrl,
+			row.EnsAvatarFormattedContentType,
+			row.Reputation,
+			row.UserCreatedAt,
+			row.UserUpdatedAt,
+		)
+		results = append(results, entities.NewSearchResult(entities.SearchResultParams{
+			Kind:      entities.SearchResultKind(row.Kind),
+			Id:        row.ID,
+			ThreadId:  row.ThreadID,
+			Title:     row.Title,
+			Snippet:   toSnippet(row.Snippet),
+			User:      user,
+			CreatedAt: row.CreatedAt.Time,
+			Rank:      row.Rank,
+		}))
+	}
+
+	return results, nil
+}
+
+// Matches are delimited with control characters by the query so the user content can be escaped before the
+// delimiters are swapped for <mark> tags. The query strips the delimiters from the content itself.
+var snippetReplacer = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
+func toSnippet(headline string) string {
+	return snippetReplacer.Replace(html.EscapeString(headline))
+}
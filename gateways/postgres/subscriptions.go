@@ -0,0 +1,28 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+)
+
+// subscribing twice is a no-op
+func (p *postgresGateway) SubscribeToThread(ctx context.Context, address string, threadId int64) error {
+	return p.queries.CreateThreadSubscription(ctx, bindings.CreateThreadSubscriptionParams{
+		Address:  address,
+		ThreadID: threadId,
+	})
+}
+
+// unsubscribing from a thread that is not followed is a no-op
+func (p *postgresGateway) UnsubscribeFromThread(ctx context.Context, address string, threadId int64) error {
+	return p.queries.DeleteThreadSubscription(ctx, bindings.DeleteThreadSubscriptionParams{
+		Address:  address,
+		ThreadID: threadId,
+	})
+}
+
+// the addresses following the thread
+func (p *postgresGateway) GetThreadSubscribers(ctx context.Context, threadId int64) ([]string, error) {
+	return p.queries.GetThreadSubscribers(ctx, threadId)
+}
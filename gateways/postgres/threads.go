@@ -3,23 +3,38 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
 	"time"
 
 	"github.com/daochanio/backend/common"
 	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
 	"github.com/daochanio/backend/gateways/postgres/bindings"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func (p *postgresGateway) CreateThread(
 	ctx context.Context,
 	address string,
+	boardId int64,
 	title string,
 	content string,
 	image *entities.Image,
 ) (entities.Thread, error) {
-	id, err := p.queries.CreateThread(ctx, bindings.CreateThreadParams{
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	id, err := qtx.CreateThread(ctx, bindings.CreateThreadParams{
 		Address:                   address,
+		BoardID:                   boardId,
 		Title:                     title,
 		Content:                   content,
 		ImageFileName:             image.FileName(),
@@ -33,54 +48,170 @@ func (p *postgresGateway) CreateThread(
 		return entities.Thread{}, err
 	}
 
+	// authors follow their own threads
+	if err := qtx.CreateThreadSubscription(ctx, bindings.CreateThreadSubscriptionParams{
+		Address:  address,
+		ThreadID: id,
+	}); err != nil {
+		return entities.Thread{}, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Thread{}, err
+	}
+
 	return p.GetThreadById(ctx, id)
 }
 
-func (p *postgresGateway) GetThreads(ctx context.Context, limit int64) ([]entities.Thread, error) {
-	dbThreads, err := p.queries.GetThreads(ctx, limit)
+func (p *postgresGateway) GetThreads(ctx context.Context, spec gateways.ThreadsSpec) ([]entities.Thread, error) {
+	cursorId := pgtype.Int8{}
+	if spec.Cursor != nil {
+		cursorId = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+	}
 
-	if err != nil {
-		return nil, err
+	boardId := pgtype.Int8{}
+	if spec.BoardId != nil {
+		boardId = pgtype.Int8{Int64: *spec.BoardId, Valid: true}
+	}
+
+	// every feed query returns the same columns so the rows can be converted to a single row type
+	var dbThreads []bindings.GetThreadRow
+	switch spec.Sort {
+	case entities.HotThreadSort:
+		cursorHotScore := pgtype.Float8{}
+		if spec.Cursor != nil {
+			cursorHotScore = pgtype.Float8{Float64: spec.Cursor.HotScore(), Valid: true}
+		}
+		rows, err := p.queries.GetHotThreads(ctx, bindings.GetHotThreadsParams{
+			BoardID:        boardId,
+			CursorID:       cursorId,
+			CursorHotScore: cursorHotScore,
+			Viewer:         toText(spec.Viewer),
+			PageLimit:      spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	case entities.NewThreadSort:
+		cursorCreatedAt := pgtype.Timestamp{}
+		if spec.Cursor != nil {
+			cursorCreatedAt = pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true}
+		}
+		rows, err := p.queries.GetNewThreads(ctx, bindings.GetNewThreadsParams{
+			BoardID:         boardId,
+			CursorID:        cursorId,
+			CursorCreatedAt: cursorCreatedAt,
+			Viewer:          toText(spec.Viewer),
+			PageLimit:       spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	case entities.TopThreadSort:
+		cursorVotes := pgtype.Int8{}
+		if spec.Cursor != nil {
+			cursorVotes = pgtype.Int8{Int64: spec.Cursor.Votes(), Valid: true}
+		}
+		rows, err := p.queries.GetTopThreads(ctx, bindings.GetTopThreadsParams{
+			BoardID:     boardId,
+			Since:       pgtype.Timestamp{Time: spec.Since, Valid: true},
+			CursorID:    cursorId,
+			CursorVotes: cursorVotes,
+			Viewer:      toText(spec.Viewer),
+			PageLimit:   spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	case entities.ActiveThreadSort:
+		cursorActiveAt := pgtype.Timestamp{}
+		if spec.Cursor != nil {
+			cursorActiveAt = pgtype.Timestamp{Time: spec.Cursor.ActiveAt(), Valid: true}
+		}
+		rows, err := p.queries.GetActiveThreads(ctx, bindings.GetActiveThreadsParams{
+			BoardID:        boardId,
+			CursorID:       cursorId,
+			CursorActiveAt: cursorActiveAt,
+			Viewer:         toText(spec.Viewer),
+			PageLimit:      spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	default:
+		return nil, fmt.Errorf("invalid thread sort: %v", spec.Sort)
 	}
 
 	threads := []entities.Thread{}
 	for _, dbThread := range dbThreads {
-		var deletedAt *time.Time
-		if dbThread.DeletedAt.Valid {
-			deletedAt = &dbThread.DeletedAt.Time
-		}
-
-		image := entities.NewImage(
-			dbThread.ImageFileName,
-			dbThread.ImageOriginalUrl,
-			dbThread.ImageOriginalContentType,
-			dbThread.ImageFormattedUrl,
-			dbThread.ImageFormattedContentType,
-		)
-		user := toUser(
-			dbThread.Address,
-			dbThread.EnsName,
-			dbThread.EnsAvatarFileName,
-			dbThread.EnsAvatarOriginalUrl,
-			dbThread.EnsAvatarOriginalContentType,
-			dbThread.EnsAvatarFormattedUrl,
-			dbThread.EnsAvatarFormattedContentType,
-			dbThread.Reputation,
-			dbThread.UserCreatedAt,
-			dbThread.UserUpdatedAt,
-		)
-		thread := entities.NewThread(entities.ThreadParams{
-			Id:        dbThread.ID,
-			Title:     dbThread.Title,
-			Content:   dbThread.Content,
-			Image:     image,
-			User:      user,
-			Votes:     dbThread.Votes,
-			CreatedAt: dbThread.CreatedAt.Time,
-			IsDeleted: dbThread.IsDeleted,
-			DeletedAt: deletedAt,
+		threads = append(threads, toThread(dbThread))
+	}
+	return threads, nil
+}
+
+func (p *postgresGateway) GetThreadsByAddress(ctx context.Context, spec gateways.ActivitySpec) ([]entities.Thread, error) {
+	cursorId := pgtype.Int8{}
+	if spec.Cursor != nil {
+		cursorId = pgtype.Int8{Int64: spec.Cursor.Id(), Valid: true}
+	}
+
+	var dbThreads []bindings.GetThreadRow
+	switch spec.Sort {
+	case entities.NewActivitySort:
+		cursorCreatedAt := pgtype.Timestamp{}
+		if spec.Cursor != nil {
+			cursorCreatedAt = pgtype.Timestamp{Time: spec.Cursor.CreatedAt(), Valid: true}
+		}
+		rows, err := p.queries.GetNewThreadsByAddress(ctx, bindings.GetNewThreadsByAddressParams{
+			Address:         spec.Address,
+			CursorID:        cursorId,
+			CursorCreatedAt: cursorCreatedAt,
+			Viewer:          toText(spec.Viewer),
+			PageLimit:       spec.Limit,
 		})
-		threads = append(threads, thread)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	case entities.TopActivitySort:
+		cursorVotes := pgtype.Int8{}
+		if spec.Cursor != nil {
+			cursorVotes = pgtype.Int8{Int64: spec.Cursor.Votes(), Valid: true}
+		}
+		rows, err := p.queries.GetTopThreadsByAddress(ctx, bindings.GetTopThreadsByAddressParams{
+			Address:     spec.Address,
+			CursorID:    cursorId,
+			CursorVotes: cursorVotes,
+			Viewer:      toText(spec.Viewer),
+			PageLimit:   spec.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			dbThreads = append(dbThreads, bindings.GetThreadRow(row))
+		}
+	default:
+		return nil, fmt.Errorf("invalid activity sort: %v", spec.Sort)
+	}
+
+	threads := []entities.Thread{}
+	for _, dbThread := range dbThreads {
+		threads = append(threads, toThread(dbThread))
 	}
 	return threads, nil
 }
@@ -96,6 +227,271 @@ func (p *postgresGateway) GetThreadById(ctx context.Context, id int64) (entities
 		return entities.Thread{}, err
 	}
 
+	return toThread(dbThread), nil
+}
+
+// The current version of the thread is kept as a revision in the same transaction as the edit
+func (p *postgresGateway) UpdateThread(ctx context.Context, id int64, title string, content string, image *entities.Image) (entities.Thread, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	if err := qtx.CreateThreadRevision(ctx, id); err != nil {
+		return entities.Thread{}, fmt.Errorf("failed to create thread revision: %w", err)
+	}
+
+	count, err := qtx.UpdateThread(ctx, bindings.UpdateThreadParams{
+		ID:                        id,
+		Title:                     title,
+		Content:                   content,
+		ImageFileName:             image.FileName(),
+		ImageOriginalUrl:          image.OriginalURL(),
+		ImageOriginalContentType:  image.OriginalContentType(),
+		ImageFormattedUrl:         image.FormattedURL(),
+		ImageFormattedContentType: image.FormattedContentType(),
+	})
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	if count == 0 {
+		return entities.Thread{}, common.ErrNotFound
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Thread{}, err
+	}
+
+	return p.GetThreadById(ctx, id)
+}
+
+// The log is nil when authors delete their own thread
+func (p *postgresGateway) DeleteThread(ctx context.Context, id int64, deletedBy string, log *entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	_, err = qtx.DeleteThread(ctx, bindings.DeleteThreadParams{
+		DeletedBy: deletedBy,
+		ID:        id,
+	})
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return common.ErrNotFound
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if log != nil {
+		if err := createModerationLog(ctx, qtx, *log); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (p *postgresGateway) GetDeletedThreadById(ctx context.Context, id int64) (entities.Thread, error) {
+	dbThread, err := p.queries.GetDeletedThread(ctx, id)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Thread{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	return toThread(bindings.GetThreadRow(dbThread)), nil
+}
+
+// Votes are recomputed in the same transaction since they keep being recorded while the thread is deleted.
+// The log is nil when authors restore their own thread.
+func (p *postgresGateway) RestoreThread(ctx context.Context, id int64, restoredBy string, reason string, log *entities.ModerationLog) (entities.Thread, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.RestoreThread(ctx, id)
+
+	if err != nil {
+		return entities.Thread{}, err
+	}
+
+	if count == 0 {
+		return entities.Thread{}, common.ErrNotFound
+	}
+
+	if err := qtx.CreateRestoration(ctx, bindings.CreateRestorationParams{
+		TargetType: string(entities.ThreadModerationTarget),
+		TargetID:   id,
+		RestoredBy: restoredBy,
+		Reason:     reason,
+	}); err != nil {
+		return entities.Thread{}, fmt.Errorf("failed to create restoration: %w", err)
+	}
+
+	if err := qtx.AggregateThreadVotes(ctx, id); err != nil {
+		return entities.Thread{}, fmt.Errorf("failed to aggregate thread votes: %w", err)
+	}
+
+	if log != nil {
+		if err := createModerationLog(ctx, qtx, *log); err != nil {
+			return entities.Thread{}, err
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return entities.Thread{}, err
+	}
+
+	return p.GetThreadById(ctx, id)
+}
+
+func (p *postgresGateway) GetPinnedThreads(ctx context.Context, boardId *int64, viewer *string) ([]entities.Thread, error) {
+	rows, err := p.queries.GetPinnedThreads(ctx, bindings.GetPinnedThreadsParams{
+		BoardID: toInt8(boardId),
+		Viewer:  toText(viewer),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	threads := []entities.Thread{}
+	for _, row := range rows {
+		threads = append(threads, toThread(bindings.GetThreadRow(row)))
+	}
+	return threads, nil
+}
+
+func (p *postgresGateway) SetThreadLocked(ctx context.Context, id int64, locked bool, log entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.SetThreadLocked(ctx, bindings.SetThreadLockedParams{
+		IsLocked: locked,
+		ID:       id,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// a nil time unpins the thread
+func (p *postgresGateway) SetThreadPinned(ctx context.Context, id int64, until *time.Time, log entities.ModerationLog) error {
+	pinnedUntil := pgtype.Timestamp{}
+	if until != nil {
+		pinnedUntil = pgtype.Timestamp{Time: *until, Valid: true}
+	}
+
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.SetThreadPinned(ctx, bindings.SetThreadPinnedParams{
+		PinnedUntil: pinnedUntil,
+		ID:          id,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Archiving an archived thread keeps its original archive time
+func (p *postgresGateway) SetThreadArchived(ctx context.Context, id int64, archived bool, log entities.ModerationLog) error {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	count, err := qtx.SetThreadArchived(ctx, bindings.SetThreadArchivedParams{
+		IsArchived: archived,
+		ID:         id,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if count == 0 {
+		return common.ErrNotFound
+	}
+
+	if err := createModerationLog(ctx, qtx, log); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// Returns the number of archived threads
+func (p *postgresGateway) ArchiveInactiveThreads(ctx context.Context, inactiveSince time.Time) (int64, error) {
+	return p.queries.ArchiveInactiveThreads(ctx, pgtype.Timestamp{Time: inactiveSince, Valid: true})
+}
+
+func toThread(dbThread bindings.GetThreadRow) entities.Thread {
 	var deletedAt *time.Time
 	if dbThread.DeletedAt.Valid {
 		deletedAt = &dbThread.DeletedAt.Time
@@ -120,26 +516,24 @@ func (p *postgresGateway) GetThreadById(ctx context.Context, id int64) (entities
 		dbThread.UserCreatedAt,
 		dbThread.UserUpdatedAt,
 	)
-	thread := entities.NewThread(entities.ThreadParams{
-		Id:        dbThread.ID,
-		Title:     dbThread.Title,
-		Content:   dbThread.Content,
-		Image:     image,
-		User:      user,
-		Votes:     dbThread.Votes,
-		CreatedAt: dbThread.CreatedAt.Time,
-		IsDeleted: dbThread.IsDeleted,
-		DeletedAt: deletedAt,
+	return entities.NewThread(entities.ThreadParams{
+		Id:          dbThread.ID,
+		BoardId:     dbThread.BoardID,
+		Title:       dbThread.Title,
+		Content:     dbThread.Content,
+		Image:       image,
+		User:        user,
+		Votes:       dbThread.Votes,
+		CreatedAt:   dbThread.CreatedAt.Time,
+		IsDeleted:   dbThread.IsDeleted,
+		IsHidden:    dbThread.IsHidden,
+		DeletedAt:   deletedAt,
+		DeletedBy:   toStringPtr(dbThread.DeletedBy),
+		EditedAt:    toTimePtr(dbThread.EditedAt),
+		HotScore:    dbThread.HotScore,
+		ActiveAt:    dbThread.ActiveAt.Time,
+		IsLocked:    dbThread.IsLocked,
+		PinnedUntil: toTimePtr(dbThread.PinnedUntil),
+		ArchivedAt:  toTimePtr(dbThread.ArchivedAt),
 	})
-	return thread, nil
-}
-
-func (p *postgresGateway) DeleteThread(ctx context.Context, id int64) error {
-	_, err := p.queries.DeleteThread(ctx, id)
-
-	if errors.Is(err, pgx.ErrNoRows) {
-		return common.ErrNotFound
-	}
-
-	return err
 }
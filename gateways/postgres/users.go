@@ -39,6 +39,16 @@ func (p *postgresGateway) GetUserByAddress(ctx context.Context, address string)
 	return user, nil
 }
 
+func (p *postgresGateway) GetUserStats(ctx context.Context, address string) (entities.UserStats, error) {
+	stats, err := p.queries.GetUserStats(ctx, address)
+
+	if err != nil {
+		return entities.UserStats{}, err
+	}
+
+	return entities.NewUserStats(stats.ThreadCount, stats.CommentCount, stats.Votes), nil
+}
+
 func (p *postgresGateway) UpsertUser(ctx context.Context, address string) error {
 	return p.queries.UpsertUser(ctx, address)
 }
@@ -6,6 +6,7 @@ import (
 
 	"github.com/daochanio/backend/domain/entities"
 	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func (g *postgresGateway) CreateVote(ctx context.Context, vote entities.Vote) error {
@@ -71,3 +72,78 @@ func (g *postgresGateway) AggregateVotes(ctx context.Context, id int64, voteType
 		return fmt.Errorf("invalid vote type: %v", voteType)
 	}
 }
+
+// Votes are keyed by the id of the voted thread or comment, ids the address never voted on are absent
+func (g *postgresGateway) GetVotesByAddress(ctx context.Context, address string, ids []int64, voteType entities.VoteType) (map[int64]entities.VoteValue, error) {
+	votes := map[int64]entities.VoteValue{}
+	switch voteType {
+	case entities.ThreadVote:
+		rows, err := g.queries.GetThreadVotesByAddress(ctx, bindings.GetThreadVotesByAddressParams{
+			Address:   address,
+			ThreadIds: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			votes[row.ThreadID] = toVoteValue(row.Vote)
+		}
+	case entities.CommentVote:
+		rows, err := g.queries.GetCommentVotesByAddress(ctx, bindings.GetCommentVotesByAddressParams{
+			Address:    address,
+			CommentIds: ids,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			votes[row.CommentID] = toVoteValue(row.Vote)
+		}
+	default:
+		return nil, fmt.Errorf("invalid vote type: %v", voteType)
+	}
+	return votes, nil
+}
+
+// Decisions on a vote that was already decided on are ignored so vote messages can be processed more than once
+func (g *postgresGateway) CreateVoteDecision(ctx context.Context, decision entities.VoteDecision) error {
+	var reason *string
+	if decision.Reason() != nil {
+		value := string(*decision.Reason())
+		reason = &value
+	}
+
+	return g.queries.CreateVoteDecision(ctx, bindings.CreateVoteDecisionParams{
+		Type:       string(decision.Type()),
+		TargetID:   decision.TargetId(),
+		Voter:      decision.Voter(),
+		Author:     toText(decision.Author()),
+		Vote:       fromVoteValue(decision.Value()),
+		Reputation: pgtype.Numeric{Int: decision.Reputation(), Valid: true},
+		Accepted:   decision.IsAccepted(),
+		Reason:     toText(reason),
+		VotedAt:    pgtype.Timestamp{Time: decision.VotedAt(), Valid: true},
+	})
+}
+
+func fromVoteValue(value entities.VoteValue) int16 {
+	switch value {
+	case entities.Upvote:
+		return 1
+	case entities.Downvote:
+		return -1
+	default:
+		return 0
+	}
+}
+
+func toVoteValue(vote int16) entities.VoteValue {
+	switch {
+	case vote > 0:
+		return entities.Upvote
+	case vote < 0:
+		return entities.Downvote
+	default:
+		return entities.Unvote
+	}
+}
@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/gateways/postgres/bindings"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func (p *postgresGateway) CreateWebhook(ctx context.Context, url string, events []entities.WebhookEventType, secret string, createdBy string) (entities.Webhook, error) {
+	dbEvents := []string{}
+	for _, event := range events {
+		dbEvents = append(dbEvents, string(event))
+	}
+
+	webhook, err := p.queries.CreateWebhook(ctx, bindings.CreateWebhookParams{
+		Url:       url,
+		Events:    dbEvents,
+		Secret:    secret,
+		CreatedBy: createdBy,
+	})
+
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return toWebhook(webhook), nil
+}
+
+func (p *postgresGateway) GetWebhooks(ctx context.Context) ([]entities.Webhook, error) {
+	dbWebhooks, err := p.queries.GetWebhooks(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := []entities.Webhook{}
+	for _, webhook := range dbWebhooks {
+		webhooks = append(webhooks, toWebhook(webhook))
+	}
+
+	return webhooks, nil
+}
+
+// the enabled webhooks subscribed to the event type
+func (p *postgresGateway) GetActiveWebhooks(ctx context.Context, eventType entities.WebhookEventType) ([]entities.Webhook, error) {
+	dbWebhooks, err := p.queries.GetActiveWebhooksByEvent(ctx, string(eventType))
+
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := []entities.Webhook{}
+	for _, webhook := range dbWebhooks {
+		webhooks = append(webhooks, toWebhook(webhook))
+	}
+
+	return webhooks, nil
+}
+
+// the delivery log of the webhook is deleted with it
+func (p *postgresGateway) DeleteWebhook(ctx context.Context, id int64) error {
+	rows, err := p.queries.DeleteWebhook(ctx, id)
+
+	if err != nil {
+		return err
+	}
+
+	if rows == 0 {
+		return common.ErrNotFound
+	}
+
+	return nil
+}
+
+// re-enables the webhook with a clean failure count
+func (p *postgresGateway) EnableWebhook(ctx context.Context, id int64) (entities.Webhook, error) {
+	webhook, err := p.queries.EnableWebhook(ctx, id)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return entities.Webhook{}, common.ErrNotFound
+	}
+
+	if err != nil {
+		return entities.Webhook{}, err
+	}
+
+	return toWebhook(webhook), nil
+}
+
+// Logs the delivery and tracks the consecutive failures of the webhook together.
+// Returns true if the failure disabled the webhook.
+func (p *postgresGateway) CreateWebhookDelivery(ctx context.Context, delivery entities.WebhookDelivery, maxFailures int64) (bool, error) {
+	tx, err := p.db.Begin(ctx)
+
+	if err != nil {
+		return false, err
+	}
+
+	defer p.rollback(ctx, tx)
+
+	qtx := p.queries.WithTx(tx)
+
+	statusCode := pgtype.Int4{}
+	if code := delivery.StatusCode(); code != nil {
+		statusCode = pgtype.Int4{Int32: *code, Valid: true}
+	}
+
+	if err := qtx.CreateWebhookDelivery(ctx, bindings.CreateWebhookDeliveryParams{
+		WebhookID:  delivery.WebhookId(),
+		EventType:  string(delivery.EventType()),
+		EventID:    delivery.EventId(),
+		StatusCode: statusCode,
+		Error:      delivery.Error(),
+		Attempts:   delivery.Attempts(),
+		Succeeded:  delivery.Succeeded(),
+	}); err != nil {
+		return false, err
+	}
+
+	disabled := false
+	if delivery.Succeeded() {
+		err = qtx.ResetWebhookFailures(ctx, delivery.WebhookId())
+	} else {
+		disabled, err = qtx.IncrementWebhookFailures(ctx, bindings.IncrementWebhookFailuresParams{
+			MaxFailures: maxFailures,
+			ID:          delivery.WebhookId(),
+		})
+	}
+
+	// the webhook was deleted while delivering
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return disabled, tx.Commit(ctx)
+}
+
+// Returns the page of deliveries of the webhook, newest first, and the total number of them
+func (p *postgresGateway) GetWebhookDeliveries(ctx context.Context, webhookId int64, offset int64, limit int64) ([]entities.WebhookDelivery, int64, error) {
+	rows, err := p.queries.GetWebhookDeliveries(ctx, bindings.GetWebhookDeliveriesParams{
+		WebhookID:  webhookId,
+		PageLimit:  limit,
+		PageOffset: offset,
+	})
+
+	if err != nil {
+		return nil, -1, err
+	}
+
+	count := int64(0)
+	deliveries := []entities.WebhookDelivery{}
+	for _, row := range rows {
+		count = row.FullCount
+
+		var statusCode *int32
+		if row.StatusCode.Valid {
+			statusCode = &row.StatusCode.Int32
+		}
+
+		deliveries = append(deliveries, entities.NewWebhookDelivery(entities.WebhookDeliveryParams{
+			Id:         row.ID,
+			WebhookId:  row.WebhookID,
+			EventType:  entities.WebhookEventType(row.EventType),
+			EventId:    row.EventID,
+			StatusCode: statusCode,
+			Error:      row.Error,
+			Attempts:   row.Attempts,
+			Succeeded:  row.Succeeded,
+			CreatedAt:  row.CreatedAt.Time,
+		}))
+	}
+
+	return deliveries, count, nil
+}
+
+func toWebhook(webhook bindings.Webhook) entities.Webhook {
+	events := []entities.WebhookEventType{}
+	for _, event := range webhook.Events {
+		events = append(events, entities.WebhookEventType(event))
+	}
+
+	return entities.NewWebhook(entities.WebhookParams{
+		Id:                  webhook.ID,
+		Url:                 webhook.Url,
+		Events:              events,
+		Secret:              webhook.Secret,
+		CreatedBy:           webhook.CreatedBy,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		DisabledAt:          toTimePtr(webhook.DisabledAt),
+		CreatedAt:           webhook.CreatedAt.Time,
+	})
+}
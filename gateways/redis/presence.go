@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Presence is kept in a sorted set of address:connection members scored by the time each member expires at,
+// so an address stays present until the last of its connections leaves or expires.
+// Members are refreshed by setting them again and the set itself expires once nobody refreshes it for the ttl.
+func (r *redisCacheGateway) SetPresence(ctx context.Context, key string, address string, connection string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	pipe := r.client.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", fmt.Sprint(now.UnixMilli()))
+	added := pipe.ZAdd(ctx, key, redis.Z{
+		Score:  float64(now.Add(ttl).UnixMilli()),
+		Member: presenceMember(address, connection),
+	})
+	pipe.Expire(ctx, key, ttl)
+	members := pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: fmt.Sprint(now.UnixMilli()), Max: "+inf"})
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("error setting presence %v for key %v: %w", address, key, err)
+	}
+
+	return added.Val() > 0 && presentAddresses(members.Val())[address] == 1, nil
+}
+
+func (r *redisCacheGateway) RemovePresence(ctx context.Context, key string, address string, connection string) (bool, error) {
+	pipe := r.client.TxPipeline()
+	removed := pipe.ZRem(ctx, key, presenceMember(address, connection))
+	members := pipe.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: fmt.Sprint(time.Now().UnixMilli()), Max: "+inf"})
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("error removing presence %v for key %v: %w", address, key, err)
+	}
+
+	return removed.Val() > 0 && presentAddresses(members.Val())[address] == 0, nil
+}
+
+func (r *redisCacheGateway) CountPresence(ctx context.Context, key string) (int64, error) {
+	members, err := r.client.ZRangeByScore(ctx, key, &redis.ZRangeBy{Min: fmt.Sprint(time.Now().UnixMilli()), Max: "+inf"}).Result()
+
+	if err != nil {
+		return 0, fmt.Errorf("error counting presence for key %v: %w", key, err)
+	}
+
+	return int64(len(presentAddresses(members))), nil
+}
+
+func presenceMember(address string, connection string) string {
+	return fmt.Sprintf("%v:%v", address, connection)
+}
+
+// the number of connections of each address
+func presentAddresses(members []string) map[string]int {
+	addresses := map[string]int{}
+	for _, member := range members {
+		address, _, _ := strings.Cut(member, ":")
+		addresses[address]++
+	}
+	return addresses
+}
@@ -35,6 +35,77 @@ func (r *redisStreamGateway) PublishVote(ctx context.Context, vote entities.Vote
 	}).Err()
 }
 
+func (r *redisStreamGateway) PublishCommentCreated(ctx context.Context, comment entities.Comment) error {
+	user := comment.User()
+	message, err := json.Marshal(common.CommentCreatedMessage{
+		Id:       comment.Id(),
+		ThreadId: comment.ThreadId(),
+		Address:  user.Address(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error marshalling comment created message: %w", err)
+	}
+
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: common.CommentCreatedStream,
+		ID:     "*",
+		MaxLen: 10000,
+		Values: map[string]any{
+			"body": message,
+		},
+	}).Err()
+}
+
+func (r *redisStreamGateway) PublishThreadCreated(ctx context.Context, thread entities.Thread) error {
+	user := thread.User()
+	message, err := json.Marshal(common.ThreadCreatedMessage{
+		Id:      thread.Id(),
+		BoardId: thread.BoardId(),
+		Address: user.Address(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error marshalling thread created message: %w", err)
+	}
+
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: common.ThreadCreatedStream,
+		ID:     "*",
+		MaxLen: 10000,
+		Values: map[string]any{
+			"body": message,
+		},
+	}).Err()
+}
+
+// a message is published per address in a single round trip
+func (r *redisStreamGateway) PublishReputationUpdated(ctx context.Context, addresses []string) error {
+	_, err := r.client.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, address := range addresses {
+			message, err := json.Marshal(common.ReputationMessage{
+				Address: address,
+			})
+
+			if err != nil {
+				return fmt.Errorf("error marshalling reputation message: %w", err)
+			}
+
+			pipe.XAdd(ctx, &redis.XAddArgs{
+				Stream: common.ReputationStream,
+				ID:     "*",
+				MaxLen: 10000,
+				Values: map[string]any{
+					"body": message,
+				},
+			})
+		}
+		return nil
+	})
+
+	return err
+}
+
 func (r *redisStreamGateway) PublishSignin(ctx context.Context, address string) error {
 	voteJson := common.SigninMessage{
 		Address: address,
@@ -0,0 +1,95 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/redis/go-redis/v9"
+)
+
+func (r *redisStreamGateway) PublishThreadEvent(ctx context.Context, event entities.ThreadEvent) error {
+	message, err := json.Marshal(common.ThreadEventMessage{
+		Type:      event.Type(),
+		ThreadId:  event.ThreadId(),
+		CommentId: event.CommentId(),
+		Votes:     event.Votes(),
+		Viewers:   event.Viewers(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error marshalling thread event message: %w", err)
+	}
+
+	return r.client.XAdd(ctx, &redis.XAddArgs{
+		Stream: common.ThreadEventStream,
+		ID:     "*",
+		MaxLen: 10000,
+		Values: map[string]any{
+			"body": message,
+		},
+	}).Err()
+}
+
+func (r *redisStreamGateway) ReadThreadEvents(ctx context.Context, after string, block time.Duration) ([]entities.ThreadEvent, error) {
+	results, err := r.client.XRead(ctx, &redis.XReadArgs{
+		Streams: []string{common.ThreadEventStream, after},
+		Block:   block,
+		Count:   100,
+	}).Result()
+
+	if err == redis.Nil {
+		return []entities.ThreadEvent{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	events := []entities.ThreadEvent{}
+	for _, result := range results {
+		events = append(events, r.toThreadEvents(ctx, result.Messages)...)
+	}
+
+	return events, nil
+}
+
+func (r *redisStreamGateway) GetThreadEvents(ctx context.Context, after string, limit int64) ([]entities.ThreadEvent, error) {
+	// the parenthesis makes the range exclusive of the given id
+	messages, err := r.client.XRangeN(ctx, common.ThreadEventStream, "("+after, "+", limit).Result()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toThreadEvents(ctx, messages), nil
+}
+
+// malformed messages are logged and skipped so they do not block the messages after them
+func (r *redisStreamGateway) toThreadEvents(ctx context.Context, messages []redis.XMessage) []entities.ThreadEvent {
+	events := []entities.ThreadEvent{}
+	for _, message := range messages {
+		body, ok := message.Values["body"].(string)
+		if !ok {
+			r.logger.Error(ctx).Msgf("invalid thread event message: %v %v", message.ID, message.Values)
+			continue
+		}
+		eventMessage, err := common.Unmarshal[common.ThreadEventMessage]([]byte(body))
+		if err != nil {
+			r.logger.Error(ctx).Err(err).Msgf("error parsing thread event message: %v %v", message.ID, message.Values)
+			continue
+		}
+		events = append(events, entities.NewThreadEvent(entities.ThreadEventParams{
+			Id:        message.ID,
+			Type:      eventMessage.Type,
+			ThreadId:  eventMessage.ThreadId,
+			CommentId: eventMessage.CommentId,
+			Votes:     eventMessage.Votes,
+			Viewers:   eventMessage.Viewers,
+		}))
+	}
+	return events
+}
@@ -0,0 +1,118 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+)
+
+type webhooks struct {
+	logger common.Logger
+	client *http.Client
+	config *gateways.WebhooksConfig
+}
+
+func NewWebhooksGateway(logger common.Logger) gateways.Webhooks {
+	return &webhooks{
+		logger,
+		nil,
+		nil,
+	}
+}
+
+func (w *webhooks) Start(ctx context.Context, config gateways.WebhooksConfig) {
+	w.logger.Info(ctx).Msg("starting webhooks gateway")
+	w.config = &config
+	// urls are supplied by admins but must still not be able to reach internal services
+	w.client = &http.Client{
+		Timeout:   config.Timeout,
+		Transport: common.NewPublicTransport(),
+		// a redirect could send the signed payload somewhere the admin never configured
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func (w *webhooks) Shutdown(ctx context.Context) {
+	w.logger.Info(ctx).Msg("shutting down webhooks gateway")
+	w.client.CloseIdleConnections()
+}
+
+// The payload is signed with HMAC-SHA256 over "<timestamp>.<body>" using the secret of the webhook.
+// Receivers should recompute the signature and reject stale timestamps to prevent replays.
+// Network errors, 429s and 5xx responses are retryable, any other non 2xx response is not.
+func (w *webhooks) SendWebhook(ctx context.Context, webhook entities.Webhook, event entities.WebhookEvent) (int, error) {
+	body, err := json.Marshal(webhookJSON{
+		Id:        event.Id(),
+		Type:      string(event.Type()),
+		CreatedAt: event.CreatedAt(),
+		Data:      event.Data(),
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf("marshal webhook payload error %w", err)
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", webhook.Url(), bytes.NewReader(body))
+
+	if err != nil {
+		return 0, fmt.Errorf("http url %v", webhook.Url())
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-Webhook-Id", event.Id())
+	req.Header.Add("X-Webhook-Event", string(event.Type()))
+	req.Header.Add("X-Webhook-Timestamp", timestamp)
+	req.Header.Add("X-Webhook-Signature", fmt.Sprintf("sha256=%s", sign(webhook.Secret(), timestamp, body)))
+
+	resp, err := w.client.Do(req)
+
+	if err != nil {
+		return 0, fmt.Errorf("http response %v err %v: %w", webhook.Url(), err, common.ErrRetryable)
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return resp.StatusCode, fmt.Errorf("http status code %v %v: %w", resp.StatusCode, webhook.Url(), common.ErrRetryable)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("http invalid status code %v %v", resp.StatusCode, webhook.Url())
+	}
+
+	return resp.StatusCode, nil
+}
+
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+type webhookJSON struct {
+	Id        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"createdAt"`
+	Data      json.RawMessage `json:"data"`
+}
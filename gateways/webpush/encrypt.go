@@ -0,0 +1,128 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/hkdf"
+)
+
+// a single record holds the whole payload so the record size only has to exceed it
+const recordSize uint32 = 4096
+
+// Encrypts the payload for the browser with the aes128gcm content encoding of RFC 8188 as described by RFC 8291.
+// A new ephemeral key pair and salt are used for every message.
+//
+// See: https://www.rfc-editor.org/rfc/rfc8291#section-3.4
+func encrypt(p256dh string, auth string, payload []byte) ([]byte, error) {
+	uaPublic, err := decodeKey(p256dh)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	authSecret, err := decodeKey(auth)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid auth secret: %w", err)
+	}
+
+	// records are padded with at least the delimiter byte and authenticated with a 16 byte tag
+	if len(payload)+1+16 > int(recordSize) {
+		return nil, fmt.Errorf("payload of %v bytes is too large", len(payload))
+	}
+
+	uaKey, err := ecdh.P256().NewPublicKey(uaPublic)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid p256dh key: %w", err)
+	}
+
+	asKey, err := ecdh.P256().GenerateKey(rand.Reader)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ecdhSecret, err := asKey.ECDH(uaKey)
+
+	if err != nil {
+		return nil, err
+	}
+
+	asPublic := asKey.PublicKey().Bytes()
+
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+	ikm, err := expand(hkdf.Extract(sha256.New, ecdhSecret, authSecret), keyInfo, 32)
+
+	if err != nil {
+		return nil, err
+	}
+
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+
+	cek, err := expand(prk, []byte("Content-Encoding: aes128gcm\x00"), 16)
+
+	if err != nil {
+		return nil, err
+	}
+
+	nonce, err := expand(prk, []byte("Content-Encoding: nonce\x00"), 12)
+
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+
+	if err != nil {
+		return nil, err
+	}
+
+	gcm, err := cipher.NewGCM(block)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// the delimiter marks the last and only record
+	plaintext := append(append([]byte{}, payload...), 0x02)
+
+	// salt || record size || key id length || key id (the ephemeral public key) || ciphertext
+	header := make([]byte, 0, 16+4+1+len(asPublic))
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, recordSize)
+	header = append(header, byte(len(asPublic)))
+	header = append(header, asPublic...)
+
+	return gcm.Seal(header, nonce, plaintext, nil), nil
+}
+
+func expand(prk []byte, info []byte, length int) ([]byte, error) {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// browsers encode keys as unpadded base64url but padded values are accepted as well
+func decodeKey(key string) ([]byte, error) {
+	if decoded, err := base64.RawURLEncoding.DecodeString(key); err == nil {
+		return decoded, nil
+	}
+	return base64.URLEncoding.DecodeString(key)
+}
@@ -0,0 +1,177 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type webPush struct {
+	logger     common.Logger
+	client     *http.Client
+	config     *gateways.WebPushConfig
+	privateKey *ecdsa.PrivateKey
+}
+
+func NewWebPushGateway(logger common.Logger) gateways.WebPush {
+	return &webPush{
+		logger,
+		nil,
+		nil,
+		nil,
+	}
+}
+
+func (w *webPush) Start(ctx context.Context, config gateways.WebPushConfig) {
+	w.logger.Info(ctx).Msg("starting web push gateway")
+	w.config = &config
+	// endpoints are supplied by users so they must not be able to reach internal services
+	w.client = &http.Client{
+		Timeout:   config.Timeout,
+		Transport: common.NewPublicTransport(),
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	if config.VAPIDPrivateKey == "" {
+		w.logger.Warn(ctx).Msg("web push is disabled without vapid keys")
+		return
+	}
+
+	privateKey, err := parsePrivateKey(config.VAPIDPrivateKey)
+
+	if err != nil {
+		panic(err)
+	}
+
+	w.privateKey = privateKey
+}
+
+func (w *webPush) Shutdown(ctx context.Context) {
+	w.logger.Info(ctx).Msg("shutting down web push gateway")
+	w.client.CloseIdleConnections()
+}
+
+// Sends the payload encrypted per RFC 8291 and authenticated with VAPID per RFC 8292.
+// Push services answer 404 or 410 for subscriptions that no longer exist.
+func (w *webPush) SendPush(ctx context.Context, subscription entities.PushSubscription, notification entities.Notification) error {
+	if w.privateKey == nil {
+		return errors.New("web push is not configured")
+	}
+
+	payload, err := json.Marshal(pushJSON{
+		Type:      string(notification.Type()),
+		Actor:     notification.Actor(),
+		ThreadId:  fmt.Sprint(notification.ThreadId()),
+		CommentId: fmt.Sprint(notification.CommentId()),
+	})
+
+	if err != nil {
+		return fmt.Errorf("marshal push payload error %w", err)
+	}
+
+	body, err := encrypt(subscription.P256dh(), subscription.Auth(), payload)
+
+	if err != nil {
+		return fmt.Errorf("encrypt push payload error %w", err)
+	}
+
+	token, err := w.vapidToken(subscription.Endpoint())
+
+	if err != nil {
+		return fmt.Errorf("vapid token error %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", subscription.Endpoint(), bytes.NewReader(body))
+
+	if err != nil {
+		return fmt.Errorf("http url %v", subscription.Endpoint())
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("vapid t=%s, k=%s", token, w.config.VAPIDPublicKey))
+	req.Header.Add("Content-Type", "application/octet-stream")
+	req.Header.Add("Content-Encoding", "aes128gcm")
+	req.Header.Add("TTL", strconv.FormatInt(int64(w.config.TTL.Seconds()), 10))
+
+	resp, err := w.client.Do(req)
+
+	if err != nil {
+		return fmt.Errorf("http response %v err %v: %w", subscription.Endpoint(), err, common.ErrRetryable)
+	}
+
+	defer resp.Body.Close()
+
+	// drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return fmt.Errorf("http status code %v %v: %w", resp.StatusCode, subscription.Endpoint(), common.ErrGone)
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500 {
+		return fmt.Errorf("http status code %v %v: %w", resp.StatusCode, subscription.Endpoint(), common.ErrRetryable)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("http invalid status code %v %v", resp.StatusCode, subscription.Endpoint())
+	}
+
+	return nil
+}
+
+// the audience of the token is the origin of the push service
+func (w *webPush) vapidToken(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+
+	if err != nil {
+		return "", err
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodES256, jwt.MapClaims{
+		"aud": fmt.Sprintf("%s://%s", u.Scheme, u.Host),
+		"exp": time.Now().Add(time.Hour * 12).Unix(),
+		"sub": w.config.VAPIDSubject,
+	}).SignedString(w.privateKey)
+}
+
+func parsePrivateKey(key string) (*ecdsa.PrivateKey, error) {
+	d, err := base64.RawURLEncoding.DecodeString(key)
+
+	if err != nil || len(d) != 32 {
+		return nil, fmt.Errorf("invalid vapid private key")
+	}
+
+	curve := elliptic.P256()
+	privateKey := &ecdsa.PrivateKey{
+		PublicKey: ecdsa.PublicKey{Curve: curve},
+		D:         new(big.Int).SetBytes(d),
+	}
+	privateKey.PublicKey.X, privateKey.PublicKey.Y = curve.ScalarBaseMult(d)
+
+	return privateKey, nil
+}
+
+// kept small since push services limit payloads to 4kb
+type pushJSON struct {
+	Type      string `json:"type"`
+	Actor     string `json:"actor"`
+	ThreadId  string `json:"threadId"`
+	CommentId string `json:"commentId"`
+}
@@ -0,0 +1,240 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daochanio/backend/common"
+	"github.com/daochanio/backend/domain/entities"
+	"github.com/daochanio/backend/domain/gateways"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/hkdf"
+)
+
+// a browser subscribed to a mock push service
+type mockBrowser struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newMockBrowser(t *testing.T) mockBrowser {
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	if _, err := rand.Read(auth); err != nil {
+		t.Fatal(err)
+	}
+	return mockBrowser{key, auth}
+}
+
+func (b mockBrowser) subscription(endpoint string) entities.PushSubscription {
+	return entities.NewPushSubscription(entities.PushSubscriptionParams{
+		Endpoint: endpoint,
+		Address:  "0x0000000000000000000000000000000000000001",
+		P256dh:   base64.RawURLEncoding.EncodeToString(b.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(b.auth),
+	})
+}
+
+// decrypts the body the way a browser does
+func (b mockBrowser) decrypt(t *testing.T, body []byte) []byte {
+	salt, idLen := body[:16], int(body[20])
+	asKey, err := ecdh.P256().NewPublicKey(body[21 : 21+idLen])
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhSecret, err := b.key.ECDH(asKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyInfo := append([]byte("WebPush: info\x00"), b.key.PublicKey().Bytes()...)
+	keyInfo = append(keyInfo, asKey.Bytes()...)
+	ikm := mustExpand(t, hkdf.Extract(sha256.New, ecdhSecret, b.auth), keyInfo, 32)
+	prk := hkdf.Extract(sha256.New, ikm, salt)
+
+	block, err := aes.NewCipher(mustExpand(t, prk, []byte("Content-Encoding: aes128gcm\x00"), 16))
+	if err != nil {
+		t.Fatal(err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := gcm.Open(nil, mustExpand(t, prk, []byte("Content-Encoding: nonce\x00"), 12), body[21+idLen:], nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plaintext[len(plaintext)-1] != 0x02 {
+		t.Fatalf("expected the last record delimiter")
+	}
+	return plaintext[:len(plaintext)-1]
+}
+
+func mustExpand(t *testing.T, prk []byte, info []byte, length int) []byte {
+	out := make([]byte, length)
+	if _, err := io.ReadFull(hkdf.Expand(sha256.New, prk, info), out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func newTestGateway(t *testing.T) (gateways.WebPush, *ecdsa.PrivateKey) {
+	ctx := context.Background()
+	vapidKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	logger := common.NewLogger()
+	logger.Start(ctx, common.LoggerConfig{Env: "test"})
+
+	gateway := NewWebPushGateway(logger)
+	gateway.Start(ctx, gateways.WebPushConfig{
+		VAPIDPublicKey:  base64.RawURLEncoding.EncodeToString(elliptic.Marshal(elliptic.P256(), vapidKey.X, vapidKey.Y)),
+		VAPIDPrivateKey: base64.RawURLEncoding.EncodeToString(vapidKey.D.FillBytes(make([]byte, 32))),
+		VAPIDSubject:    "mailto:test@daochan.io",
+		TTL:             time.Hour,
+		Timeout:         time.Second * 5,
+	})
+	t.Cleanup(func() { gateway.Shutdown(ctx) })
+
+	// the mock push services listen on loopback
+	gateway.(*webPush).client.Transport = http.DefaultTransport
+
+	return gateway, vapidKey
+}
+
+func TestSendPush(t *testing.T) {
+	gateway, vapidKey := newTestGateway(t)
+	browser := newMockBrowser(t)
+
+	var payload pushJSON
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Encoding") != "aes128gcm" {
+			t.Errorf("expected aes128gcm content encoding but got %v", r.Header.Get("Content-Encoding"))
+		}
+
+		// vapid t=<jwt>, k=<public key>
+		authorization := strings.TrimPrefix(r.Header.Get("Authorization"), "vapid t=")
+		token, _, _ := strings.Cut(authorization, ", k=")
+		claims := jwt.MapClaims{}
+		if _, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) {
+			return &vapidKey.PublicKey, nil
+		}, jwt.WithValidMethods([]string{"ES256"})); err != nil {
+			t.Errorf("invalid vapid token: %v", err)
+		}
+		if claims["aud"] != "http://"+r.Host {
+			t.Errorf("expected audience http://%v but got %v", r.Host, claims["aud"])
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(browser.decrypt(t, body), &payload); err != nil {
+			t.Errorf("invalid payload: %v", err)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	err := gateway.SendPush(context.Background(), browser.subscription(server.URL), entities.NewNotification(entities.NotificationParams{
+		Type:      entities.ReplyNotification,
+		Actor:     "0x0000000000000000000000000000000000000002",
+		ThreadId:  1,
+		CommentId: 2,
+	}))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := pushJSON{Type: "reply", Actor: "0x0000000000000000000000000000000000000002", ThreadId: "1", CommentId: "2"}
+	if payload != expected {
+		t.Errorf("expected payload %+v but got %+v", expected, payload)
+	}
+}
+
+func TestSendPushStatusCodes(t *testing.T) {
+	gateway, _ := newTestGateway(t)
+	browser := newMockBrowser(t)
+
+	for status, expected := range map[int]error{
+		http.StatusNotFound:            common.ErrGone,
+		http.StatusGone:                common.ErrGone,
+		http.StatusTooManyRequests:     common.ErrRetryable,
+		http.StatusInternalServerError: common.ErrRetryable,
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		err := gateway.SendPush(context.Background(), browser.subscription(server.URL), entities.Notification{})
+		server.Close()
+
+		if !errors.Is(err, expected) {
+			t.Errorf("expected %v for status %v but got %v", expected, status, err)
+		}
+	}
+}
+
+func TestEncryptRejectsLargePayloads(t *testing.T) {
+	browser := newMockBrowser(t)
+	subscription := browser.subscription("https://push.example.com")
+
+	if _, err := encrypt(subscription.P256dh(), subscription.Auth(), bytes.Repeat([]byte("a"), 4096)); err == nil {
+		t.Errorf("expected an error for a payload larger than a record")
+	}
+}
+
+func TestSendPushDoesNotFollowRedirects(t *testing.T) {
+	gateway, _ := newTestGateway(t)
+	browser := newMockBrowser(t)
+
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected the redirect to not be followed")
+	}))
+	defer target.Close()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, target.URL, http.StatusTemporaryRedirect)
+	}))
+	defer server.Close()
+
+	if err := gateway.SendPush(context.Background(), browser.subscription(server.URL), entities.Notification{}); err == nil {
+		t.Errorf("expected an error for a redirect")
+	}
+}
+
+func TestSendPushRefusesLoopback(t *testing.T) {
+	gateway, _ := newTestGateway(t)
+	gateway.(*webPush).client.Transport = common.NewPublicTransport()
+	browser := newMockBrowser(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected the loopback endpoint to not be reached")
+	}))
+	defer server.Close()
+
+	if err := gateway.SendPush(context.Background(), browser.subscription(server.URL), entities.Notification{}); err == nil {
+		t.Errorf("expected an error for a loopback endpoint")
+	}
+}